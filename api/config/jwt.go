@@ -0,0 +1,113 @@
+package config
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"strings"
+	"time"
+)
+
+// minHmacSecretLength is the shortest HS256 secret accepted, matching the size of the hash
+const minHmacSecretLength = 32
+
+// LoadTokenKeys sets up the keys access tokens are signed and verified with.
+//
+// JWT_KEYS is a comma-separated list of keys in the form <kid>:<alg>:<key>. For HS256 the key is the
+// secret itself. For EdDSA and RS256 it is the path to a PEM file: a private key can sign and verify
+// tokens, and a public key can only verify them. JWT_ACTIVE_KEY is the kid of the key new tokens are
+// signed with, and defaults to the first key. JWT_ISSUER and JWT_AUDIENCE set the iss and aud claims,
+// and JWT_ACCESS_TOKEN_TTL_MINUTES sets how long access tokens last
+func LoadTokenKeys() (*helpers.TokenKeys, error) {
+	definitions := strings.Split(os.Getenv("JWT_KEYS"), ",")
+
+	var keys []helpers.SigningKey
+	for _, definition := range definitions {
+		definition = strings.TrimSpace(definition)
+		if definition == "" {
+			continue
+		}
+
+		key, err := parseSigningKey(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("JWT_KEYS is not set")
+	}
+
+	activeKeyId := os.Getenv("JWT_ACTIVE_KEY")
+	if activeKeyId == "" {
+		activeKeyId = keys[0].ID
+	}
+
+	return helpers.NewTokenKeys(
+		keys,
+		activeKeyId,
+		envString("JWT_ISSUER", "eigakanban"),
+		envString("JWT_AUDIENCE", "eigakanban"),
+		time.Duration(envInt("JWT_ACCESS_TOKEN_TTL_MINUTES", 60))*time.Minute,
+	)
+}
+
+// parseSigningKey parses a single <kid>:<alg>:<key> definition from JWT_KEYS
+func parseSigningKey(definition string) (helpers.SigningKey, error) {
+	parts := strings.SplitN(definition, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return helpers.SigningKey{}, errors.New("JWT_KEYS entries must look like <kid>:<alg>:<key>")
+	}
+
+	keyId, alg, value := parts[0], parts[1], parts[2]
+
+	switch alg {
+	case jwt.SigningMethodHS256.Alg():
+		if len(value) < minHmacSecretLength {
+			return helpers.SigningKey{}, fmt.Errorf("HS256 secret for key %q must be at least %d characters", keyId, minHmacSecretLength)
+		}
+		secret := []byte(value)
+		return helpers.SigningKey{ID: keyId, Method: jwt.SigningMethodHS256, SignKey: secret, VerifyKey: secret}, nil
+	case jwt.SigningMethodEdDSA.Alg():
+		pem, err := os.ReadFile(value)
+		if err != nil {
+			return helpers.SigningKey{}, fmt.Errorf("couldn't read key %q: %w", keyId, err)
+		}
+		if private, err := jwt.ParseEdPrivateKeyFromPEM(pem); err == nil {
+			return helpers.SigningKey{ID: keyId, Method: jwt.SigningMethodEdDSA, SignKey: private, VerifyKey: private.(ed25519.PrivateKey).Public()}, nil
+		}
+		public, err := jwt.ParseEdPublicKeyFromPEM(pem)
+		if err != nil {
+			return helpers.SigningKey{}, fmt.Errorf("couldn't parse key %q: %w", keyId, err)
+		}
+		return helpers.SigningKey{ID: keyId, Method: jwt.SigningMethodEdDSA, VerifyKey: public}, nil
+	case jwt.SigningMethodRS256.Alg():
+		pem, err := os.ReadFile(value)
+		if err != nil {
+			return helpers.SigningKey{}, fmt.Errorf("couldn't read key %q: %w", keyId, err)
+		}
+		if private, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+			return helpers.SigningKey{ID: keyId, Method: jwt.SigningMethodRS256, SignKey: private, VerifyKey: &private.PublicKey}, nil
+		}
+		public, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return helpers.SigningKey{}, fmt.Errorf("couldn't parse key %q: %w", keyId, err)
+		}
+		return helpers.SigningKey{ID: keyId, Method: jwt.SigningMethodRS256, VerifyKey: public}, nil
+	default:
+		return helpers.SigningKey{}, fmt.Errorf("unsupported algorithm %q for key %q", alg, keyId)
+	}
+}
+
+// envString reads a string from the environment, falling back to a default when unset
+func envString(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
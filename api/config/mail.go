@@ -0,0 +1,75 @@
+package config
+
+import (
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+type AccountEmailConfig struct {
+	// AppURL is where the app is served from. Links in emails point to pages under it
+	AppURL string
+	// PasswordResetTTL is how long a password reset link can be used for
+	PasswordResetTTL time.Duration
+	// EmailVerificationTTL is how long an email verification link can be used for
+	EmailVerificationTTL time.Duration
+	// UnverifiedAccess is how much users can do before they verify their email address
+	UnverifiedAccess types.UnverifiedAccess
+}
+
+// LoadMailer sets up the mailer named by MAIL_DRIVER. The log driver is used by default and writes emails
+// to the server log. The file driver appends them to MAIL_FILE instead. The smtp driver sends them through
+// SMTP_HOST and SMTP_PORT, authenticating with SMTP_USERNAME and SMTP_PASSWORD if they are set
+func LoadMailer() (services.Mailer, error) {
+	from := envString("MAIL_FROM", "eigakanban <noreply@localhost>")
+
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "", "log":
+		return services.NewLogMailer(os.Stderr, from), nil
+	case "file":
+		path := envString("MAIL_FILE", "mail.log")
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't open mail file: %w", err)
+		}
+		return services.NewLogMailer(file, from), nil
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, errors.New("SMTP_HOST is not set")
+		}
+		return services.NewSmtpMailer(host, envInt("SMTP_PORT", 587), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
+
+// LoadAccountEmailConfig reads the settings for emails about users' accounts. UNVERIFIED_USER_ACCESS is full,
+// read_only or none, and defaults to read_only
+func LoadAccountEmailConfig() AccountEmailConfig {
+	return AccountEmailConfig{
+		AppURL:               strings.TrimSuffix(envString("APP_URL", "http://localhost:5173"), "/"),
+		PasswordResetTTL:     time.Duration(envInt("PASSWORD_RESET_TTL_MINUTES", 60)) * time.Minute,
+		EmailVerificationTTL: time.Duration(envInt("EMAIL_VERIFICATION_TTL_HOURS", 48)) * time.Hour,
+		UnverifiedAccess:     envUnverifiedAccess("UNVERIFIED_USER_ACCESS", types.UnverifiedAccessReadOnly),
+	}
+}
+
+// envUnverifiedAccess reads an unverified access level from the environment, falling back to a default when
+// unset or invalid
+func envUnverifiedAccess(key string, fallback types.UnverifiedAccess) types.UnverifiedAccess {
+	switch access := types.UnverifiedAccess(os.Getenv(key)); access {
+	case "":
+		return fallback
+	case types.UnverifiedAccessFull, types.UnverifiedAccessReadOnly, types.UnverifiedAccessNone:
+		return access
+	default:
+		log.Printf("Ignoring invalid value for %s: %q", key, access)
+		return fallback
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"codeberg.org/sporiff/eigakanban/services"
+	"fmt"
+	"os"
+)
+
+// LoadMetadataProvider sets up the provider named by METADATA_PROVIDER. The TMDB provider is used
+// by default. The fixtures provider serves recorded responses from METADATA_FIXTURES_DIR and needs
+// neither an API key nor network access
+func LoadMetadataProvider() (services.MetadataProvider, error) {
+	switch provider := os.Getenv("METADATA_PROVIDER"); provider {
+	case "", "tmdb":
+		tmdbClient, err := LoadTmdbConfig()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't set up TMDB client: %w", err)
+		}
+		return services.NewTmdbProvider(tmdbClient), nil
+	case "fixtures":
+		dir := os.Getenv("METADATA_FIXTURES_DIR")
+		if dir == "" {
+			dir = "fixtures/tmdb"
+		}
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("couldn't open fixtures directory: %w", err)
+		}
+		return services.NewFixtureProvider(dir), nil
+	default:
+		return nil, fmt.Errorf("unknown metadata provider %q", provider)
+	}
+}
@@ -2,9 +2,26 @@ package config
 
 import (
 	tmdb "github.com/cyruzin/golang-tmdb"
+	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
+type TmdbRefreshConfig struct {
+	// MaxAge is how old an item's metadata can get before it is fetched again
+	MaxAge time.Duration
+	// RequestInterval is the minimum time between two TMDB requests made by the refresh job
+	RequestInterval time.Duration
+}
+
+type TmdbCacheConfig struct {
+	// TTL is how long a cached TMDB response is served before TMDB is asked again
+	TTL time.Duration
+	// MaxStale is how long a cached TMDB response is kept to be served when TMDB can't be reached
+	MaxStale time.Duration
+}
+
 func LoadTmdbConfig() (*tmdb.Client, error) {
 	tmdbClient, err := tmdb.Init(os.Getenv("TMDB_API_KEY"))
 	if err != nil {
@@ -15,3 +32,33 @@ func LoadTmdbConfig() (*tmdb.Client, error) {
 
 	return tmdbClient, nil
 }
+
+func LoadTmdbRefreshConfig() TmdbRefreshConfig {
+	return TmdbRefreshConfig{
+		MaxAge:          time.Duration(envInt("TMDB_REFRESH_MAX_AGE_DAYS", 30)) * 24 * time.Hour,
+		RequestInterval: time.Duration(envInt("TMDB_REFRESH_REQUEST_INTERVAL_MS", 250)) * time.Millisecond,
+	}
+}
+
+func LoadTmdbCacheConfig() TmdbCacheConfig {
+	return TmdbCacheConfig{
+		TTL:      time.Duration(envInt("TMDB_CACHE_TTL_MINUTES", 24*60)) * time.Minute,
+		MaxStale: time.Duration(envInt("TMDB_CACHE_MAX_STALE_DAYS", 7)) * 24 * time.Hour,
+	}
+}
+
+// envInt reads a positive integer from the environment, falling back to a default when unset or invalid
+func envInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Ignoring invalid value for %s: %q", key, value)
+		return fallback
+	}
+
+	return parsed
+}
@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE list_items ADD COLUMN rank TEXT COLLATE "C";
+
+-- Spread the existing items out in their current order. Keys are hexadecimal, which is a
+-- subset of the base-36 alphabet used by the application, with trailing zeros trimmed
+UPDATE list_items
+SET rank = ranked.rank
+FROM (
+    SELECT
+        list_item_id,
+        RTRIM(LPAD(TO_HEX(ROW_NUMBER() OVER (PARTITION BY list_id, status_id ORDER BY position, list_item_id) * 4096), 8, '0'), '0') AS rank
+    FROM list_items
+) ranked
+WHERE list_items.list_item_id = ranked.list_item_id;
+
+ALTER TABLE list_items ALTER COLUMN rank SET NOT NULL;
+
+ALTER TABLE list_items
+    ADD CONSTRAINT list_items_list_id_status_id_rank_key UNIQUE (list_id, status_id, rank) DEFERRABLE INITIALLY IMMEDIATE;
+
+DROP INDEX idx_list_items_list_id_position;
+
+DROP INDEX idx_list_items_prev_item_id;
+
+DROP INDEX idx_list_items_next_item_id;
+
+ALTER TABLE list_items DROP COLUMN position;
+
+ALTER TABLE list_items DROP COLUMN prev_item_id;
+
+ALTER TABLE list_items DROP COLUMN next_item_id;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE list_items ADD COLUMN position INT;
+
+ALTER TABLE list_items ADD COLUMN prev_item_id BIGINT REFERENCES list_items (list_item_id) ON DELETE SET NULL;
+
+ALTER TABLE list_items ADD COLUMN next_item_id BIGINT REFERENCES list_items (list_item_id) ON DELETE SET NULL;
+
+UPDATE list_items
+SET
+    position = ordered.position,
+    prev_item_id = ordered.prev_id,
+    next_item_id = ordered.next_id
+FROM (
+    SELECT
+        list_item_id,
+        ROW_NUMBER() OVER w - 1 AS position,
+        LAG(list_item_id) OVER w AS prev_id,
+        LEAD(list_item_id) OVER w AS next_id
+    FROM list_items
+    WINDOW w AS (PARTITION BY list_id, status_id ORDER BY rank)
+) ordered
+WHERE list_items.list_item_id = ordered.list_item_id;
+
+ALTER TABLE list_items ALTER COLUMN position SET NOT NULL;
+
+CREATE INDEX idx_list_items_list_id_position ON list_items (list_id, position);
+
+CREATE INDEX idx_list_items_prev_item_id ON list_items (status_id, prev_item_id);
+
+CREATE INDEX idx_list_items_next_item_id ON list_items (status_id, next_item_id);
+
+ALTER TABLE list_items DROP CONSTRAINT list_items_list_id_status_id_rank_key;
+
+ALTER TABLE list_items DROP COLUMN rank;
+-- +goose StatementEnd
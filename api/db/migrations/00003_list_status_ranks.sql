@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE list_statuses ADD COLUMN rank TEXT COLLATE "C";
+
+-- Keep the existing column order, which followed the date each status was added to the list
+UPDATE list_statuses
+SET rank = ranked.rank
+FROM (
+    SELECT
+        list_status_id,
+        RTRIM(LPAD(TO_HEX(ROW_NUMBER() OVER (PARTITION BY list_id ORDER BY created_date, list_status_id) * 4096), 8, '0'), '0') AS rank
+    FROM list_statuses
+) ranked
+WHERE list_statuses.list_status_id = ranked.list_status_id;
+
+ALTER TABLE list_statuses ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX idx_list_statuses_list_id_rank ON list_statuses (list_id, rank);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_list_statuses_list_id_rank;
+
+ALTER TABLE list_statuses DROP COLUMN rank;
+-- +goose StatementEnd
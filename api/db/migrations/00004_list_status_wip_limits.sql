@@ -0,0 +1,8 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE list_statuses ADD COLUMN wip_limit INTEGER CHECK (wip_limit > 0);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE list_statuses DROP COLUMN wip_limit;
+-- +goose StatementEnd
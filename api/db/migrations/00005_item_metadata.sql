@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE items
+    ADD COLUMN tmdb_id BIGINT UNIQUE,
+    ADD COLUMN imdb_id TEXT,
+    ADD COLUMN release_date DATE,
+    ADD COLUMN runtime INTEGER,
+    ADD COLUMN overview TEXT,
+    ADD COLUMN original_language TEXT,
+    ADD COLUMN poster_path TEXT,
+    ADD COLUMN backdrop_path TEXT,
+    ADD COLUMN genres TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_items_imdb_id ON items (imdb_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_items_imdb_id;
+
+ALTER TABLE items
+    DROP COLUMN tmdb_id,
+    DROP COLUMN imdb_id,
+    DROP COLUMN release_date,
+    DROP COLUMN runtime,
+    DROP COLUMN overview,
+    DROP COLUMN original_language,
+    DROP COLUMN poster_path,
+    DROP COLUMN backdrop_path,
+    DROP COLUMN genres;
+-- +goose StatementEnd
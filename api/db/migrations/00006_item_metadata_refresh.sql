@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE items ADD COLUMN metadata_refreshed_at TIMESTAMP WITH TIME ZONE;
+
+-- Items imported from TMDB were fetched when they were created
+UPDATE items
+SET metadata_refreshed_at = created_date
+WHERE tmdb_id IS NOT NULL;
+
+CREATE INDEX idx_items_metadata_refreshed_at ON items (metadata_refreshed_at) WHERE tmdb_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_items_metadata_refreshed_at;
+
+ALTER TABLE items DROP COLUMN metadata_refreshed_at;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tmdb_cache (
+                            cache_key TEXT PRIMARY KEY,
+                            response JSONB NOT NULL,
+                            fetched_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tmdb_cache_fetched_date ON tmdb_cache (fetched_date);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE tmdb_cache;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ratings are out of five stars in half-star steps
+ALTER TABLE reviews
+    ADD COLUMN rating NUMERIC(2, 1) CHECK (rating BETWEEN 0.5 AND 5 AND rating * 2 = TRUNC(rating * 2)),
+    ADD COLUMN updated_date TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE reviews ALTER COLUMN content SET DEFAULT '';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reviews ALTER COLUMN content DROP DEFAULT;
+
+ALTER TABLE reviews
+    DROP COLUMN rating,
+    DROP COLUMN updated_date;
+-- +goose StatementEnd
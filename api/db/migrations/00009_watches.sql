@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A diary entry for each time a user watches an item. Unlike reviews, a user can log an item any number of times
+CREATE TABLE watches (
+                         watch_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                         uuid UUID DEFAULT gen_random_uuid () UNIQUE,
+                         user_id BIGINT NOT NULL,
+                         item_id BIGINT NOT NULL,
+                         review_id BIGINT,
+                         watched_date DATE NOT NULL,
+                         rewatch BOOLEAN NOT NULL DEFAULT FALSE,
+                         rating NUMERIC(2, 1) CHECK (rating BETWEEN 0.5 AND 5 AND rating * 2 = TRUNC(rating * 2)),
+                         location TEXT,
+                         format TEXT,
+                         created_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                         updated_date TIMESTAMP WITH TIME ZONE,
+                         FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE,
+                         FOREIGN KEY (item_id) REFERENCES items (item_id) ON DELETE CASCADE,
+                         FOREIGN KEY (review_id) REFERENCES reviews (review_id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_watches_user_watched_date ON watches (user_id, watched_date DESC);
+CREATE INDEX idx_watches_item_id ON watches (item_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE watches;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Imports that run in the background. Each row of the uploaded files is stored so that a job can
+-- pick up where it left off after a restart or a failure
+CREATE TABLE import_jobs (
+                             import_job_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                             uuid UUID DEFAULT gen_random_uuid () UNIQUE,
+                             user_id BIGINT NOT NULL,
+                             source TEXT NOT NULL,
+                             state TEXT NOT NULL DEFAULT 'pending' CHECK (state IN ('pending', 'running', 'completed', 'failed')),
+                             list_id BIGINT,
+                             status_id BIGINT,
+                             error TEXT,
+                             created_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             started_date TIMESTAMP WITH TIME ZONE,
+                             finished_date TIMESTAMP WITH TIME ZONE,
+                             FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE,
+                             FOREIGN KEY (list_id) REFERENCES lists (list_id) ON DELETE SET NULL,
+                             FOREIGN KEY (status_id) REFERENCES statuses (status_id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_import_jobs_user_id ON import_jobs (user_id);
+CREATE INDEX idx_import_jobs_unfinished ON import_jobs (import_job_id) WHERE state IN ('pending', 'running');
+
+-- outcome is NULL until the row has been processed
+CREATE TABLE import_job_rows (
+                                 import_job_row_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                                 import_job_id BIGINT NOT NULL,
+                                 file TEXT NOT NULL,
+                                 line INTEGER NOT NULL,
+                                 kind TEXT NOT NULL CHECK (kind IN ('rating', 'watchlist')),
+                                 external_id TEXT NOT NULL,
+                                 title TEXT NOT NULL DEFAULT '',
+                                 year TEXT NOT NULL DEFAULT '',
+                                 rating NUMERIC(2, 1),
+                                 outcome TEXT CHECK (outcome IN ('created', 'skipped', 'unmatched')),
+                                 reason TEXT,
+                                 FOREIGN KEY (import_job_id) REFERENCES import_jobs (import_job_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_import_job_rows_job_id ON import_job_rows (import_job_id, import_job_row_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE import_job_rows;
+
+DROP TABLE import_jobs;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A session is a refresh token family. Each rotated token carries the details of the client that used it
+ALTER TABLE refresh_tokens ADD COLUMN device_name TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN user_agent TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN ip_address TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE refresh_tokens SET last_used_at = created_at;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens DROP COLUMN last_used_at;
+ALTER TABLE refresh_tokens DROP COLUMN ip_address;
+ALTER TABLE refresh_tokens DROP COLUMN user_agent;
+ALTER TABLE refresh_tokens DROP COLUMN device_name;
+-- +goose StatementEnd
@@ -0,0 +1,86 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE roles (
+                       role_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                       name TEXT NOT NULL UNIQUE,
+                       description TEXT NOT NULL DEFAULT '',
+                       created_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE permissions (
+                             permission_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                             name TEXT NOT NULL UNIQUE,
+                             description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+                                  role_id BIGINT NOT NULL,
+                                  permission_id BIGINT NOT NULL,
+                                  FOREIGN KEY (role_id) REFERENCES roles (role_id) ON DELETE CASCADE,
+                                  FOREIGN KEY (permission_id) REFERENCES permissions (permission_id) ON DELETE CASCADE,
+                                  PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE user_roles (
+                            user_id BIGINT NOT NULL,
+                            role_id BIGINT NOT NULL,
+                            created_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                            FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE,
+                            FOREIGN KEY (role_id) REFERENCES roles (role_id) ON DELETE CASCADE,
+                            PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles (role_id);
+
+INSERT INTO roles (name, description)
+VALUES
+    ('admin', 'Manages users, roles and the item catalogue'),
+    ('moderator', 'Keeps the item catalogue and reviews tidy'),
+    ('member', 'A regular user');
+
+INSERT INTO permissions (name, description)
+VALUES
+    ('users:list', 'List every user'),
+    ('users:edit', 'Edit any user''s details'),
+    ('users:delete', 'Delete any user'),
+    ('roles:assign', 'Assign roles to users and remove them'),
+    ('items:edit', 'Edit items in the catalogue'),
+    ('items:refresh', 'Refresh item metadata from TMDB'),
+    ('reviews:moderate', 'Delete any user''s reviews');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+JOIN permissions p ON
+    r.name = 'admin'
+    OR (r.name = 'moderator' AND p.name IN ('items:edit', 'items:refresh', 'reviews:moderate'));
+
+-- Everyone is a member, and superusers become admins
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.user_id, r.role_id
+FROM users u
+JOIN roles r ON r.name = 'member' OR (r.name = 'admin' AND u.superuser);
+
+DROP INDEX idx_users_superuser;
+ALTER TABLE users DROP COLUMN superuser;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN superuser BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX idx_users_superuser ON users(uuid, superuser);
+
+UPDATE users u
+SET superuser = TRUE
+FROM user_roles ur
+JOIN roles r ON r.role_id = ur.role_id
+WHERE ur.user_id = u.user_id
+  AND r.name = 'admin';
+
+DROP TABLE user_roles;
+
+DROP TABLE role_permissions;
+
+DROP TABLE permissions;
+
+DROP TABLE roles;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tokens emailed to users who have forgotten their password. Only a hash of each token is stored, and
+-- each can only be used once
+CREATE TABLE password_reset_tokens (
+                                       token_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                                       user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                       token_hash TEXT NOT NULL UNIQUE,
+                                       expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                       used_at TIMESTAMP WITH TIME ZONE,
+                                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+CREATE INDEX idx_password_reset_tokens_expires_at ON password_reset_tokens (expires_at);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE password_reset_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- email_verified_at is NULL until the user follows the link emailed to them, and is cleared when the
+-- email changes. Existing addresses were trusted before verification was added, so they count as verified
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users SET email_verified_at = created_date;
+
+-- Tokens emailed to users to verify their address. Each token is for the address it was sent to, so a
+-- link sent before the email changed can't verify the new address
+CREATE TABLE email_verification_tokens (
+                                           token_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                                           user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                           email TEXT NOT NULL,
+                                           token_hash TEXT NOT NULL UNIQUE,
+                                           expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                           created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
+CREATE INDEX idx_email_verification_tokens_expires_at ON email_verification_tokens (expires_at);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE email_verification_tokens;
+
+ALTER TABLE users DROP COLUMN email_verified_at;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- totp_secret is set when a user starts enrolling, and two-factor authentication is only on once
+-- totp_enabled_at is set by entering a code from it. totp_last_used_step is the time step of the last code
+-- accepted, so that a code can't be used twice
+ALTER TABLE users ADD COLUMN totp_secret TEXT;
+ALTER TABLE users ADD COLUMN totp_enabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN totp_last_used_step BIGINT;
+
+-- One-time codes for logging in without the authenticator. Only hashes are stored
+CREATE TABLE recovery_codes (
+                                recovery_code_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                                user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                code_hash TEXT NOT NULL,
+                                used_at TIMESTAMP WITH TIME ZONE,
+                                created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                UNIQUE (user_id, code_hash)
+);
+
+-- Logins waiting for a second factor. The challenge token is given out once the password has been checked,
+-- and is exchanged for a session once a code is entered
+CREATE TABLE mfa_challenges (
+                                mfa_challenge_id BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+                                user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                token_hash TEXT NOT NULL UNIQUE,
+                                device_name TEXT,
+                                attempts INTEGER NOT NULL DEFAULT 0,
+                                expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_mfa_challenges_expires_at ON mfa_challenges (expires_at);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE mfa_challenges;
+
+DROP TABLE recovery_codes;
+
+ALTER TABLE users DROP COLUMN totp_last_used_step;
+ALTER TABLE users DROP COLUMN totp_enabled_at;
+ALTER TABLE users DROP COLUMN totp_secret;
+-- +goose StatementEnd
@@ -0,0 +1,266 @@
+-- name: GetUserForExport :one
+SELECT
+    uuid,
+    username,
+    email,
+    full_name,
+    bio,
+    created_date
+FROM
+    users
+WHERE
+    uuid = @user_uuid
+LIMIT
+    1;
+
+-- name: GetItemsForUserExport :many
+-- Returns every item the user has on a list, reviewed or watched
+SELECT
+    i.uuid,
+    i.title,
+    i.tmdb_id,
+    i.imdb_id,
+    i.release_date,
+    i.runtime,
+    i.overview,
+    i.original_language,
+    i.poster_path,
+    i.backdrop_path,
+    i.genres,
+    i.created_date
+FROM
+    items i
+WHERE
+    i.item_id IN (
+        SELECT li.item_id
+        FROM list_items li
+        JOIN lists l ON l.list_id = li.list_id
+        JOIN users u ON u.user_id = l.user_id
+        WHERE u.uuid = @user_uuid
+        UNION
+        SELECT r.item_id
+        FROM reviews r
+        JOIN users u ON u.user_id = r.user_id
+        WHERE u.uuid = @user_uuid
+        UNION
+        SELECT w.item_id
+        FROM watches w
+        JOIN users u ON u.user_id = w.user_id
+        WHERE u.uuid = @user_uuid
+    )
+ORDER BY
+    i.item_id;
+
+-- name: GetStatusesForUserExport :many
+SELECT
+    s.uuid,
+    s.label,
+    s.created_date
+FROM
+    statuses s
+        JOIN users u ON u.user_id = s.user_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    s.status_id;
+
+-- name: GetListsForUserExport :many
+SELECT
+    l.uuid,
+    l.name,
+    l.created_date
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    l.created_date,
+    l.list_id;
+
+-- name: GetListStatusesForUserExport :many
+SELECT
+    l.uuid AS list_uuid,
+    s.uuid AS status_uuid,
+    ls.rank,
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN lists l ON l.list_id = ls.list_id
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    ls.list_id,
+    ls.rank;
+
+-- name: GetListItemsForUserExport :many
+SELECT
+    li.uuid,
+    l.uuid AS list_uuid,
+    i.uuid AS item_uuid,
+    s.uuid AS status_uuid,
+    li.rank,
+    li.created_date
+FROM
+    list_items li
+        JOIN lists l ON l.list_id = li.list_id
+        JOIN items i ON i.item_id = li.item_id
+        JOIN users u ON u.user_id = l.user_id
+        LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    li.list_id,
+    li.status_id,
+    li.rank;
+
+-- name: GetReviewsForUserExport :many
+SELECT
+    r.uuid,
+    i.uuid AS item_uuid,
+    r.content,
+    r.rating,
+    r.created_date,
+    r.updated_date
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    r.review_id;
+
+-- name: GetWatchesForUserExport :many
+SELECT
+    w.uuid,
+    i.uuid AS item_uuid,
+    r.uuid AS review_uuid,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.location,
+    w.format,
+    w.created_date,
+    w.updated_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+        LEFT JOIN reviews r ON r.review_id = w.review_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    w.watch_id;
+
+-- name: GetStatusUuidByLabelForUser :one
+SELECT
+    s.uuid
+FROM
+    statuses s
+        JOIN users u ON u.user_id = s.user_id
+WHERE
+    u.uuid = @user_uuid
+    AND s.label = @label
+LIMIT
+    1;
+
+-- name: ImportStatus :one
+-- Inserts a status with a known UUID. Returns no rows if the UUID is already taken
+INSERT INTO
+    statuses (uuid, label, user_id, created_date)
+VALUES
+    (
+        @uuid,
+        @label,
+        (SELECT user_id FROM users WHERE users.uuid = @user_uuid),
+        @created_date
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid;
+
+-- name: ImportList :one
+-- Inserts a list with a known UUID. Returns no rows if the UUID is already taken
+INSERT INTO
+    lists (uuid, name, user_id, created_date)
+VALUES
+    (
+        @uuid,
+        @name,
+        (SELECT user_id FROM users WHERE users.uuid = @user_uuid),
+        @created_date
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid;
+
+-- name: ImportListStatus :exec
+INSERT INTO
+    list_statuses (list_id, status_id, rank, wip_limit)
+VALUES
+    (
+        (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid),
+        (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid),
+        @rank,
+        @wip_limit
+    );
+
+-- name: ImportListItem :one
+-- Inserts a list item with a known UUID. Returns no rows if the UUID is already taken
+INSERT INTO
+    list_items (uuid, list_id, item_id, status_id, rank, created_date)
+VALUES
+    (
+        @uuid,
+        (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid),
+        (SELECT item_id FROM items WHERE items.uuid = @item_uuid),
+        (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid),
+        @rank,
+        @created_date
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid;
+
+-- name: ImportReview :one
+-- Inserts a review with a known UUID. Returns no rows if the UUID is already taken
+INSERT INTO
+    reviews (uuid, item_id, user_id, content, rating, created_date, updated_date)
+VALUES
+    (
+        @uuid,
+        (SELECT item_id FROM items WHERE items.uuid = @item_uuid),
+        (SELECT user_id FROM users WHERE users.uuid = @user_uuid),
+        @content,
+        @rating,
+        @created_date,
+        @updated_date
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid;
+
+-- name: ImportWatch :one
+-- Inserts a watch with a known UUID. Returns no rows if the UUID is already taken
+INSERT INTO
+    watches (uuid, user_id, item_id, review_id, watched_date, rewatch, rating, location, format, created_date, updated_date)
+VALUES
+    (
+        @uuid,
+        (SELECT user_id FROM users WHERE users.uuid = @user_uuid),
+        (SELECT item_id FROM items WHERE items.uuid = @item_uuid),
+        (SELECT review_id FROM reviews WHERE reviews.uuid = sqlc.narg(review_uuid)),
+        @watched_date,
+        @rewatch,
+        @rating,
+        @location,
+        @format,
+        @created_date,
+        @updated_date
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid;
@@ -0,0 +1,28 @@
+-- name: AddEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at)
+SELECT user_id, @email, @token_hash, @expires_at
+FROM users
+WHERE uuid = @user_uuid;
+
+-- name: UseEmailVerificationToken :one
+DELETE FROM email_verification_tokens
+WHERE token_hash = @token_hash
+RETURNING user_id, email, expires_at;
+
+-- name: GetLatestEmailVerificationTokenDate :one
+SELECT evt.created_at
+FROM email_verification_tokens evt
+JOIN users u ON evt.user_id = u.user_id
+WHERE u.uuid = @user_uuid
+ORDER BY evt.created_at DESC
+LIMIT 1;
+
+-- name: DeleteEmailVerificationTokensForUser :exec
+DELETE FROM email_verification_tokens evt
+USING users u
+WHERE evt.user_id = u.user_id
+  AND u.uuid = @user_uuid;
+
+-- name: DeleteExpiredEmailVerificationTokens :execrows
+DELETE FROM email_verification_tokens
+WHERE expires_at < CURRENT_TIMESTAMP;
@@ -0,0 +1,102 @@
+-- name: GetWatchesForExport :many
+SELECT
+    i.title,
+    i.release_date,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.created_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    w.watched_date,
+    w.watch_id
+LIMIT
+    @page_size
+    OFFSET
+    sqlc.arg('offset');
+
+-- name: GetReviewsForExport :many
+-- Returns reviews with content. The watched date and rewatch flag come from the latest watch linked to the review
+SELECT
+    i.title,
+    i.release_date,
+    r.content,
+    r.rating,
+    r.created_date,
+    lw.watched_date,
+    COALESCE(lw.rewatch, FALSE)::boolean AS rewatch
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+        LEFT JOIN LATERAL (
+            SELECT
+                w.watched_date,
+                w.rewatch
+            FROM
+                watches w
+            WHERE
+                w.review_id = r.review_id
+            ORDER BY
+                w.watched_date DESC
+            LIMIT
+                1
+        ) lw ON TRUE
+WHERE
+    u.uuid = @user_uuid
+    AND r.content <> ''
+ORDER BY
+    r.created_date,
+    r.review_id
+LIMIT
+    @page_size
+    OFFSET
+    sqlc.arg('offset');
+
+-- name: GetRatingsForExport :many
+SELECT
+    i.title,
+    i.release_date,
+    r.rating,
+    COALESCE(r.updated_date, r.created_date)::timestamptz AS rated_date
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+WHERE
+    u.uuid = @user_uuid
+    AND r.rating IS NOT NULL
+ORDER BY
+    r.created_date,
+    r.review_id
+LIMIT
+    @page_size
+    OFFSET
+    sqlc.arg('offset');
+
+-- name: GetWatchlistForExport :many
+-- Returns the items in the status column used for the watchlist, in the order they were added
+SELECT
+    i.title,
+    i.release_date,
+    li.created_date AS added_date
+FROM
+    list_items li
+        JOIN lists l ON l.list_id = li.list_id
+        JOIN statuses s ON s.status_id = li.status_id
+        JOIN items i ON i.item_id = li.item_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+ORDER BY
+    li.created_date,
+    li.list_item_id
+LIMIT
+    @page_size
+    OFFSET
+    sqlc.arg('offset');
@@ -0,0 +1,208 @@
+-- name: CreateImportJob :one
+INSERT INTO
+    import_jobs (user_id, source, list_id, status_id)
+VALUES
+    (
+        (SELECT user_id FROM users WHERE users.uuid = @user_uuid),
+        @source,
+        (SELECT list_id FROM lists WHERE lists.uuid = sqlc.narg(list_uuid)),
+        (SELECT status_id FROM statuses WHERE statuses.uuid = sqlc.narg(status_uuid))
+    )
+RETURNING
+    uuid;
+
+-- name: AddImportJobRow :exec
+-- Rows that can't be imported at all are stored with an outcome so that they appear in the report
+INSERT INTO
+    import_job_rows (import_job_id, file, line, kind, external_id, title, year, rating, outcome, reason)
+VALUES
+    (
+        (SELECT import_job_id FROM import_jobs WHERE import_jobs.uuid = @job_uuid),
+        @file,
+        @line,
+        @kind,
+        @external_id,
+        @title,
+        @year,
+        @rating,
+        sqlc.narg(outcome),
+        sqlc.narg(reason)
+    );
+
+-- name: GetImportJob :one
+SELECT
+    j.uuid,
+    u.uuid AS user_uuid,
+    j.source,
+    j.state,
+    l.uuid AS list_uuid,
+    s.uuid AS status_uuid,
+    j.error,
+    j.created_date,
+    j.started_date,
+    j.finished_date,
+    p.total,
+    p.processed,
+    p.matched,
+    p.ratings_created,
+    p.watchlist_added,
+    p.skipped
+FROM
+    import_jobs j
+        JOIN users u ON u.user_id = j.user_id
+        LEFT JOIN lists l ON l.list_id = j.list_id
+        LEFT JOIN statuses s ON s.status_id = j.status_id
+        CROSS JOIN LATERAL (
+            SELECT
+                COUNT(*) AS total,
+                COUNT(*) FILTER (WHERE r.outcome IS NOT NULL) AS processed,
+                COUNT(*) FILTER (WHERE r.outcome IN ('created', 'skipped')) AS matched,
+                COUNT(*) FILTER (WHERE r.kind = 'rating' AND r.outcome = 'created') AS ratings_created,
+                COUNT(*) FILTER (WHERE r.kind = 'watchlist' AND r.outcome = 'created') AS watchlist_added,
+                COUNT(*) FILTER (WHERE r.outcome = 'skipped') AS skipped
+            FROM
+                import_job_rows r
+            WHERE
+                r.import_job_id = j.import_job_id
+        ) p
+WHERE
+    j.uuid = @job_uuid
+LIMIT
+    1;
+
+-- name: GetImportJobsCountForUser :one
+SELECT COUNT(*)
+FROM import_jobs j
+JOIN users u ON u.user_id = j.user_id
+WHERE
+    u.uuid = @user_uuid;
+
+-- name: GetImportJobsForUser :many
+-- Returns a user's import jobs, most recent first
+SELECT
+    j.uuid,
+    u.uuid AS user_uuid,
+    j.source,
+    j.state,
+    l.uuid AS list_uuid,
+    s.uuid AS status_uuid,
+    j.error,
+    j.created_date,
+    j.started_date,
+    j.finished_date,
+    p.total,
+    p.processed,
+    p.matched,
+    p.ratings_created,
+    p.watchlist_added,
+    p.skipped
+FROM
+    import_jobs j
+        JOIN users u ON u.user_id = j.user_id
+        LEFT JOIN lists l ON l.list_id = j.list_id
+        LEFT JOIN statuses s ON s.status_id = j.status_id
+        CROSS JOIN LATERAL (
+            SELECT
+                COUNT(*) AS total,
+                COUNT(*) FILTER (WHERE r.outcome IS NOT NULL) AS processed,
+                COUNT(*) FILTER (WHERE r.outcome IN ('created', 'skipped')) AS matched,
+                COUNT(*) FILTER (WHERE r.kind = 'rating' AND r.outcome = 'created') AS ratings_created,
+                COUNT(*) FILTER (WHERE r.kind = 'watchlist' AND r.outcome = 'created') AS watchlist_added,
+                COUNT(*) FILTER (WHERE r.outcome = 'skipped') AS skipped
+            FROM
+                import_job_rows r
+            WHERE
+                r.import_job_id = j.import_job_id
+        ) p
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    j.import_job_id DESC
+LIMIT
+    @page_size
+    OFFSET
+    @page;
+
+-- name: GetUnmatchedImportJobRows :many
+SELECT
+    r.file,
+    r.line,
+    r.title,
+    r.year,
+    r.reason
+FROM
+    import_job_rows r
+        JOIN import_jobs j ON j.import_job_id = r.import_job_id
+WHERE
+    j.uuid = @job_uuid
+    AND r.outcome = 'unmatched'
+ORDER BY
+    r.import_job_row_id;
+
+-- name: GetPendingImportJobRows :many
+-- Returns the next rows of a job that haven't been processed, in the order they were uploaded
+SELECT
+    r.import_job_row_id,
+    r.kind,
+    r.external_id,
+    r.rating
+FROM
+    import_job_rows r
+        JOIN import_jobs j ON j.import_job_id = r.import_job_id
+WHERE
+    j.uuid = @job_uuid
+    AND r.outcome IS NULL
+ORDER BY
+    r.import_job_row_id
+LIMIT
+    @batch_size;
+
+-- name: SetImportJobRowOutcome :exec
+UPDATE import_job_rows
+SET
+    outcome = @outcome,
+    reason = sqlc.narg(reason)
+WHERE
+    import_job_row_id = @import_job_row_id;
+
+-- name: GetUnfinishedImportJobs :many
+-- Returns the jobs that are waiting to run or were interrupted while running, oldest first
+SELECT
+    uuid
+FROM
+    import_jobs
+WHERE
+    state IN ('pending', 'running')
+ORDER BY
+    import_job_id;
+
+-- name: StartImportJob :exec
+UPDATE import_jobs
+SET
+    state = 'running',
+    error = NULL,
+    started_date = COALESCE(started_date, CURRENT_TIMESTAMP)
+WHERE
+    uuid = @job_uuid;
+
+-- name: FinishImportJob :exec
+UPDATE import_jobs
+SET
+    state = @state,
+    error = sqlc.narg(error),
+    finished_date = CURRENT_TIMESTAMP
+WHERE
+    uuid = @job_uuid;
+
+-- name: ResumeImportJob :one
+-- Queues a failed job to run again. Returns no rows if the job hasn't failed
+UPDATE import_jobs
+SET
+    state = 'pending',
+    error = NULL,
+    finished_date = NULL
+WHERE
+    uuid = @job_uuid
+    AND state = 'failed'
+RETURNING
+    uuid;
@@ -14,14 +14,121 @@ RETURNING
 SELECT
     uuid,
     title,
-    created_date
+    tmdb_id,
+    imdb_id,
+    release_date,
+    runtime,
+    overview,
+    original_language,
+    poster_path,
+    backdrop_path,
+    genres,
+    metadata_refreshed_at,
+    created_date,
+    (
+        SELECT COALESCE(ROUND(AVG(r.rating), 2), 0)::float8
+        FROM reviews r
+        WHERE r.item_id = items.item_id
+    ) AS average_rating,
+    (
+        SELECT COUNT(r.rating)
+        FROM reviews r
+        WHERE r.item_id = items.item_id
+    ) AS rating_count,
+    (
+        SELECT COUNT(*)
+        FROM reviews r
+        WHERE r.item_id = items.item_id
+    ) AS review_count
+FROM
+    items
+WHERE
+    items.uuid = @item_uuid
+LIMIT
+    1;
+
+-- name: GetItemUuidByTmdbId :one
+SELECT
+    uuid
 FROM
     items
 WHERE
-    uuid = @item_uuid
+    tmdb_id = @tmdb_id
 LIMIT
     1;
 
+-- name: AddTmdbItem :one
+-- Returns no rows if an item with the same TMDB ID already exists
+INSERT INTO
+    items (
+        title,
+        tmdb_id,
+        imdb_id,
+        release_date,
+        runtime,
+        overview,
+        original_language,
+        poster_path,
+        backdrop_path,
+        genres,
+        metadata_refreshed_at
+    )
+VALUES
+    (
+        @title,
+        @tmdb_id,
+        @imdb_id,
+        @release_date,
+        @runtime,
+        @overview,
+        @original_language,
+        @poster_path,
+        @backdrop_path,
+        @genres,
+        CURRENT_TIMESTAMP
+    )
+ON CONFLICT (tmdb_id) DO NOTHING
+RETURNING
+    uuid;
+
+-- name: GetItemsDueForRefresh :many
+-- Returns TMDB items that have not been refreshed since stale_before, oldest first
+SELECT
+    uuid,
+    tmdb_id
+FROM
+    items
+WHERE
+    tmdb_id IS NOT NULL
+    AND (metadata_refreshed_at IS NULL OR metadata_refreshed_at < @stale_before)
+ORDER BY
+    metadata_refreshed_at NULLS FIRST,
+    item_id
+LIMIT
+    @batch_size;
+
+-- name: UpdateItemMetadata :exec
+UPDATE items
+SET
+    imdb_id = @imdb_id,
+    release_date = @release_date,
+    runtime = @runtime,
+    overview = @overview,
+    original_language = @original_language,
+    poster_path = @poster_path,
+    backdrop_path = @backdrop_path,
+    genres = @genres,
+    metadata_refreshed_at = CURRENT_TIMESTAMP
+WHERE
+    uuid = @item_uuid;
+
+-- name: TouchItemMetadataRefreshed :exec
+UPDATE items
+SET
+    metadata_refreshed_at = CURRENT_TIMESTAMP
+WHERE
+    uuid = @item_uuid;
+
 -- name: GetItemsCount :one
 SELECT COUNT (*)
 FROM items;
@@ -29,7 +136,10 @@ FROM items;
 -- name: GetAllItems :many
 SELECT
     uuid,
-    title
+    title,
+    tmdb_id,
+    release_date,
+    poster_path
 FROM
     items
 ORDER BY
@@ -50,4 +160,15 @@ RETURNING
 -- name: DeleteItem :exec
 DELETE FROM items
 WHERE
-    uuid = @item_uuid;
\ No newline at end of file
+    uuid = @item_uuid;
+-- name: GetItemUuidByImdbId :one
+SELECT
+    uuid
+FROM
+    items
+WHERE
+    imdb_id = @imdb_id
+ORDER BY
+    item_id
+LIMIT
+    1;
@@ -1,31 +1,142 @@
 -- name: GetAllListItems :many
-SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.position
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.rank,
+       (ROW_NUMBER() OVER (PARTITION BY li.list_id, li.status_id ORDER BY li.rank) - 1)::int AS position
 FROM list_items li
 JOIN lists l ON l.list_id = li.list_id
 JOIN items i ON i.item_id = li.item_id
 JOIN statuses s ON s.status_id = li.status_id
 ORDER BY
-    li.position
+    li.list_id,
+    li.status_id,
+    li.rank
 LIMIT
     @page_size
     OFFSET
     @page;
 
 -- name: GetListItemsByListUuid :many
-SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.position
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.rank,
+       (ROW_NUMBER() OVER (PARTITION BY li.status_id ORDER BY li.rank) - 1)::int AS position
 FROM list_items li
 JOIN lists l ON l.list_id = li.list_id
 JOIN items i ON i.item_id = li.item_id
 JOIN statuses s ON s.status_id = li.status_id
 WHERE
-    li.uuid = @list_uuid
+    l.uuid = @list_uuid
 ORDER BY
-    li.position
+    li.status_id,
+    li.rank
 LIMIT
     @page_size
     OFFSET
     @page;
 
+-- name: GetBoardCardsForList :many
+SELECT
+    li.uuid AS list_item_uuid,
+    s.uuid AS status_uuid,
+    i.uuid AS item_uuid,
+    i.title,
+    i.tmdb_id,
+    i.release_date,
+    i.runtime,
+    i.poster_path,
+    i.created_date AS item_created_date,
+    li.rank,
+    (ROW_NUMBER() OVER (PARTITION BY li.status_id ORDER BY li.rank) - 1)::int AS position,
+    li.created_date
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+ORDER BY
+    li.status_id,
+    li.rank;
+
+-- name: GetListItemByUuid :one
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.uuid AS status_uuid, s.label, li.rank,
+       (SELECT COUNT(*) FROM list_items o WHERE o.list_id = li.list_id AND o.status_id = li.status_id AND o.rank < li.rank)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    li.uuid = @list_item_uuid
+LIMIT
+    1;
+
+-- name: GetPreviousListItem :one
+-- Returns the item directly before a rank in a column
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.uuid AS status_uuid, s.label, li.rank,
+       (SELECT COUNT(*) FROM list_items o WHERE o.list_id = li.list_id AND o.status_id = li.status_id AND o.rank < li.rank)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+    AND li.rank < @rank
+ORDER BY
+    li.rank DESC
+LIMIT
+    1;
+
+-- name: GetNextListItem :one
+-- Returns the item directly after a rank in a column
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.uuid AS status_uuid, s.label, li.rank,
+       (SELECT COUNT(*) FROM list_items o WHERE o.list_id = li.list_id AND o.status_id = li.status_id AND o.rank < li.rank)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+    AND li.rank > @rank
+ORDER BY
+    li.rank
+LIMIT
+    1;
+
+-- name: GetRanksAroundPosition :many
+-- Returns the ranks of the items either side of a zero-based position in a column, skipping exclude_uuid
+SELECT li.rank
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+    AND li.uuid IS DISTINCT FROM @exclude_uuid
+ORDER BY
+    li.rank
+LIMIT
+    2
+    OFFSET
+    GREATEST(sqlc.arg(position)::int - 1, 0);
+
+-- name: CheckItemInList :one
+SELECT COUNT(*)
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+WHERE
+    l.uuid = @list_uuid
+    AND i.uuid = @item_uuid;
+
+-- name: GetListItemsCountForStatus :one
+SELECT COUNT(*)
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+    AND li.uuid IS DISTINCT FROM @exclude_uuid;
+
 -- name: GetAllListItemsCount :one
 SELECT COUNT(*)
 FROM list_items;
@@ -35,92 +146,140 @@ SELECT COUNT(*)
 FROM list_items li
 WHERE li.list_id = (SELECT l.list_id FROM lists l WHERE l.uuid = @list_uuid);
 
--- name: AddItemToListAtPosition :one
--- Arguments: list_uuid, item_uuid, new_position, status_uuid
-WITH new_item AS (
-    INSERT INTO list_items (list_id, item_id, position, prev_item_id, next_item_id, status_id)
-        VALUES (
-                   (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid),
-                   (SELECT item_id FROM items WHERE items.uuid = @item_uuid),
-                   @new_position,
-                   (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid) AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid) AND list_items.position = @prev_position),
-                   (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid) AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid) AND list_items.position = @next_position),
-                   (SELECT status_id FROM statuses WHERE uuid = @status_uuid)
-               )
-        RETURNING list_item_id, list_id, position, prev_item_id, next_item_id, status_id
-),
-     update_prev_item AS (
-         UPDATE list_items
-             SET next_item_id = new_item.list_item_id
-             FROM new_item
-             WHERE list_items.list_item_id = new_item.prev_item_id
-                 AND new_item.prev_item_id IS NOT NULL
-     ),
-     update_next_item AS (
-         UPDATE list_items
-             SET prev_item_id = new_item.list_item_id
-             FROM new_item
-             WHERE list_items.list_item_id = new_item.next_item_id
-                 AND new_item.next_item_id IS NOT NULL
-     )
-SELECT list_items.uuid, list_items.position
-FROM list_items
-         JOIN items ON list_items.item_id = items.item_id
-WHERE list_items.list_item_id = (SELECT list_item_id FROM new_item);
+-- name: AddItemToList :one
+-- Arguments: list_uuid, item_uuid, status_uuid, rank
+INSERT INTO list_items (list_id, item_id, status_id, rank)
+VALUES (
+           (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid),
+           (SELECT item_id FROM items WHERE items.uuid = @item_uuid),
+           (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid),
+           @rank
+       )
+RETURNING uuid, rank;
 
 -- name: MoveItemInList :one
--- Arguments: list_item_uuid, new_position, status_uuid
-WITH current_item AS (
-    SELECT list_item_id, prev_item_id, next_item_id, position, list_id, status_id
-    FROM list_items
-    WHERE list_items.uuid = @list_item_uuid
-),
-     updated_positions AS (
-         UPDATE list_items
-             SET position = position + CASE
-                                           WHEN list_items.position >= @new_position THEN 1
-                                           ELSE -1
-                 END
-             WHERE list_id = (SELECT list_id FROM current_item)
-                 AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid)
-                 AND position BETWEEN LEAST(@new_position, (SELECT position FROM current_item))
-                       AND GREATEST(@new_position, (SELECT position FROM current_item))
-             RETURNING list_item_id, position
-     ),
-     update_current_item AS (
-         UPDATE list_items
-             SET
-                 position = @new_position,
-                 prev_item_id = (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM current_item) AND status_id = (SELECT status_id FROM statuses WHERE uuid = @status_uuid) AND list_items.position = @prev_position),
-                 next_item_id = (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM current_item) AND status_id = (SELECT status_id FROM statuses WHERE uuid = @status_uuid) AND list_items.position = @next_position),
-                 status_id = (SELECT status_id FROM statuses WHERE uuid = @status_uuid)
-             WHERE list_item_id = (SELECT list_item_id FROM current_item)
-             RETURNING uuid, position, prev_item_id, next_item_id
-     )
-SELECT uuid, position, prev_item_id, next_item_id
-FROM update_current_item;
+-- Arguments: list_item_uuid, status_uuid, rank
+UPDATE list_items
+SET
+    status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid),
+    rank = @rank
+WHERE
+    list_items.uuid = @list_item_uuid
+RETURNING
+    uuid,
+    rank;
 
 -- name: DeleteItemFromList :exec
 -- Arguments: list_item_uuid
-WITH deleted_item AS (
-    DELETE FROM list_items
-        WHERE list_items.uuid = @list_item_uuid
-        RETURNING list_item_id, prev_item_id, next_item_id, status_id
-),
-     update_prev_item AS (
-         UPDATE list_items
-             SET next_item_id = deleted_item.next_item_id
-             FROM deleted_item
-             WHERE list_items.list_item_id = deleted_item.prev_item_id
-                 AND deleted_item.prev_item_id IS NOT NULL
-                 AND list_items.status_id = deleted_item.status_id
-     ),
-     update_next_item AS (
-         UPDATE list_items
-             SET prev_item_id = deleted_item.prev_item_id
-             FROM deleted_item
-             WHERE list_items.list_item_id = deleted_item.next_item_id
-                 AND deleted_item.next_item_id IS NOT NULL
-                 AND list_items.status_id = deleted_item.status_id
-     )
-SELECT 1;
+DELETE FROM list_items
+WHERE
+    list_items.uuid = @list_item_uuid;
+
+-- name: GetListItemUuidsForStatus :many
+SELECT li.uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+ORDER BY
+    li.rank,
+    li.list_item_id;
+
+-- name: GetLastRankForStatus :one
+SELECT li.rank
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+ORDER BY
+    li.rank DESC
+LIMIT
+    1;
+
+-- name: ReassignListItems :exec
+-- Moves each list item in list_item_uuids to a status with the matching entry in ranks
+UPDATE list_items
+SET
+    status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid),
+    rank = updated.rank
+FROM (
+    SELECT
+        UNNEST(sqlc.arg(list_item_uuids)::uuid[]) AS uuid,
+        UNNEST(sqlc.arg(ranks)::text[]) AS rank
+) updated
+WHERE
+    list_items.uuid = updated.uuid;
+
+-- name: UpdateListItemRanks :exec
+-- Sets the rank of each list item in list_item_uuids to the matching entry in ranks
+UPDATE list_items
+SET
+    rank = updated.rank
+FROM (
+    SELECT
+        UNNEST(sqlc.arg(list_item_uuids)::uuid[]) AS uuid,
+        UNNEST(sqlc.arg(ranks)::text[]) AS rank
+) updated
+WHERE
+    list_items.uuid = updated.uuid;
+
+-- name: GetColumnsWithLongRanks :many
+-- Returns every list and status pair containing a rank longer than max_length
+SELECT DISTINCT l.uuid AS list_uuid, s.uuid AS status_uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    LENGTH(li.rank) > sqlc.arg(max_length)::int;
+
+-- name: GetListItemsForIntegrityCheck :many
+-- Returns every item in a list with whether its status is attached to the list
+SELECT
+    li.uuid AS list_item_uuid,
+    s.uuid AS status_uuid,
+    li.rank,
+    EXISTS (
+        SELECT 1
+        FROM list_statuses ls
+        WHERE ls.list_id = li.list_id
+          AND ls.status_id = li.status_id
+    ) AS status_on_list
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+ORDER BY
+    li.status_id,
+    li.rank,
+    li.list_item_id;
+
+-- name: GetListItemForItem :one
+-- Returns the card for an item on a list, if the item is on it
+SELECT li.uuid AS list_item_uuid, s.uuid AS status_uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = @list_uuid
+    AND i.uuid = @item_uuid
+LIMIT
+    1;
+
+-- name: GetListUuidForListItem :one
+SELECT l.uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+WHERE li.uuid = @list_item_uuid;
+
+-- name: GetListItemOwner :one
+SELECT u.uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN users u ON u.user_id = l.user_id
+WHERE li.uuid = @list_item_uuid;
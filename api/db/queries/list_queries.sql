@@ -20,12 +20,28 @@ RETURNING
 
 -- name: GetListByUuid :one
 SELECT
-    uuid,
-    name
+    l.uuid,
+    l.name,
+    u.uuid AS user_uuid,
+    l.created_date
 FROM
-    lists
+    lists l
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    l.uuid = @list_uuid
+LIMIT
+    1;
+
+-- name: LockListByUuid :one
+SELECT
+    l.uuid,
+    u.uuid AS user_uuid
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
 WHERE
-    uuid = @list_uuid;
+    l.uuid = @list_uuid
+FOR UPDATE OF l;
 
 -- name: GetListsByUser :many
 SELECT
@@ -43,6 +59,38 @@ LIMIT
     OFFSET
     @page;
 
+-- name: GetDefaultListForUser :one
+-- The default list is the user's oldest list, which is the watchlist created when they registered
+SELECT
+    l.uuid,
+    l.name
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    l.created_date,
+    l.list_id
+LIMIT
+    1;
+
+-- name: GetListsCountForUser :one
+SELECT COUNT(*)
+FROM lists l
+JOIN users u
+ON u.user_id = l.user_id
+WHERE
+    u.uuid = @user_uuid;
+
+-- name: GetAllListUuids :many
+SELECT
+    uuid
+FROM
+    lists
+ORDER BY
+    list_id;
+
 -- name: UpdateList :one
 UPDATE lists
 SET
@@ -1,6 +1,6 @@
 -- name: AddListStatus :one
 INSERT INTO
-    list_statuses (list_id, status_id)
+    list_statuses (list_id, status_id, rank)
 VALUES
     (
         (
@@ -18,7 +18,8 @@ VALUES
                 statuses s
             WHERE
                 s.uuid = @status_uuid
-        )
+        ),
+        @rank
     )
 RETURNING
     uuid,
@@ -35,12 +36,23 @@ WHERE
 LIMIT
     1;
 
+-- name: CheckStatusOnList :one
+SELECT COUNT(*)
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid;
+
 -- name: GetStatusesForList :many
 SELECT
-    ls.uuid,
-    s.uuid,
+    ls.uuid AS list_status_uuid,
+    s.uuid AS status_uuid,
     s.label,
-    ls.created_date
+    ls.rank,
+    ls.wip_limit
 FROM
     list_statuses ls
         JOIN statuses s ON s.status_id = ls.status_id
@@ -48,14 +60,94 @@ FROM
 WHERE
     l.uuid = @list_uuid
 ORDER BY
-    l.uuid,
-    ls.created_date
+    ls.rank,
+    ls.list_status_id;
+
+-- name: GetListStatusRank :one
+SELECT
+    ls.rank
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
 LIMIT
-    @page_size
-    OFFSET
-    @page;
+    1;
+
+-- name: GetLastListStatusRank :one
+SELECT
+    ls.rank
+FROM
+    list_statuses ls
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = @list_uuid
+ORDER BY
+    ls.rank DESC
+LIMIT
+    1;
+
+-- name: UpdateListStatusRanks :exec
+-- Sets the rank of each status in status_uuids on a list to the matching entry in ranks
+UPDATE list_statuses
+SET
+    rank = updated.rank
+FROM (
+    SELECT
+        UNNEST(sqlc.arg(status_uuids)::uuid[]) AS uuid,
+        UNNEST(sqlc.arg(ranks)::text[]) AS rank
+) updated
+    JOIN statuses s ON s.uuid = updated.uuid
+WHERE
+    list_statuses.status_id = s.status_id
+    AND list_statuses.list_id = (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid);
+
+-- name: DeleteListStatusForList :exec
+DELETE FROM list_statuses
+WHERE
+    list_id = (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid)
+    AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid);
+
+-- name: GetBoardColumnsForList :many
+SELECT
+    ls.uuid AS list_status_uuid,
+    s.uuid AS status_uuid,
+    s.label,
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = @list_uuid
+ORDER BY
+    ls.rank,
+    ls.list_status_id;
 
 -- name: DeleteListStatus :exec
 DELETE FROM list_statuses
 WHERE
-    uuid = @list_status_uuid;
\ No newline at end of file
+    uuid = @list_status_uuid;
+
+-- name: GetListStatusWipLimit :one
+SELECT
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = @list_uuid
+    AND s.uuid = @status_uuid
+LIMIT
+    1;
+
+-- name: UpdateListStatusWipLimit :exec
+UPDATE list_statuses
+SET
+    wip_limit = @wip_limit
+WHERE
+    list_id = (SELECT list_id FROM lists WHERE lists.uuid = @list_uuid)
+    AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = @status_uuid);
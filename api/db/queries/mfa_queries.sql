@@ -0,0 +1,87 @@
+-- name: GetUserTotp :one
+SELECT
+    user_id,
+    username,
+    hashed_password,
+    totp_secret,
+    totp_enabled_at,
+    totp_last_used_step
+FROM users
+WHERE uuid = @user_uuid;
+
+-- name: SetPendingTotpSecret :execrows
+UPDATE users
+SET totp_secret = @totp_secret
+WHERE uuid = @user_uuid
+  AND totp_enabled_at IS NULL;
+
+-- name: EnableTotp :execrows
+UPDATE users
+SET
+    totp_enabled_at = CURRENT_TIMESTAMP,
+    totp_last_used_step = @step
+WHERE user_id = @user_id
+  AND totp_secret IS NOT NULL
+  AND totp_enabled_at IS NULL;
+
+-- name: DisableTotp :exec
+UPDATE users
+SET
+    totp_secret = NULL,
+    totp_enabled_at = NULL,
+    totp_last_used_step = NULL
+WHERE user_id = @user_id;
+
+-- name: UseTotpStep :execrows
+UPDATE users
+SET totp_last_used_step = @step
+WHERE user_id = @user_id
+  AND (totp_last_used_step IS NULL OR totp_last_used_step < @step);
+
+-- name: AddRecoveryCode :exec
+INSERT INTO recovery_codes (user_id, code_hash)
+VALUES (@user_id, @code_hash);
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+  AND code_hash = @code_hash
+  AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE user_id = @user_id;
+
+-- name: AddMfaChallenge :exec
+INSERT INTO mfa_challenges (user_id, token_hash, device_name, expires_at)
+VALUES (@user_id, @token_hash, @device_name, @expires_at);
+
+-- name: GetMfaChallengeByHash :one
+SELECT
+    mc.mfa_challenge_id,
+    mc.user_id,
+    u.uuid AS user_uuid,
+    mc.device_name,
+    mc.attempts,
+    mc.expires_at
+FROM mfa_challenges mc
+JOIN users u ON mc.user_id = u.user_id
+WHERE mc.token_hash = @token_hash;
+
+-- name: AddMfaChallengeAttempt :one
+-- Counts an attempt before the code is checked. Returns no rows once the challenge has run out of attempts,
+-- so concurrent requests can't make more than max_attempts guesses between them
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE mfa_challenge_id = @mfa_challenge_id
+  AND attempts < @max_attempts
+RETURNING attempts;
+
+-- name: DeleteMfaChallenge :execrows
+DELETE FROM mfa_challenges
+WHERE mfa_challenge_id = @mfa_challenge_id;
+
+-- name: DeleteExpiredMfaChallenges :execrows
+DELETE FROM mfa_challenges
+WHERE expires_at < CURRENT_TIMESTAMP;
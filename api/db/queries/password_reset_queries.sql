@@ -0,0 +1,27 @@
+-- name: AddPasswordResetToken :exec
+INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+VALUES (@user_id, @token_hash, @expires_at);
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT
+    token_id,
+    user_id,
+    expires_at,
+    used_at
+FROM password_reset_tokens
+WHERE token_hash = @token_hash;
+
+-- name: MarkPasswordResetTokenUsed :execrows
+UPDATE password_reset_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE token_id = @token_id
+  AND used_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: DeletePasswordResetTokensForUser :exec
+DELETE FROM password_reset_tokens
+WHERE user_id = @user_id;
+
+-- name: DeleteExpiredPasswordResetTokens :execrows
+DELETE FROM password_reset_tokens
+WHERE expires_at < CURRENT_TIMESTAMP;
@@ -1,6 +1,6 @@
 -- name: AddReview :one
 INSERT INTO
-    reviews (item_id, user_id, content)
+    reviews (item_id, user_id, content, rating)
 VALUES
     (
         (
@@ -19,70 +19,130 @@ VALUES
             WHERE
                 users.uuid = @user_uuid
         ),
-        @content
+        @content,
+        @rating
     )
 RETURNING
-    uuid,
-    content,
-    created_date;
+    uuid;
 
 -- name: GetReview :one
 SELECT
-    uuid,
-    content,
-    created_date
+    r.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
+    r.content,
+    r.rating,
+    r.created_date,
+    r.updated_date
 FROM
-    reviews
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
 WHERE
-    uuid = @review_uuid
+    r.uuid = @review_uuid
 LIMIT
     1;
 
+-- name: CheckReviewExists :one
+SELECT COUNT(*)
+FROM reviews r
+JOIN items i ON i.item_id = r.item_id
+JOIN users u ON u.user_id = r.user_id
+WHERE
+    i.uuid = @item_uuid
+    AND u.uuid = @user_uuid;
+
+-- name: GetReviewForUserItem :one
+SELECT
+    r.uuid,
+    r.content,
+    r.rating
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+WHERE
+    i.uuid = @item_uuid
+    AND u.uuid = @user_uuid
+LIMIT
+    1;
+
+-- name: GetReviewsCountForUser :one
+SELECT COUNT(*)
+FROM reviews r
+JOIN users u ON u.user_id = r.user_id
+WHERE
+    u.uuid = @user_uuid;
+
 -- name: GetReviewsForUser :many
 SELECT
     r.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
     r.content,
-    r.created_date
+    r.rating,
+    r.created_date,
+    r.updated_date
 FROM
     reviews r
+        JOIN items i ON i.item_id = r.item_id
         JOIN users u ON u.user_id = r.user_id
 WHERE
     u.uuid = @user_uuid
 ORDER BY
-    r.created_date
+    r.created_date DESC,
+    r.review_id DESC
 LIMIT
     @page_size
     OFFSET
     @page;
 
+-- name: GetReviewsCountForItem :one
+SELECT COUNT(*)
+FROM reviews r
+JOIN items i ON i.item_id = r.item_id
+WHERE
+    i.uuid = @item_uuid;
+
 -- name: GetReviewsForItem :many
 SELECT
     r.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
     r.content,
-    r.created_date
+    r.rating,
+    r.created_date,
+    r.updated_date
 FROM
     reviews r
         JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
 WHERE
     i.uuid = @item_uuid
 ORDER BY
-    r.created_date
+    r.created_date DESC,
+    r.review_id DESC
 LIMIT
     @page_size
     OFFSET
     @page;
 
--- name: UpdateReview :one
+-- name: UpdateReview :exec
 UPDATE reviews
 SET
-    content = @content
+    content = @content,
+    rating = @rating,
+    updated_date = CURRENT_TIMESTAMP
 WHERE
-    uuid = @review_uuid
-RETURNING
-    uuid,
-    content;
+    uuid = @review_uuid;
 
 -- name: DeleteReview :exec
 DELETE FROM reviews
 WHERE
-    uuid = @review_uuid;
\ No newline at end of file
+    uuid = @review_uuid;
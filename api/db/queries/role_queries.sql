@@ -0,0 +1,61 @@
+-- name: GetPermissionsForUser :many
+SELECT DISTINCT p.name
+FROM users u
+JOIN user_roles ur ON ur.user_id = u.user_id
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+JOIN permissions p ON p.permission_id = rp.permission_id
+WHERE u.uuid = @user_uuid
+ORDER BY p.name;
+
+-- name: GetRoles :many
+SELECT
+    r.name,
+    r.description,
+    COALESCE(ARRAY_AGG(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')::TEXT[] AS permissions
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role_id = r.role_id
+LEFT JOIN permissions p ON p.permission_id = rp.permission_id
+GROUP BY r.role_id
+ORDER BY r.role_id;
+
+-- name: GetRolesForUser :many
+SELECT r.name
+FROM users u
+JOIN user_roles ur ON ur.user_id = u.user_id
+JOIN roles r ON r.role_id = ur.role_id
+WHERE u.uuid = @user_uuid
+ORDER BY r.role_id;
+
+-- name: LockRoleByName :one
+-- Locks a role so that concurrent changes to who holds it are applied one at a time
+SELECT role_id
+FROM roles
+WHERE name = @role_name
+FOR UPDATE;
+
+-- name: GetUserCountForRole :one
+SELECT COUNT(*)
+FROM user_roles
+WHERE role_id = @role_id;
+
+-- name: AddUserRole :execrows
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.user_id, @role_id
+FROM users u
+WHERE u.uuid = @user_uuid
+ON CONFLICT DO NOTHING;
+
+-- name: AddUserRoleByName :exec
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.user_id, r.role_id
+FROM users u
+JOIN roles r ON r.name = @role_name
+WHERE u.uuid = @user_uuid
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveUserRole :execrows
+DELETE FROM user_roles ur
+USING users u
+WHERE ur.user_id = u.user_id
+  AND u.uuid = @user_uuid
+  AND ur.role_id = @role_id;
@@ -19,15 +19,66 @@ RETURNING
 
 -- name: GetStatus :one
 SELECT
-    uuid,
-    label
+    s.uuid,
+    s.label,
+    u.uuid AS user_uuid
 FROM
-    statuses
+    statuses s
+        JOIN users u ON u.user_id = s.user_id
 WHERE
-    uuid = @status_uuid
+    s.uuid = @status_uuid
 LIMIT
     1;
 
+-- name: CheckStatusLabelForUser :one
+SELECT COUNT(*)
+FROM statuses s
+JOIN users u
+ON u.user_id = s.user_id
+WHERE
+    u.uuid = @user_uuid
+    AND s.label = @status_label
+    AND s.uuid IS DISTINCT FROM @exclude_uuid;
+
+-- name: UpdateStatusLabel :one
+UPDATE statuses
+SET
+    label = @status_label
+WHERE
+    uuid = @status_uuid
+RETURNING
+    uuid,
+    label;
+
+-- name: DeleteStatus :exec
+DELETE FROM statuses
+WHERE
+    uuid = @status_uuid;
+
+-- name: GetListUuidsForStatus :many
+-- Returns every list that shows a status as a column or has items in it
+SELECT
+    l.uuid
+FROM
+    lists l
+WHERE
+    EXISTS (
+        SELECT 1
+        FROM list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        WHERE ls.list_id = l.list_id
+          AND s.uuid = @status_uuid
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM list_items li
+        JOIN statuses s ON s.status_id = li.status_id
+        WHERE li.list_id = l.list_id
+          AND s.uuid = @status_uuid
+    )
+ORDER BY
+    l.list_id;
+
 -- name: GetStatusesForUser :many
 SELECT
     s.uuid,
@@ -0,0 +1,28 @@
+-- name: GetTmdbCacheEntry :one
+SELECT
+    response,
+    fetched_date
+FROM
+    tmdb_cache
+WHERE
+    cache_key = @cache_key
+LIMIT
+    1;
+
+-- name: UpsertTmdbCacheEntry :exec
+INSERT INTO
+    tmdb_cache (cache_key, response)
+VALUES
+    (
+        @cache_key,
+        @response
+    )
+ON CONFLICT (cache_key) DO UPDATE
+SET
+    response = EXCLUDED.response,
+    fetched_date = CURRENT_TIMESTAMP;
+
+-- name: DeleteTmdbCacheEntriesBefore :execrows
+DELETE FROM tmdb_cache
+WHERE
+    fetched_date < @fetched_before;
@@ -1,18 +1,93 @@
 -- name: AddRefreshToken :one
-INSERT INTO refresh_tokens (user_id, token, expires_at)
+INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, device_name, user_agent, ip_address)
 VALUES (
-        @user_id, @token, @expires_at
+        @user_id, @token_hash, @family_id, @expires_at, @device_name, @user_agent, @ip_address
        )
-RETURNING token, expires_at;
+RETURNING family_id, expires_at;
 
--- name: DeleteRefreshToken :exec
+-- name: GetRefreshTokenByHash :one
+SELECT
+    rt.token_id,
+    rt.user_id,
+    u.uuid AS user_uuid,
+    rt.family_id,
+    rt.device_name,
+    rt.expires_at,
+    rt.used_at,
+    rt.revoked_at
+FROM refresh_tokens rt
+JOIN users u ON rt.user_id = u.user_id
+WHERE rt.token_hash = @token_hash;
+
+-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE token_id = @token_id
+  AND used_at IS NULL
+  AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE family_id = @family_id
+  AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokensForUser :exec
+-- Revokes every session a user has. The tokens are kept until they expire so that reuse is still detected
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+  AND revoked_at IS NULL;
+
+-- name: DeleteRefreshTokenFamily :exec
 DELETE FROM refresh_tokens
-WHERE token = @token;
+WHERE family_id = @family_id;
 
--- name: GetRefreshTokenByToken :one
+-- name: DeleteExpiredRefreshTokens :execrows
+DELETE FROM refresh_tokens
+WHERE expires_at < CURRENT_TIMESTAMP;
+
+-- name: GetSessionsForUser :many
 SELECT
-    user_id,
-    token,
-    expires_at
+    rt.family_id,
+    rt.device_name,
+    rt.user_agent,
+    rt.ip_address,
+    rt.last_used_at,
+    rt.expires_at
+FROM refresh_tokens rt
+JOIN users u ON rt.user_id = u.user_id
+WHERE u.uuid = @user_uuid
+  AND rt.used_at IS NULL
+  AND rt.revoked_at IS NULL
+  AND rt.expires_at > CURRENT_TIMESTAMP
+ORDER BY rt.last_used_at DESC;
+
+-- name: GetSessionOwner :one
+SELECT DISTINCT u.uuid
+FROM refresh_tokens rt
+JOIN users u ON rt.user_id = u.user_id
+WHERE rt.family_id = @family_id;
+
+-- name: IsSessionActive :one
+SELECT EXISTS (
+    SELECT 1
+    FROM refresh_tokens
+    WHERE family_id = @family_id
+      AND revoked_at IS NULL
+      AND expires_at > CURRENT_TIMESTAMP
+);
+
+-- name: DeleteOtherSessionsForUser :execrows
+DELETE FROM refresh_tokens rt
+USING users u
+WHERE rt.user_id = u.user_id
+  AND u.uuid = @user_uuid
+  AND rt.family_id <> @current_family_id;
+
+-- name: GetSessionDeviceName :one
+SELECT device_name
 FROM refresh_tokens
-WHERE token = @token;
\ No newline at end of file
+WHERE family_id = @family_id
+ORDER BY created_at DESC
+LIMIT 1;
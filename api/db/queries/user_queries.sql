@@ -19,8 +19,7 @@ SELECT
     uuid,
     username,
     full_name,
-    bio,
-    superuser
+    bio
 FROM
     users
 WHERE
@@ -33,8 +32,7 @@ SELECT
     uuid,
     username,
     full_name,
-    bio,
-    superuser
+    bio
 FROM
     users
 WHERE
@@ -49,7 +47,8 @@ SELECT
     username,
     email,
     hashed_password,
-    superuser
+    email_verified_at,
+    totp_enabled_at
 FROM
     users
 WHERE
@@ -99,4 +98,72 @@ RETURNING
 -- name: DeleteUser :exec
 DELETE FROM users
 WHERE
-    uuid = @user_uuid;
\ No newline at end of file
+    uuid = @user_uuid;
+
+-- name: GetUserPassword :one
+SELECT
+    user_id,
+    hashed_password
+FROM
+    users
+WHERE
+    uuid = @user_uuid
+LIMIT
+    1;
+
+-- name: GetUserByEmail :one
+SELECT
+    user_id,
+    uuid,
+    username,
+    email,
+    email_verified_at
+FROM
+    users
+WHERE
+    email = @email
+LIMIT
+    1;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET
+    hashed_password = @hashed_password
+WHERE
+    user_id = @user_id;
+
+
+-- name: GetUserEmail :one
+SELECT
+    uuid,
+    username,
+    email,
+    email_verified_at
+FROM
+    users
+WHERE
+    uuid = @user_uuid
+LIMIT
+    1;
+
+-- name: UpdateUserEmail :exec
+UPDATE users
+SET
+    email = @email,
+    email_verified_at = NULL
+WHERE
+    uuid = @user_uuid;
+
+-- name: MarkEmailVerified :execrows
+UPDATE users
+SET
+    email_verified_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = @user_id
+AND
+    email = @email;
+
+-- name: IsEmailVerified :one
+SELECT (email_verified_at IS NOT NULL)::BOOLEAN AS verified
+FROM users
+WHERE uuid = @user_uuid;
\ No newline at end of file
@@ -0,0 +1,107 @@
+-- name: AddWatch :one
+INSERT INTO
+    watches (user_id, item_id, review_id, watched_date, rewatch, rating, location, format)
+VALUES
+    (
+        (SELECT user_id FROM users WHERE users.uuid = @user_uuid),
+        (SELECT item_id FROM items WHERE items.uuid = @item_uuid),
+        (SELECT review_id FROM reviews WHERE reviews.uuid = sqlc.narg(review_uuid)),
+        @watched_date,
+        @rewatch,
+        @rating,
+        @location,
+        @format
+    )
+RETURNING
+    uuid;
+
+-- name: GetWatch :one
+SELECT
+    w.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
+    r.uuid AS review_uuid,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.location,
+    w.format,
+    w.created_date,
+    w.updated_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+        LEFT JOIN reviews r ON r.review_id = w.review_id
+WHERE
+    w.uuid = @watch_uuid
+LIMIT
+    1;
+
+-- name: GetWatchesCountForUser :one
+SELECT COUNT(*)
+FROM watches w
+JOIN users u ON u.user_id = w.user_id
+WHERE
+    u.uuid = @user_uuid;
+
+-- name: GetWatchesForUser :many
+-- Returns a user's diary, most recently watched first
+SELECT
+    w.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
+    r.uuid AS review_uuid,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.location,
+    w.format,
+    w.created_date,
+    w.updated_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+        LEFT JOIN reviews r ON r.review_id = w.review_id
+WHERE
+    u.uuid = @user_uuid
+ORDER BY
+    w.watched_date DESC,
+    w.watch_id DESC
+LIMIT
+    @page_size
+    OFFSET
+    @page;
+
+-- name: UpdateWatch :exec
+UPDATE watches
+SET
+    review_id = (SELECT review_id FROM reviews WHERE reviews.uuid = sqlc.narg(review_uuid)),
+    watched_date = @watched_date,
+    rewatch = @rewatch,
+    rating = @rating,
+    location = @location,
+    format = @format,
+    updated_date = CURRENT_TIMESTAMP
+WHERE
+    watches.uuid = @watch_uuid;
+
+-- name: DeleteWatch :exec
+DELETE FROM watches
+WHERE
+    uuid = @watch_uuid;
+
+-- name: CheckWatchExists :one
+SELECT COUNT(*)
+FROM watches w
+JOIN items i ON i.item_id = w.item_id
+JOIN users u ON u.user_id = w.user_id
+WHERE
+    u.uuid = @user_uuid
+    AND i.uuid = @item_uuid
+    AND w.watched_date = @watched_date;
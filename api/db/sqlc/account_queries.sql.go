@@ -0,0 +1,713 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: account_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getItemsForUserExport = `-- name: GetItemsForUserExport :many
+SELECT
+    i.uuid,
+    i.title,
+    i.tmdb_id,
+    i.imdb_id,
+    i.release_date,
+    i.runtime,
+    i.overview,
+    i.original_language,
+    i.poster_path,
+    i.backdrop_path,
+    i.genres,
+    i.created_date
+FROM
+    items i
+WHERE
+    i.item_id IN (
+        SELECT li.item_id
+        FROM list_items li
+        JOIN lists l ON l.list_id = li.list_id
+        JOIN users u ON u.user_id = l.user_id
+        WHERE u.uuid = $1
+        UNION
+        SELECT r.item_id
+        FROM reviews r
+        JOIN users u ON u.user_id = r.user_id
+        WHERE u.uuid = $1
+        UNION
+        SELECT w.item_id
+        FROM watches w
+        JOIN users u ON u.user_id = w.user_id
+        WHERE u.uuid = $1
+    )
+ORDER BY
+    i.item_id
+`
+
+type GetItemsForUserExportRow struct {
+	Uuid             pgtype.UUID        `json:"uuid"`
+	Title            string             `json:"title"`
+	TmdbID           pgtype.Int8        `json:"tmdb_id"`
+	ImdbID           pgtype.Text        `json:"imdb_id"`
+	ReleaseDate      pgtype.Date        `json:"release_date"`
+	Runtime          pgtype.Int4        `json:"runtime"`
+	Overview         pgtype.Text        `json:"overview"`
+	OriginalLanguage pgtype.Text        `json:"original_language"`
+	PosterPath       pgtype.Text        `json:"poster_path"`
+	BackdropPath     pgtype.Text        `json:"backdrop_path"`
+	Genres           []string           `json:"genres"`
+	CreatedDate      pgtype.Timestamptz `json:"created_date"`
+}
+
+// Returns every item the user has on a list, reviewed or watched
+func (q *Queries) GetItemsForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetItemsForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getItemsForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsForUserExportRow
+	for rows.Next() {
+		var i GetItemsForUserExportRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Title,
+			&i.TmdbID,
+			&i.ImdbID,
+			&i.ReleaseDate,
+			&i.Runtime,
+			&i.Overview,
+			&i.OriginalLanguage,
+			&i.PosterPath,
+			&i.BackdropPath,
+			&i.Genres,
+			&i.CreatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListItemsForUserExport = `-- name: GetListItemsForUserExport :many
+SELECT
+    li.uuid,
+    l.uuid AS list_uuid,
+    i.uuid AS item_uuid,
+    s.uuid AS status_uuid,
+    li.rank,
+    li.created_date
+FROM
+    list_items li
+        JOIN lists l ON l.list_id = li.list_id
+        JOIN items i ON i.item_id = li.item_id
+        JOIN users u ON u.user_id = l.user_id
+        LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    li.list_id,
+    li.status_id,
+    li.rank
+`
+
+type GetListItemsForUserExportRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ListUuid    pgtype.UUID        `json:"list_uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	StatusUuid  pgtype.UUID        `json:"status_uuid"`
+	Rank        string             `json:"rank"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+func (q *Queries) GetListItemsForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetListItemsForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getListItemsForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetListItemsForUserExportRow
+	for rows.Next() {
+		var i GetListItemsForUserExportRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ListUuid,
+			&i.ItemUuid,
+			&i.StatusUuid,
+			&i.Rank,
+			&i.CreatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListStatusesForUserExport = `-- name: GetListStatusesForUserExport :many
+SELECT
+    l.uuid AS list_uuid,
+    s.uuid AS status_uuid,
+    ls.rank,
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN lists l ON l.list_id = ls.list_id
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    ls.list_id,
+    ls.rank
+`
+
+type GetListStatusesForUserExportRow struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Rank       string      `json:"rank"`
+	WipLimit   pgtype.Int4 `json:"wip_limit"`
+}
+
+func (q *Queries) GetListStatusesForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetListStatusesForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getListStatusesForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetListStatusesForUserExportRow
+	for rows.Next() {
+		var i GetListStatusesForUserExportRow
+		if err := rows.Scan(
+			&i.ListUuid,
+			&i.StatusUuid,
+			&i.Rank,
+			&i.WipLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListsForUserExport = `-- name: GetListsForUserExport :many
+SELECT
+    l.uuid,
+    l.name,
+    l.created_date
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    l.created_date,
+    l.list_id
+`
+
+type GetListsForUserExportRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	Name        string             `json:"name"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+func (q *Queries) GetListsForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetListsForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getListsForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetListsForUserExportRow
+	for rows.Next() {
+		var i GetListsForUserExportRow
+		if err := rows.Scan(&i.Uuid, &i.Name, &i.CreatedDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewsForUserExport = `-- name: GetReviewsForUserExport :many
+SELECT
+    r.uuid,
+    i.uuid AS item_uuid,
+    r.content,
+    r.rating,
+    r.created_date,
+    r.updated_date
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    r.review_id
+`
+
+type GetReviewsForUserExportRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	Content     string             `json:"content"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+func (q *Queries) GetReviewsForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetReviewsForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getReviewsForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewsForUserExportRow
+	for rows.Next() {
+		var i GetReviewsForUserExportRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ItemUuid,
+			&i.Content,
+			&i.Rating,
+			&i.CreatedDate,
+			&i.UpdatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStatusUuidByLabelForUser = `-- name: GetStatusUuidByLabelForUser :one
+SELECT
+    s.uuid
+FROM
+    statuses s
+        JOIN users u ON u.user_id = s.user_id
+WHERE
+    u.uuid = $1
+    AND s.label = $2
+LIMIT
+    1
+`
+
+type GetStatusUuidByLabelForUserParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	Label    pgtype.Text `json:"label"`
+}
+
+func (q *Queries) GetStatusUuidByLabelForUser(ctx context.Context, arg GetStatusUuidByLabelForUserParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getStatusUuidByLabelForUser, arg.UserUuid, arg.Label)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const getStatusesForUserExport = `-- name: GetStatusesForUserExport :many
+SELECT
+    s.uuid,
+    s.label,
+    s.created_date
+FROM
+    statuses s
+        JOIN users u ON u.user_id = s.user_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    s.status_id
+`
+
+type GetStatusesForUserExportRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	Label       pgtype.Text        `json:"label"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+func (q *Queries) GetStatusesForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetStatusesForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getStatusesForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStatusesForUserExportRow
+	for rows.Next() {
+		var i GetStatusesForUserExportRow
+		if err := rows.Scan(&i.Uuid, &i.Label, &i.CreatedDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserForExport = `-- name: GetUserForExport :one
+SELECT
+    uuid,
+    username,
+    email,
+    full_name,
+    bio,
+    created_date
+FROM
+    users
+WHERE
+    uuid = $1
+LIMIT
+    1
+`
+
+type GetUserForExportRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	Username    string             `json:"username"`
+	Email       string             `json:"email"`
+	FullName    pgtype.Text        `json:"full_name"`
+	Bio         pgtype.Text        `json:"bio"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+func (q *Queries) GetUserForExport(ctx context.Context, userUuid pgtype.UUID) (GetUserForExportRow, error) {
+	row := q.db.QueryRow(ctx, getUserForExport, userUuid)
+	var i GetUserForExportRow
+	err := row.Scan(
+		&i.Uuid,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.Bio,
+		&i.CreatedDate,
+	)
+	return i, err
+}
+
+const getWatchesForUserExport = `-- name: GetWatchesForUserExport :many
+SELECT
+    w.uuid,
+    i.uuid AS item_uuid,
+    r.uuid AS review_uuid,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.location,
+    w.format,
+    w.created_date,
+    w.updated_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+        LEFT JOIN reviews r ON r.review_id = w.review_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    w.watch_id
+`
+
+type GetWatchesForUserExportRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ReviewUuid  pgtype.UUID        `json:"review_uuid"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	Location    pgtype.Text        `json:"location"`
+	Format      pgtype.Text        `json:"format"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+func (q *Queries) GetWatchesForUserExport(ctx context.Context, userUuid pgtype.UUID) ([]GetWatchesForUserExportRow, error) {
+	rows, err := q.db.Query(ctx, getWatchesForUserExport, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWatchesForUserExportRow
+	for rows.Next() {
+		var i GetWatchesForUserExportRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ItemUuid,
+			&i.ReviewUuid,
+			&i.WatchedDate,
+			&i.Rewatch,
+			&i.Rating,
+			&i.Location,
+			&i.Format,
+			&i.CreatedDate,
+			&i.UpdatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const importList = `-- name: ImportList :one
+INSERT INTO
+    lists (uuid, name, user_id, created_date)
+VALUES
+    (
+        $1,
+        $2,
+        (SELECT user_id FROM users WHERE users.uuid = $3),
+        $4
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid
+`
+
+type ImportListParams struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	Name        string             `json:"name"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+// Inserts a list with a known UUID. Returns no rows if the UUID is already taken
+func (q *Queries) ImportList(ctx context.Context, arg ImportListParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, importList,
+		arg.Uuid,
+		arg.Name,
+		arg.UserUuid,
+		arg.CreatedDate,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const importListItem = `-- name: ImportListItem :one
+INSERT INTO
+    list_items (uuid, list_id, item_id, status_id, rank, created_date)
+VALUES
+    (
+        $1,
+        (SELECT list_id FROM lists WHERE lists.uuid = $2),
+        (SELECT item_id FROM items WHERE items.uuid = $3),
+        (SELECT status_id FROM statuses WHERE statuses.uuid = $4),
+        $5,
+        $6
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid
+`
+
+type ImportListItemParams struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ListUuid    pgtype.UUID        `json:"list_uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	StatusUuid  pgtype.UUID        `json:"status_uuid"`
+	Rank        string             `json:"rank"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+// Inserts a list item with a known UUID. Returns no rows if the UUID is already taken
+func (q *Queries) ImportListItem(ctx context.Context, arg ImportListItemParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, importListItem,
+		arg.Uuid,
+		arg.ListUuid,
+		arg.ItemUuid,
+		arg.StatusUuid,
+		arg.Rank,
+		arg.CreatedDate,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const importListStatus = `-- name: ImportListStatus :exec
+INSERT INTO
+    list_statuses (list_id, status_id, rank, wip_limit)
+VALUES
+    (
+        (SELECT list_id FROM lists WHERE lists.uuid = $1),
+        (SELECT status_id FROM statuses WHERE statuses.uuid = $2),
+        $3,
+        $4
+    )
+`
+
+type ImportListStatusParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Rank       string      `json:"rank"`
+	WipLimit   pgtype.Int4 `json:"wip_limit"`
+}
+
+func (q *Queries) ImportListStatus(ctx context.Context, arg ImportListStatusParams) error {
+	_, err := q.db.Exec(ctx, importListStatus,
+		arg.ListUuid,
+		arg.StatusUuid,
+		arg.Rank,
+		arg.WipLimit,
+	)
+	return err
+}
+
+const importReview = `-- name: ImportReview :one
+INSERT INTO
+    reviews (uuid, item_id, user_id, content, rating, created_date, updated_date)
+VALUES
+    (
+        $1,
+        (SELECT item_id FROM items WHERE items.uuid = $2),
+        (SELECT user_id FROM users WHERE users.uuid = $3),
+        $4,
+        $5,
+        $6,
+        $7
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid
+`
+
+type ImportReviewParams struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	Content     string             `json:"content"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+// Inserts a review with a known UUID. Returns no rows if the UUID is already taken
+func (q *Queries) ImportReview(ctx context.Context, arg ImportReviewParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, importReview,
+		arg.Uuid,
+		arg.ItemUuid,
+		arg.UserUuid,
+		arg.Content,
+		arg.Rating,
+		arg.CreatedDate,
+		arg.UpdatedDate,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const importStatus = `-- name: ImportStatus :one
+INSERT INTO
+    statuses (uuid, label, user_id, created_date)
+VALUES
+    (
+        $1,
+        $2,
+        (SELECT user_id FROM users WHERE users.uuid = $3),
+        $4
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid
+`
+
+type ImportStatusParams struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	Label       pgtype.Text        `json:"label"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+// Inserts a status with a known UUID. Returns no rows if the UUID is already taken
+func (q *Queries) ImportStatus(ctx context.Context, arg ImportStatusParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, importStatus,
+		arg.Uuid,
+		arg.Label,
+		arg.UserUuid,
+		arg.CreatedDate,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const importWatch = `-- name: ImportWatch :one
+INSERT INTO
+    watches (uuid, user_id, item_id, review_id, watched_date, rewatch, rating, location, format, created_date, updated_date)
+VALUES
+    (
+        $1,
+        (SELECT user_id FROM users WHERE users.uuid = $2),
+        (SELECT item_id FROM items WHERE items.uuid = $3),
+        (SELECT review_id FROM reviews WHERE reviews.uuid = $4),
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11
+    )
+ON CONFLICT (uuid) DO NOTHING
+RETURNING
+    uuid
+`
+
+type ImportWatchParams struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ReviewUuid  pgtype.UUID        `json:"review_uuid"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	Location    pgtype.Text        `json:"location"`
+	Format      pgtype.Text        `json:"format"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+// Inserts a watch with a known UUID. Returns no rows if the UUID is already taken
+func (q *Queries) ImportWatch(ctx context.Context, arg ImportWatchParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, importWatch,
+		arg.Uuid,
+		arg.UserUuid,
+		arg.ItemUuid,
+		arg.ReviewUuid,
+		arg.WatchedDate,
+		arg.Rewatch,
+		arg.Rating,
+		arg.Location,
+		arg.Format,
+		arg.CreatedDate,
+		arg.UpdatedDate,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
@@ -0,0 +1,96 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: email_verification_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addEmailVerificationToken = `-- name: AddEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at)
+SELECT user_id, $1, $2, $3
+FROM users
+WHERE uuid = $4
+`
+
+type AddEmailVerificationTokenParams struct {
+	Email     string             `json:"email"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UserUuid  pgtype.UUID        `json:"user_uuid"`
+}
+
+func (q *Queries) AddEmailVerificationToken(ctx context.Context, arg AddEmailVerificationTokenParams) error {
+	_, err := q.db.Exec(ctx, addEmailVerificationToken,
+		arg.Email,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.UserUuid,
+	)
+	return err
+}
+
+const deleteEmailVerificationTokensForUser = `-- name: DeleteEmailVerificationTokensForUser :exec
+DELETE FROM email_verification_tokens evt
+USING users u
+WHERE evt.user_id = u.user_id
+  AND u.uuid = $1
+`
+
+func (q *Queries) DeleteEmailVerificationTokensForUser(ctx context.Context, userUuid pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteEmailVerificationTokensForUser, userUuid)
+	return err
+}
+
+const deleteExpiredEmailVerificationTokens = `-- name: DeleteExpiredEmailVerificationTokens :execrows
+DELETE FROM email_verification_tokens
+WHERE expires_at < CURRENT_TIMESTAMP
+`
+
+func (q *Queries) DeleteExpiredEmailVerificationTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredEmailVerificationTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getLatestEmailVerificationTokenDate = `-- name: GetLatestEmailVerificationTokenDate :one
+SELECT evt.created_at
+FROM email_verification_tokens evt
+JOIN users u ON evt.user_id = u.user_id
+WHERE u.uuid = $1
+ORDER BY evt.created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestEmailVerificationTokenDate(ctx context.Context, userUuid pgtype.UUID) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getLatestEmailVerificationTokenDate, userUuid)
+	var created_at pgtype.Timestamptz
+	err := row.Scan(&created_at)
+	return created_at, err
+}
+
+const useEmailVerificationToken = `-- name: UseEmailVerificationToken :one
+DELETE FROM email_verification_tokens
+WHERE token_hash = $1
+RETURNING user_id, email, expires_at
+`
+
+type UseEmailVerificationTokenRow struct {
+	UserID    int64              `json:"user_id"`
+	Email     string             `json:"email"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) UseEmailVerificationToken(ctx context.Context, tokenHash string) (UseEmailVerificationTokenRow, error) {
+	row := q.db.QueryRow(ctx, useEmailVerificationToken, tokenHash)
+	var i UseEmailVerificationTokenRow
+	err := row.Scan(&i.UserID, &i.Email, &i.ExpiresAt)
+	return i, err
+}
@@ -0,0 +1,281 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: export_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getRatingsForExport = `-- name: GetRatingsForExport :many
+SELECT
+    i.title,
+    i.release_date,
+    r.rating,
+    COALESCE(r.updated_date, r.created_date)::timestamptz AS rated_date
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+WHERE
+    u.uuid = $1
+    AND r.rating IS NOT NULL
+ORDER BY
+    r.created_date,
+    r.review_id
+LIMIT
+    $3
+    OFFSET
+    $2
+`
+
+type GetRatingsForExportParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	Offset   int32       `json:"offset"`
+	PageSize int32       `json:"page_size"`
+}
+
+type GetRatingsForExportRow struct {
+	Title       string             `json:"title"`
+	ReleaseDate pgtype.Date        `json:"release_date"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	RatedDate   pgtype.Timestamptz `json:"rated_date"`
+}
+
+func (q *Queries) GetRatingsForExport(ctx context.Context, arg GetRatingsForExportParams) ([]GetRatingsForExportRow, error) {
+	rows, err := q.db.Query(ctx, getRatingsForExport, arg.UserUuid, arg.Offset, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRatingsForExportRow
+	for rows.Next() {
+		var i GetRatingsForExportRow
+		if err := rows.Scan(
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Rating,
+			&i.RatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewsForExport = `-- name: GetReviewsForExport :many
+SELECT
+    i.title,
+    i.release_date,
+    r.content,
+    r.rating,
+    r.created_date,
+    lw.watched_date,
+    COALESCE(lw.rewatch, FALSE)::boolean AS rewatch
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+        LEFT JOIN LATERAL (
+            SELECT
+                w.watched_date,
+                w.rewatch
+            FROM
+                watches w
+            WHERE
+                w.review_id = r.review_id
+            ORDER BY
+                w.watched_date DESC
+            LIMIT
+                1
+        ) lw ON TRUE
+WHERE
+    u.uuid = $1
+    AND r.content <> ''
+ORDER BY
+    r.created_date,
+    r.review_id
+LIMIT
+    $3
+    OFFSET
+    $2
+`
+
+type GetReviewsForExportParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	Offset   int32       `json:"offset"`
+	PageSize int32       `json:"page_size"`
+}
+
+type GetReviewsForExportRow struct {
+	Title       string             `json:"title"`
+	ReleaseDate pgtype.Date        `json:"release_date"`
+	Content     string             `json:"content"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+}
+
+// Returns reviews with content. The watched date and rewatch flag come from the latest watch linked to the review
+func (q *Queries) GetReviewsForExport(ctx context.Context, arg GetReviewsForExportParams) ([]GetReviewsForExportRow, error) {
+	rows, err := q.db.Query(ctx, getReviewsForExport, arg.UserUuid, arg.Offset, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewsForExportRow
+	for rows.Next() {
+		var i GetReviewsForExportRow
+		if err := rows.Scan(
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Content,
+			&i.Rating,
+			&i.CreatedDate,
+			&i.WatchedDate,
+			&i.Rewatch,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWatchesForExport = `-- name: GetWatchesForExport :many
+SELECT
+    i.title,
+    i.release_date,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.created_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    w.watched_date,
+    w.watch_id
+LIMIT
+    $3
+    OFFSET
+    $2
+`
+
+type GetWatchesForExportParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	Offset   int32       `json:"offset"`
+	PageSize int32       `json:"page_size"`
+}
+
+type GetWatchesForExportRow struct {
+	Title       string             `json:"title"`
+	ReleaseDate pgtype.Date        `json:"release_date"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+func (q *Queries) GetWatchesForExport(ctx context.Context, arg GetWatchesForExportParams) ([]GetWatchesForExportRow, error) {
+	rows, err := q.db.Query(ctx, getWatchesForExport, arg.UserUuid, arg.Offset, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWatchesForExportRow
+	for rows.Next() {
+		var i GetWatchesForExportRow
+		if err := rows.Scan(
+			&i.Title,
+			&i.ReleaseDate,
+			&i.WatchedDate,
+			&i.Rewatch,
+			&i.Rating,
+			&i.CreatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWatchlistForExport = `-- name: GetWatchlistForExport :many
+SELECT
+    i.title,
+    i.release_date,
+    li.created_date AS added_date
+FROM
+    list_items li
+        JOIN lists l ON l.list_id = li.list_id
+        JOIN statuses s ON s.status_id = li.status_id
+        JOIN items i ON i.item_id = li.item_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+ORDER BY
+    li.created_date,
+    li.list_item_id
+LIMIT
+    $4
+    OFFSET
+    $3
+`
+
+type GetWatchlistForExportParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Offset     int32       `json:"offset"`
+	PageSize   int32       `json:"page_size"`
+}
+
+type GetWatchlistForExportRow struct {
+	Title       string             `json:"title"`
+	ReleaseDate pgtype.Date        `json:"release_date"`
+	AddedDate   pgtype.Timestamptz `json:"added_date"`
+}
+
+// Returns the items in the status column used for the watchlist, in the order they were added
+func (q *Queries) GetWatchlistForExport(ctx context.Context, arg GetWatchlistForExportParams) ([]GetWatchlistForExportRow, error) {
+	rows, err := q.db.Query(ctx, getWatchlistForExport,
+		arg.ListUuid,
+		arg.StatusUuid,
+		arg.Offset,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWatchlistForExportRow
+	for rows.Next() {
+		var i GetWatchlistForExportRow
+		if err := rows.Scan(&i.Title, &i.ReleaseDate, &i.AddedDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,518 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: import_job_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addImportJobRow = `-- name: AddImportJobRow :exec
+INSERT INTO
+    import_job_rows (import_job_id, file, line, kind, external_id, title, year, rating, outcome, reason)
+VALUES
+    (
+        (SELECT import_job_id FROM import_jobs WHERE import_jobs.uuid = $1),
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10
+    )
+`
+
+type AddImportJobRowParams struct {
+	JobUuid    pgtype.UUID    `json:"job_uuid"`
+	File       string         `json:"file"`
+	Line       int32          `json:"line"`
+	Kind       string         `json:"kind"`
+	ExternalID string         `json:"external_id"`
+	Title      string         `json:"title"`
+	Year       string         `json:"year"`
+	Rating     pgtype.Numeric `json:"rating"`
+	Outcome    pgtype.Text    `json:"outcome"`
+	Reason     pgtype.Text    `json:"reason"`
+}
+
+// Rows that can't be imported at all are stored with an outcome so that they appear in the report
+func (q *Queries) AddImportJobRow(ctx context.Context, arg AddImportJobRowParams) error {
+	_, err := q.db.Exec(ctx, addImportJobRow,
+		arg.JobUuid,
+		arg.File,
+		arg.Line,
+		arg.Kind,
+		arg.ExternalID,
+		arg.Title,
+		arg.Year,
+		arg.Rating,
+		arg.Outcome,
+		arg.Reason,
+	)
+	return err
+}
+
+const createImportJob = `-- name: CreateImportJob :one
+INSERT INTO
+    import_jobs (user_id, source, list_id, status_id)
+VALUES
+    (
+        (SELECT user_id FROM users WHERE users.uuid = $1),
+        $2,
+        (SELECT list_id FROM lists WHERE lists.uuid = $3),
+        (SELECT status_id FROM statuses WHERE statuses.uuid = $4)
+    )
+RETURNING
+    uuid
+`
+
+type CreateImportJobParams struct {
+	UserUuid   pgtype.UUID `json:"user_uuid"`
+	Source     string      `json:"source"`
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) CreateImportJob(ctx context.Context, arg CreateImportJobParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, createImportJob,
+		arg.UserUuid,
+		arg.Source,
+		arg.ListUuid,
+		arg.StatusUuid,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const finishImportJob = `-- name: FinishImportJob :exec
+UPDATE import_jobs
+SET
+    state = $1,
+    error = $2,
+    finished_date = CURRENT_TIMESTAMP
+WHERE
+    uuid = $3
+`
+
+type FinishImportJobParams struct {
+	State   string      `json:"state"`
+	Error   pgtype.Text `json:"error"`
+	JobUuid pgtype.UUID `json:"job_uuid"`
+}
+
+func (q *Queries) FinishImportJob(ctx context.Context, arg FinishImportJobParams) error {
+	_, err := q.db.Exec(ctx, finishImportJob, arg.State, arg.Error, arg.JobUuid)
+	return err
+}
+
+const getImportJob = `-- name: GetImportJob :one
+SELECT
+    j.uuid,
+    u.uuid AS user_uuid,
+    j.source,
+    j.state,
+    l.uuid AS list_uuid,
+    s.uuid AS status_uuid,
+    j.error,
+    j.created_date,
+    j.started_date,
+    j.finished_date,
+    p.total,
+    p.processed,
+    p.matched,
+    p.ratings_created,
+    p.watchlist_added,
+    p.skipped
+FROM
+    import_jobs j
+        JOIN users u ON u.user_id = j.user_id
+        LEFT JOIN lists l ON l.list_id = j.list_id
+        LEFT JOIN statuses s ON s.status_id = j.status_id
+        CROSS JOIN LATERAL (
+            SELECT
+                COUNT(*) AS total,
+                COUNT(*) FILTER (WHERE r.outcome IS NOT NULL) AS processed,
+                COUNT(*) FILTER (WHERE r.outcome IN ('created', 'skipped')) AS matched,
+                COUNT(*) FILTER (WHERE r.kind = 'rating' AND r.outcome = 'created') AS ratings_created,
+                COUNT(*) FILTER (WHERE r.kind = 'watchlist' AND r.outcome = 'created') AS watchlist_added,
+                COUNT(*) FILTER (WHERE r.outcome = 'skipped') AS skipped
+            FROM
+                import_job_rows r
+            WHERE
+                r.import_job_id = j.import_job_id
+        ) p
+WHERE
+    j.uuid = $1
+LIMIT
+    1
+`
+
+type GetImportJobRow struct {
+	Uuid           pgtype.UUID        `json:"uuid"`
+	UserUuid       pgtype.UUID        `json:"user_uuid"`
+	Source         string             `json:"source"`
+	State          string             `json:"state"`
+	ListUuid       pgtype.UUID        `json:"list_uuid"`
+	StatusUuid     pgtype.UUID        `json:"status_uuid"`
+	Error          pgtype.Text        `json:"error"`
+	CreatedDate    pgtype.Timestamptz `json:"created_date"`
+	StartedDate    pgtype.Timestamptz `json:"started_date"`
+	FinishedDate   pgtype.Timestamptz `json:"finished_date"`
+	Total          int64              `json:"total"`
+	Processed      int64              `json:"processed"`
+	Matched        int64              `json:"matched"`
+	RatingsCreated int64              `json:"ratings_created"`
+	WatchlistAdded int64              `json:"watchlist_added"`
+	Skipped        int64              `json:"skipped"`
+}
+
+func (q *Queries) GetImportJob(ctx context.Context, jobUuid pgtype.UUID) (GetImportJobRow, error) {
+	row := q.db.QueryRow(ctx, getImportJob, jobUuid)
+	var i GetImportJobRow
+	err := row.Scan(
+		&i.Uuid,
+		&i.UserUuid,
+		&i.Source,
+		&i.State,
+		&i.ListUuid,
+		&i.StatusUuid,
+		&i.Error,
+		&i.CreatedDate,
+		&i.StartedDate,
+		&i.FinishedDate,
+		&i.Total,
+		&i.Processed,
+		&i.Matched,
+		&i.RatingsCreated,
+		&i.WatchlistAdded,
+		&i.Skipped,
+	)
+	return i, err
+}
+
+const getImportJobsCountForUser = `-- name: GetImportJobsCountForUser :one
+SELECT COUNT(*)
+FROM import_jobs j
+JOIN users u ON u.user_id = j.user_id
+WHERE
+    u.uuid = $1
+`
+
+func (q *Queries) GetImportJobsCountForUser(ctx context.Context, userUuid pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getImportJobsCountForUser, userUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getImportJobsForUser = `-- name: GetImportJobsForUser :many
+SELECT
+    j.uuid,
+    u.uuid AS user_uuid,
+    j.source,
+    j.state,
+    l.uuid AS list_uuid,
+    s.uuid AS status_uuid,
+    j.error,
+    j.created_date,
+    j.started_date,
+    j.finished_date,
+    p.total,
+    p.processed,
+    p.matched,
+    p.ratings_created,
+    p.watchlist_added,
+    p.skipped
+FROM
+    import_jobs j
+        JOIN users u ON u.user_id = j.user_id
+        LEFT JOIN lists l ON l.list_id = j.list_id
+        LEFT JOIN statuses s ON s.status_id = j.status_id
+        CROSS JOIN LATERAL (
+            SELECT
+                COUNT(*) AS total,
+                COUNT(*) FILTER (WHERE r.outcome IS NOT NULL) AS processed,
+                COUNT(*) FILTER (WHERE r.outcome IN ('created', 'skipped')) AS matched,
+                COUNT(*) FILTER (WHERE r.kind = 'rating' AND r.outcome = 'created') AS ratings_created,
+                COUNT(*) FILTER (WHERE r.kind = 'watchlist' AND r.outcome = 'created') AS watchlist_added,
+                COUNT(*) FILTER (WHERE r.outcome = 'skipped') AS skipped
+            FROM
+                import_job_rows r
+            WHERE
+                r.import_job_id = j.import_job_id
+        ) p
+WHERE
+    u.uuid = $1
+ORDER BY
+    j.import_job_id DESC
+LIMIT
+    $3
+    OFFSET
+    $2
+`
+
+type GetImportJobsForUserParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	Page     int32       `json:"page"`
+	PageSize int32       `json:"page_size"`
+}
+
+type GetImportJobsForUserRow struct {
+	Uuid           pgtype.UUID        `json:"uuid"`
+	UserUuid       pgtype.UUID        `json:"user_uuid"`
+	Source         string             `json:"source"`
+	State          string             `json:"state"`
+	ListUuid       pgtype.UUID        `json:"list_uuid"`
+	StatusUuid     pgtype.UUID        `json:"status_uuid"`
+	Error          pgtype.Text        `json:"error"`
+	CreatedDate    pgtype.Timestamptz `json:"created_date"`
+	StartedDate    pgtype.Timestamptz `json:"started_date"`
+	FinishedDate   pgtype.Timestamptz `json:"finished_date"`
+	Total          int64              `json:"total"`
+	Processed      int64              `json:"processed"`
+	Matched        int64              `json:"matched"`
+	RatingsCreated int64              `json:"ratings_created"`
+	WatchlistAdded int64              `json:"watchlist_added"`
+	Skipped        int64              `json:"skipped"`
+}
+
+// Returns a user's import jobs, most recent first
+func (q *Queries) GetImportJobsForUser(ctx context.Context, arg GetImportJobsForUserParams) ([]GetImportJobsForUserRow, error) {
+	rows, err := q.db.Query(ctx, getImportJobsForUser, arg.UserUuid, arg.Page, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetImportJobsForUserRow
+	for rows.Next() {
+		var i GetImportJobsForUserRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.UserUuid,
+			&i.Source,
+			&i.State,
+			&i.ListUuid,
+			&i.StatusUuid,
+			&i.Error,
+			&i.CreatedDate,
+			&i.StartedDate,
+			&i.FinishedDate,
+			&i.Total,
+			&i.Processed,
+			&i.Matched,
+			&i.RatingsCreated,
+			&i.WatchlistAdded,
+			&i.Skipped,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingImportJobRows = `-- name: GetPendingImportJobRows :many
+SELECT
+    r.import_job_row_id,
+    r.kind,
+    r.external_id,
+    r.rating
+FROM
+    import_job_rows r
+        JOIN import_jobs j ON j.import_job_id = r.import_job_id
+WHERE
+    j.uuid = $1
+    AND r.outcome IS NULL
+ORDER BY
+    r.import_job_row_id
+LIMIT
+    $2
+`
+
+type GetPendingImportJobRowsParams struct {
+	JobUuid   pgtype.UUID `json:"job_uuid"`
+	BatchSize int32       `json:"batch_size"`
+}
+
+type GetPendingImportJobRowsRow struct {
+	ImportJobRowID pgtype.Int8    `json:"import_job_row_id"`
+	Kind           string         `json:"kind"`
+	ExternalID     string         `json:"external_id"`
+	Rating         pgtype.Numeric `json:"rating"`
+}
+
+// Returns the next rows of a job that haven't been processed, in the order they were uploaded
+func (q *Queries) GetPendingImportJobRows(ctx context.Context, arg GetPendingImportJobRowsParams) ([]GetPendingImportJobRowsRow, error) {
+	rows, err := q.db.Query(ctx, getPendingImportJobRows, arg.JobUuid, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPendingImportJobRowsRow
+	for rows.Next() {
+		var i GetPendingImportJobRowsRow
+		if err := rows.Scan(
+			&i.ImportJobRowID,
+			&i.Kind,
+			&i.ExternalID,
+			&i.Rating,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnfinishedImportJobs = `-- name: GetUnfinishedImportJobs :many
+SELECT
+    uuid
+FROM
+    import_jobs
+WHERE
+    state IN ('pending', 'running')
+ORDER BY
+    import_job_id
+`
+
+// Returns the jobs that are waiting to run or were interrupted while running, oldest first
+func (q *Queries) GetUnfinishedImportJobs(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getUnfinishedImportJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var uuid pgtype.UUID
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		items = append(items, uuid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnmatchedImportJobRows = `-- name: GetUnmatchedImportJobRows :many
+SELECT
+    r.file,
+    r.line,
+    r.title,
+    r.year,
+    r.reason
+FROM
+    import_job_rows r
+        JOIN import_jobs j ON j.import_job_id = r.import_job_id
+WHERE
+    j.uuid = $1
+    AND r.outcome = 'unmatched'
+ORDER BY
+    r.import_job_row_id
+`
+
+type GetUnmatchedImportJobRowsRow struct {
+	File   string      `json:"file"`
+	Line   int32       `json:"line"`
+	Title  string      `json:"title"`
+	Year   string      `json:"year"`
+	Reason pgtype.Text `json:"reason"`
+}
+
+func (q *Queries) GetUnmatchedImportJobRows(ctx context.Context, jobUuid pgtype.UUID) ([]GetUnmatchedImportJobRowsRow, error) {
+	rows, err := q.db.Query(ctx, getUnmatchedImportJobRows, jobUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnmatchedImportJobRowsRow
+	for rows.Next() {
+		var i GetUnmatchedImportJobRowsRow
+		if err := rows.Scan(
+			&i.File,
+			&i.Line,
+			&i.Title,
+			&i.Year,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resumeImportJob = `-- name: ResumeImportJob :one
+UPDATE import_jobs
+SET
+    state = 'pending',
+    error = NULL,
+    finished_date = NULL
+WHERE
+    uuid = $1
+    AND state = 'failed'
+RETURNING
+    uuid
+`
+
+// Queues a failed job to run again. Returns no rows if the job hasn't failed
+func (q *Queries) ResumeImportJob(ctx context.Context, jobUuid pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, resumeImportJob, jobUuid)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const setImportJobRowOutcome = `-- name: SetImportJobRowOutcome :exec
+UPDATE import_job_rows
+SET
+    outcome = $1,
+    reason = $2
+WHERE
+    import_job_row_id = $3
+`
+
+type SetImportJobRowOutcomeParams struct {
+	Outcome        pgtype.Text `json:"outcome"`
+	Reason         pgtype.Text `json:"reason"`
+	ImportJobRowID pgtype.Int8 `json:"import_job_row_id"`
+}
+
+func (q *Queries) SetImportJobRowOutcome(ctx context.Context, arg SetImportJobRowOutcomeParams) error {
+	_, err := q.db.Exec(ctx, setImportJobRowOutcome, arg.Outcome, arg.Reason, arg.ImportJobRowID)
+	return err
+}
+
+const startImportJob = `-- name: StartImportJob :exec
+UPDATE import_jobs
+SET
+    state = 'running',
+    error = NULL,
+    started_date = COALESCE(started_date, CURRENT_TIMESTAMP)
+WHERE
+    uuid = $1
+`
+
+func (q *Queries) StartImportJob(ctx context.Context, jobUuid pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, startImportJob, jobUuid)
+	return err
+}
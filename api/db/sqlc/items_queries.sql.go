@@ -37,6 +37,72 @@ func (q *Queries) AddItem(ctx context.Context, title string) (AddItemRow, error)
 	return i, err
 }
 
+const addTmdbItem = `-- name: AddTmdbItem :one
+INSERT INTO
+    items (
+        title,
+        tmdb_id,
+        imdb_id,
+        release_date,
+        runtime,
+        overview,
+        original_language,
+        poster_path,
+        backdrop_path,
+        genres,
+        metadata_refreshed_at
+    )
+VALUES
+    (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        CURRENT_TIMESTAMP
+    )
+ON CONFLICT (tmdb_id) DO NOTHING
+RETURNING
+    uuid
+`
+
+type AddTmdbItemParams struct {
+	Title            string      `json:"title"`
+	TmdbID           pgtype.Int8 `json:"tmdb_id"`
+	ImdbID           pgtype.Text `json:"imdb_id"`
+	ReleaseDate      pgtype.Date `json:"release_date"`
+	Runtime          pgtype.Int4 `json:"runtime"`
+	Overview         pgtype.Text `json:"overview"`
+	OriginalLanguage pgtype.Text `json:"original_language"`
+	PosterPath       pgtype.Text `json:"poster_path"`
+	BackdropPath     pgtype.Text `json:"backdrop_path"`
+	Genres           []string    `json:"genres"`
+}
+
+// Returns no rows if an item with the same TMDB ID already exists
+func (q *Queries) AddTmdbItem(ctx context.Context, arg AddTmdbItemParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, addTmdbItem,
+		arg.Title,
+		arg.TmdbID,
+		arg.ImdbID,
+		arg.ReleaseDate,
+		arg.Runtime,
+		arg.Overview,
+		arg.OriginalLanguage,
+		arg.PosterPath,
+		arg.BackdropPath,
+		arg.Genres,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
 const deleteItem = `-- name: DeleteItem :exec
 DELETE FROM items
 WHERE
@@ -51,7 +117,10 @@ func (q *Queries) DeleteItem(ctx context.Context, itemUuid pgtype.UUID) error {
 const getAllItems = `-- name: GetAllItems :many
 SELECT
     uuid,
-    title
+    title,
+    tmdb_id,
+    release_date,
+    poster_path
 FROM
     items
 ORDER BY
@@ -68,8 +137,11 @@ type GetAllItemsParams struct {
 }
 
 type GetAllItemsRow struct {
-	Uuid  pgtype.UUID `json:"uuid"`
-	Title string      `json:"title"`
+	Uuid        pgtype.UUID `json:"uuid"`
+	Title       string      `json:"title"`
+	TmdbID      pgtype.Int8 `json:"tmdb_id"`
+	ReleaseDate pgtype.Date `json:"release_date"`
+	PosterPath  pgtype.Text `json:"poster_path"`
 }
 
 func (q *Queries) GetAllItems(ctx context.Context, arg GetAllItemsParams) ([]GetAllItemsRow, error) {
@@ -81,7 +153,13 @@ func (q *Queries) GetAllItems(ctx context.Context, arg GetAllItemsParams) ([]Get
 	var items []GetAllItemsRow
 	for rows.Next() {
 		var i GetAllItemsRow
-		if err := rows.Scan(&i.Uuid, &i.Title); err != nil {
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Title,
+			&i.TmdbID,
+			&i.ReleaseDate,
+			&i.PosterPath,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -96,28 +174,121 @@ const getItemByUuid = `-- name: GetItemByUuid :one
 SELECT
     uuid,
     title,
-    created_date
+    tmdb_id,
+    imdb_id,
+    release_date,
+    runtime,
+    overview,
+    original_language,
+    poster_path,
+    backdrop_path,
+    genres,
+    metadata_refreshed_at,
+    created_date,
+    (
+        SELECT COALESCE(ROUND(AVG(r.rating), 2), 0)::float8
+        FROM reviews r
+        WHERE r.item_id = items.item_id
+    ) AS average_rating,
+    (
+        SELECT COUNT(r.rating)
+        FROM reviews r
+        WHERE r.item_id = items.item_id
+    ) AS rating_count,
+    (
+        SELECT COUNT(*)
+        FROM reviews r
+        WHERE r.item_id = items.item_id
+    ) AS review_count
 FROM
     items
 WHERE
-    uuid = $1
+    items.uuid = $1
 LIMIT
     1
 `
 
 type GetItemByUuidRow struct {
-	Uuid        pgtype.UUID        `json:"uuid"`
-	Title       string             `json:"title"`
-	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	Uuid                pgtype.UUID        `json:"uuid"`
+	Title               string             `json:"title"`
+	TmdbID              pgtype.Int8        `json:"tmdb_id"`
+	ImdbID              pgtype.Text        `json:"imdb_id"`
+	ReleaseDate         pgtype.Date        `json:"release_date"`
+	Runtime             pgtype.Int4        `json:"runtime"`
+	Overview            pgtype.Text        `json:"overview"`
+	OriginalLanguage    pgtype.Text        `json:"original_language"`
+	PosterPath          pgtype.Text        `json:"poster_path"`
+	BackdropPath        pgtype.Text        `json:"backdrop_path"`
+	Genres              []string           `json:"genres"`
+	MetadataRefreshedAt pgtype.Timestamptz `json:"metadata_refreshed_at"`
+	CreatedDate         pgtype.Timestamptz `json:"created_date"`
+	AverageRating       float64            `json:"average_rating"`
+	RatingCount         int64              `json:"rating_count"`
+	ReviewCount         int64              `json:"review_count"`
 }
 
 func (q *Queries) GetItemByUuid(ctx context.Context, itemUuid pgtype.UUID) (GetItemByUuidRow, error) {
 	row := q.db.QueryRow(ctx, getItemByUuid, itemUuid)
 	var i GetItemByUuidRow
-	err := row.Scan(&i.Uuid, &i.Title, &i.CreatedDate)
+	err := row.Scan(
+		&i.Uuid,
+		&i.Title,
+		&i.TmdbID,
+		&i.ImdbID,
+		&i.ReleaseDate,
+		&i.Runtime,
+		&i.Overview,
+		&i.OriginalLanguage,
+		&i.PosterPath,
+		&i.BackdropPath,
+		&i.Genres,
+		&i.MetadataRefreshedAt,
+		&i.CreatedDate,
+		&i.AverageRating,
+		&i.RatingCount,
+		&i.ReviewCount,
+	)
 	return i, err
 }
 
+const getItemUuidByImdbId = `-- name: GetItemUuidByImdbId :one
+SELECT
+    uuid
+FROM
+    items
+WHERE
+    imdb_id = $1
+ORDER BY
+    item_id
+LIMIT
+    1
+`
+
+func (q *Queries) GetItemUuidByImdbId(ctx context.Context, imdbID pgtype.Text) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getItemUuidByImdbId, imdbID)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const getItemUuidByTmdbId = `-- name: GetItemUuidByTmdbId :one
+SELECT
+    uuid
+FROM
+    items
+WHERE
+    tmdb_id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) GetItemUuidByTmdbId(ctx context.Context, tmdbID pgtype.Int8) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getItemUuidByTmdbId, tmdbID)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
 const getItemsCount = `-- name: GetItemsCount :one
 SELECT COUNT (*)
 FROM items
@@ -130,6 +301,66 @@ func (q *Queries) GetItemsCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const getItemsDueForRefresh = `-- name: GetItemsDueForRefresh :many
+SELECT
+    uuid,
+    tmdb_id
+FROM
+    items
+WHERE
+    tmdb_id IS NOT NULL
+    AND (metadata_refreshed_at IS NULL OR metadata_refreshed_at < $1)
+ORDER BY
+    metadata_refreshed_at NULLS FIRST,
+    item_id
+LIMIT
+    $2
+`
+
+type GetItemsDueForRefreshParams struct {
+	StaleBefore pgtype.Timestamptz `json:"stale_before"`
+	BatchSize   int32              `json:"batch_size"`
+}
+
+type GetItemsDueForRefreshRow struct {
+	Uuid   pgtype.UUID `json:"uuid"`
+	TmdbID pgtype.Int8 `json:"tmdb_id"`
+}
+
+// Returns TMDB items that have not been refreshed since stale_before, oldest first
+func (q *Queries) GetItemsDueForRefresh(ctx context.Context, arg GetItemsDueForRefreshParams) ([]GetItemsDueForRefreshRow, error) {
+	rows, err := q.db.Query(ctx, getItemsDueForRefresh, arg.StaleBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsDueForRefreshRow
+	for rows.Next() {
+		var i GetItemsDueForRefreshRow
+		if err := rows.Scan(&i.Uuid, &i.TmdbID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const touchItemMetadataRefreshed = `-- name: TouchItemMetadataRefreshed :exec
+UPDATE items
+SET
+    metadata_refreshed_at = CURRENT_TIMESTAMP
+WHERE
+    uuid = $1
+`
+
+func (q *Queries) TouchItemMetadataRefreshed(ctx context.Context, itemUuid pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, touchItemMetadataRefreshed, itemUuid)
+	return err
+}
+
 const updateItem = `-- name: UpdateItem :one
 UPDATE items
 SET title = COALESCE($1, title)
@@ -155,3 +386,46 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (UpdateI
 	err := row.Scan(&i.Uuid, &i.Title)
 	return i, err
 }
+
+const updateItemMetadata = `-- name: UpdateItemMetadata :exec
+UPDATE items
+SET
+    imdb_id = $1,
+    release_date = $2,
+    runtime = $3,
+    overview = $4,
+    original_language = $5,
+    poster_path = $6,
+    backdrop_path = $7,
+    genres = $8,
+    metadata_refreshed_at = CURRENT_TIMESTAMP
+WHERE
+    uuid = $9
+`
+
+type UpdateItemMetadataParams struct {
+	ImdbID           pgtype.Text `json:"imdb_id"`
+	ReleaseDate      pgtype.Date `json:"release_date"`
+	Runtime          pgtype.Int4 `json:"runtime"`
+	Overview         pgtype.Text `json:"overview"`
+	OriginalLanguage pgtype.Text `json:"original_language"`
+	PosterPath       pgtype.Text `json:"poster_path"`
+	BackdropPath     pgtype.Text `json:"backdrop_path"`
+	Genres           []string    `json:"genres"`
+	ItemUuid         pgtype.UUID `json:"item_uuid"`
+}
+
+func (q *Queries) UpdateItemMetadata(ctx context.Context, arg UpdateItemMetadataParams) error {
+	_, err := q.db.Exec(ctx, updateItemMetadata,
+		arg.ImdbID,
+		arg.ReleaseDate,
+		arg.Runtime,
+		arg.Overview,
+		arg.OriginalLanguage,
+		arg.PosterPath,
+		arg.BackdropPath,
+		arg.Genres,
+		arg.ItemUuid,
+	)
+	return err
+}
@@ -11,91 +11,68 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const addItemToListAtPosition = `-- name: AddItemToListAtPosition :one
-WITH new_item AS (
-    INSERT INTO list_items (list_id, item_id, position, prev_item_id, next_item_id, status_id)
-        VALUES (
-                   (SELECT list_id FROM lists WHERE lists.uuid = $1),
-                   (SELECT item_id FROM items WHERE items.uuid = $2),
-                   $3,
-                   (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM lists WHERE lists.uuid = $1) AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $4) AND list_items.position = $5),
-                   (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM lists WHERE lists.uuid = $1) AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $4) AND list_items.position = $6),
-                   (SELECT status_id FROM statuses WHERE uuid = $4)
-               )
-        RETURNING list_item_id, list_id, position, prev_item_id, next_item_id, status_id
-),
-     update_prev_item AS (
-         UPDATE list_items
-             SET next_item_id = new_item.list_item_id
-             FROM new_item
-             WHERE list_items.list_item_id = new_item.prev_item_id
-                 AND new_item.prev_item_id IS NOT NULL
-     ),
-     update_next_item AS (
-         UPDATE list_items
-             SET prev_item_id = new_item.list_item_id
-             FROM new_item
-             WHERE list_items.list_item_id = new_item.next_item_id
-                 AND new_item.next_item_id IS NOT NULL
-     )
-SELECT list_items.uuid, list_items.position
-FROM list_items
-         JOIN items ON list_items.item_id = items.item_id
-WHERE list_items.list_item_id = (SELECT list_item_id FROM new_item)
+const addItemToList = `-- name: AddItemToList :one
+INSERT INTO list_items (list_id, item_id, status_id, rank)
+VALUES (
+           (SELECT list_id FROM lists WHERE lists.uuid = $1),
+           (SELECT item_id FROM items WHERE items.uuid = $2),
+           (SELECT status_id FROM statuses WHERE statuses.uuid = $3),
+           $4
+       )
+RETURNING uuid, rank
 `
 
-type AddItemToListAtPositionParams struct {
-	ListUuid     pgtype.UUID `json:"list_uuid"`
-	ItemUuid     pgtype.UUID `json:"item_uuid"`
-	NewPosition  int32       `json:"new_position"`
-	StatusUuid   pgtype.UUID `json:"status_uuid"`
-	PrevPosition int32       `json:"prev_position"`
-	NextPosition int32       `json:"next_position"`
+type AddItemToListParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	ItemUuid   pgtype.UUID `json:"item_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Rank       string      `json:"rank"`
 }
 
-type AddItemToListAtPositionRow struct {
-	Uuid     pgtype.UUID `json:"uuid"`
-	Position int32       `json:"position"`
+type AddItemToListRow struct {
+	Uuid pgtype.UUID `json:"uuid"`
+	Rank string      `json:"rank"`
 }
 
-// Arguments: list_uuid, item_uuid, new_position, status_uuid
-func (q *Queries) AddItemToListAtPosition(ctx context.Context, arg AddItemToListAtPositionParams) (AddItemToListAtPositionRow, error) {
-	row := q.db.QueryRow(ctx, addItemToListAtPosition,
+// Arguments: list_uuid, item_uuid, status_uuid, rank
+func (q *Queries) AddItemToList(ctx context.Context, arg AddItemToListParams) (AddItemToListRow, error) {
+	row := q.db.QueryRow(ctx, addItemToList,
 		arg.ListUuid,
 		arg.ItemUuid,
-		arg.NewPosition,
 		arg.StatusUuid,
-		arg.PrevPosition,
-		arg.NextPosition,
+		arg.Rank,
 	)
-	var i AddItemToListAtPositionRow
-	err := row.Scan(&i.Uuid, &i.Position)
+	var i AddItemToListRow
+	err := row.Scan(&i.Uuid, &i.Rank)
 	return i, err
 }
 
+const checkItemInList = `-- name: CheckItemInList :one
+SELECT COUNT(*)
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+WHERE
+    l.uuid = $1
+    AND i.uuid = $2
+`
+
+type CheckItemInListParams struct {
+	ListUuid pgtype.UUID `json:"list_uuid"`
+	ItemUuid pgtype.UUID `json:"item_uuid"`
+}
+
+func (q *Queries) CheckItemInList(ctx context.Context, arg CheckItemInListParams) (int64, error) {
+	row := q.db.QueryRow(ctx, checkItemInList, arg.ListUuid, arg.ItemUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteItemFromList = `-- name: DeleteItemFromList :exec
-WITH deleted_item AS (
-    DELETE FROM list_items
-        WHERE list_items.uuid = $1
-        RETURNING list_item_id, prev_item_id, next_item_id, status_id
-),
-     update_prev_item AS (
-         UPDATE list_items
-             SET next_item_id = deleted_item.next_item_id
-             FROM deleted_item
-             WHERE list_items.list_item_id = deleted_item.prev_item_id
-                 AND deleted_item.prev_item_id IS NOT NULL
-                 AND list_items.status_id = deleted_item.status_id
-     ),
-     update_next_item AS (
-         UPDATE list_items
-             SET prev_item_id = deleted_item.prev_item_id
-             FROM deleted_item
-             WHERE list_items.list_item_id = deleted_item.next_item_id
-                 AND deleted_item.next_item_id IS NOT NULL
-                 AND list_items.status_id = deleted_item.status_id
-     )
-SELECT 1
+DELETE FROM list_items
+WHERE
+    list_items.uuid = $1
 `
 
 // Arguments: list_item_uuid
@@ -105,13 +82,16 @@ func (q *Queries) DeleteItemFromList(ctx context.Context, listItemUuid pgtype.UU
 }
 
 const getAllListItems = `-- name: GetAllListItems :many
-SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.position
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.rank,
+       (ROW_NUMBER() OVER (PARTITION BY li.list_id, li.status_id ORDER BY li.rank) - 1)::int AS position
 FROM list_items li
 JOIN lists l ON l.list_id = li.list_id
 JOIN items i ON i.item_id = li.item_id
 JOIN statuses s ON s.status_id = li.status_id
 ORDER BY
-    li.position
+    li.list_id,
+    li.status_id,
+    li.rank
 LIMIT
     $2
     OFFSET
@@ -128,6 +108,7 @@ type GetAllListItemsRow struct {
 	ListUuid     pgtype.UUID `json:"list_uuid"`
 	ItemUuid     pgtype.UUID `json:"item_uuid"`
 	Label        pgtype.Text `json:"label"`
+	Rank         string      `json:"rank"`
 	Position     int32       `json:"position"`
 }
 
@@ -145,6 +126,7 @@ func (q *Queries) GetAllListItems(ctx context.Context, arg GetAllListItemsParams
 			&i.ListUuid,
 			&i.ItemUuid,
 			&i.Label,
+			&i.Rank,
 			&i.Position,
 		); err != nil {
 			return nil, err
@@ -169,16 +151,274 @@ func (q *Queries) GetAllListItemsCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
-const getListItemsByListUuid = `-- name: GetListItemsByListUuid :many
-SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.position
+const getBoardCardsForList = `-- name: GetBoardCardsForList :many
+SELECT
+    li.uuid AS list_item_uuid,
+    s.uuid AS status_uuid,
+    i.uuid AS item_uuid,
+    i.title,
+    i.tmdb_id,
+    i.release_date,
+    i.runtime,
+    i.poster_path,
+    i.created_date AS item_created_date,
+    li.rank,
+    (ROW_NUMBER() OVER (PARTITION BY li.status_id ORDER BY li.rank) - 1)::int AS position,
+    li.created_date
 FROM list_items li
 JOIN lists l ON l.list_id = li.list_id
 JOIN items i ON i.item_id = li.item_id
 JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+ORDER BY
+    li.status_id,
+    li.rank
+`
+
+type GetBoardCardsForListRow struct {
+	ListItemUuid    pgtype.UUID        `json:"list_item_uuid"`
+	StatusUuid      pgtype.UUID        `json:"status_uuid"`
+	ItemUuid        pgtype.UUID        `json:"item_uuid"`
+	Title           string             `json:"title"`
+	TmdbID          pgtype.Int8        `json:"tmdb_id"`
+	ReleaseDate     pgtype.Date        `json:"release_date"`
+	Runtime         pgtype.Int4        `json:"runtime"`
+	PosterPath      pgtype.Text        `json:"poster_path"`
+	ItemCreatedDate pgtype.Timestamptz `json:"item_created_date"`
+	Rank            string             `json:"rank"`
+	Position        int32              `json:"position"`
+	CreatedDate     pgtype.Timestamptz `json:"created_date"`
+}
+
+func (q *Queries) GetBoardCardsForList(ctx context.Context, listUuid pgtype.UUID) ([]GetBoardCardsForListRow, error) {
+	rows, err := q.db.Query(ctx, getBoardCardsForList, listUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBoardCardsForListRow
+	for rows.Next() {
+		var i GetBoardCardsForListRow
+		if err := rows.Scan(
+			&i.ListItemUuid,
+			&i.StatusUuid,
+			&i.ItemUuid,
+			&i.Title,
+			&i.TmdbID,
+			&i.ReleaseDate,
+			&i.Runtime,
+			&i.PosterPath,
+			&i.ItemCreatedDate,
+			&i.Rank,
+			&i.Position,
+			&i.CreatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getColumnsWithLongRanks = `-- name: GetColumnsWithLongRanks :many
+SELECT DISTINCT l.uuid AS list_uuid, s.uuid AS status_uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    LENGTH(li.rank) > $1::int
+`
+
+type GetColumnsWithLongRanksRow struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+// Returns every list and status pair containing a rank longer than max_length
+func (q *Queries) GetColumnsWithLongRanks(ctx context.Context, maxLength int32) ([]GetColumnsWithLongRanksRow, error) {
+	rows, err := q.db.Query(ctx, getColumnsWithLongRanks, maxLength)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetColumnsWithLongRanksRow
+	for rows.Next() {
+		var i GetColumnsWithLongRanksRow
+		if err := rows.Scan(&i.ListUuid, &i.StatusUuid); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastRankForStatus = `-- name: GetLastRankForStatus :one
+SELECT li.rank
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+ORDER BY
+    li.rank DESC
+LIMIT
+    1
+`
+
+type GetLastRankForStatusParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) GetLastRankForStatus(ctx context.Context, arg GetLastRankForStatusParams) (string, error) {
+	row := q.db.QueryRow(ctx, getLastRankForStatus, arg.ListUuid, arg.StatusUuid)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getListItemByUuid = `-- name: GetListItemByUuid :one
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.uuid AS status_uuid, s.label, li.rank,
+       (SELECT COUNT(*) FROM list_items o WHERE o.list_id = li.list_id AND o.status_id = li.status_id AND o.rank < li.rank)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+LEFT JOIN statuses s ON s.status_id = li.status_id
 WHERE
     li.uuid = $1
+LIMIT
+    1
+`
+
+type GetListItemByUuidRow struct {
+	ListItemUuid pgtype.UUID `json:"list_item_uuid"`
+	ListUuid     pgtype.UUID `json:"list_uuid"`
+	ItemUuid     pgtype.UUID `json:"item_uuid"`
+	StatusUuid   pgtype.UUID `json:"status_uuid"`
+	Label        pgtype.Text `json:"label"`
+	Rank         string      `json:"rank"`
+	Position     int32       `json:"position"`
+}
+
+func (q *Queries) GetListItemByUuid(ctx context.Context, listItemUuid pgtype.UUID) (GetListItemByUuidRow, error) {
+	row := q.db.QueryRow(ctx, getListItemByUuid, listItemUuid)
+	var i GetListItemByUuidRow
+	err := row.Scan(
+		&i.ListItemUuid,
+		&i.ListUuid,
+		&i.ItemUuid,
+		&i.StatusUuid,
+		&i.Label,
+		&i.Rank,
+		&i.Position,
+	)
+	return i, err
+}
+
+const getListItemForItem = `-- name: GetListItemForItem :one
+SELECT li.uuid AS list_item_uuid, s.uuid AS status_uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND i.uuid = $2
+LIMIT
+    1
+`
+
+type GetListItemForItemParams struct {
+	ListUuid pgtype.UUID `json:"list_uuid"`
+	ItemUuid pgtype.UUID `json:"item_uuid"`
+}
+
+type GetListItemForItemRow struct {
+	ListItemUuid pgtype.UUID `json:"list_item_uuid"`
+	StatusUuid   pgtype.UUID `json:"status_uuid"`
+}
+
+// Returns the card for an item on a list, if the item is on it
+func (q *Queries) GetListItemForItem(ctx context.Context, arg GetListItemForItemParams) (GetListItemForItemRow, error) {
+	row := q.db.QueryRow(ctx, getListItemForItem, arg.ListUuid, arg.ItemUuid)
+	var i GetListItemForItemRow
+	err := row.Scan(&i.ListItemUuid, &i.StatusUuid)
+	return i, err
+}
+
+const getListItemOwner = `-- name: GetListItemOwner :one
+SELECT u.uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN users u ON u.user_id = l.user_id
+WHERE li.uuid = $1
+`
+
+func (q *Queries) GetListItemOwner(ctx context.Context, listItemUuid pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getListItemOwner, listItemUuid)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const getListItemUuidsForStatus = `-- name: GetListItemUuidsForStatus :many
+SELECT li.uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+ORDER BY
+    li.rank,
+    li.list_item_id
+`
+
+type GetListItemUuidsForStatusParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) GetListItemUuidsForStatus(ctx context.Context, arg GetListItemUuidsForStatusParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getListItemUuidsForStatus, arg.ListUuid, arg.StatusUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var uuid pgtype.UUID
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		items = append(items, uuid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getListItemsByListUuid = `-- name: GetListItemsByListUuid :many
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.label, li.rank,
+       (ROW_NUMBER() OVER (PARTITION BY li.status_id ORDER BY li.rank) - 1)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
 ORDER BY
-    li.position
+    li.status_id,
+    li.rank
 LIMIT
     $3
     OFFSET
@@ -196,6 +436,7 @@ type GetListItemsByListUuidRow struct {
 	ListUuid     pgtype.UUID `json:"list_uuid"`
 	ItemUuid     pgtype.UUID `json:"item_uuid"`
 	Label        pgtype.Text `json:"label"`
+	Rank         string      `json:"rank"`
 	Position     int32       `json:"position"`
 }
 
@@ -213,6 +454,7 @@ func (q *Queries) GetListItemsByListUuid(ctx context.Context, arg GetListItemsBy
 			&i.ListUuid,
 			&i.ItemUuid,
 			&i.Label,
+			&i.Rank,
 			&i.Position,
 		); err != nil {
 			return nil, err
@@ -238,68 +480,324 @@ func (q *Queries) GetListItemsCountForList(ctx context.Context, listUuid pgtype.
 	return count, err
 }
 
-const moveItemInList = `-- name: MoveItemInList :one
-WITH current_item AS (
-    SELECT list_item_id, prev_item_id, next_item_id, position, list_id, status_id
-    FROM list_items
-    WHERE list_items.uuid = $1
-),
-     updated_positions AS (
-         UPDATE list_items
-             SET position = position + CASE
-                                           WHEN list_items.position >= $2 THEN 1
-                                           ELSE -1
-                 END
-             WHERE list_id = (SELECT list_id FROM current_item)
-                 AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $3)
-                 AND position BETWEEN LEAST($2, (SELECT position FROM current_item))
-                       AND GREATEST($2, (SELECT position FROM current_item))
-             RETURNING list_item_id, position
-     ),
-     update_current_item AS (
-         UPDATE list_items
-             SET
-                 position = $2,
-                 prev_item_id = (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM current_item) AND status_id = (SELECT status_id FROM statuses WHERE uuid = $3) AND list_items.position = $4),
-                 next_item_id = (SELECT list_item_id FROM list_items WHERE list_id = (SELECT list_id FROM current_item) AND status_id = (SELECT status_id FROM statuses WHERE uuid = $3) AND list_items.position = $5),
-                 status_id = (SELECT status_id FROM statuses WHERE uuid = $3)
-             WHERE list_item_id = (SELECT list_item_id FROM current_item)
-             RETURNING uuid, position, prev_item_id, next_item_id
-     )
-SELECT uuid, position, prev_item_id, next_item_id
-FROM update_current_item
+const getListItemsCountForStatus = `-- name: GetListItemsCountForStatus :one
+SELECT COUNT(*)
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+    AND li.uuid IS DISTINCT FROM $3
 `
 
-type MoveItemInListParams struct {
+type GetListItemsCountForStatusParams struct {
+	ListUuid    pgtype.UUID `json:"list_uuid"`
+	StatusUuid  pgtype.UUID `json:"status_uuid"`
+	ExcludeUuid pgtype.UUID `json:"exclude_uuid"`
+}
+
+func (q *Queries) GetListItemsCountForStatus(ctx context.Context, arg GetListItemsCountForStatusParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getListItemsCountForStatus, arg.ListUuid, arg.StatusUuid, arg.ExcludeUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getListItemsForIntegrityCheck = `-- name: GetListItemsForIntegrityCheck :many
+SELECT
+    li.uuid AS list_item_uuid,
+    s.uuid AS status_uuid,
+    li.rank,
+    EXISTS (
+        SELECT 1
+        FROM list_statuses ls
+        WHERE ls.list_id = li.list_id
+          AND ls.status_id = li.status_id
+    ) AS status_on_list
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+LEFT JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+ORDER BY
+    li.status_id,
+    li.rank,
+    li.list_item_id
+`
+
+type GetListItemsForIntegrityCheckRow struct {
 	ListItemUuid pgtype.UUID `json:"list_item_uuid"`
-	NewPosition  int32       `json:"new_position"`
 	StatusUuid   pgtype.UUID `json:"status_uuid"`
-	PrevPosition int32       `json:"prev_position"`
-	NextPosition int32       `json:"next_position"`
+	Rank         string      `json:"rank"`
+	StatusOnList bool        `json:"status_on_list"`
 }
 
-type MoveItemInListRow struct {
-	Uuid       pgtype.UUID `json:"uuid"`
-	Position   int32       `json:"position"`
-	PrevItemID pgtype.Int8 `json:"prev_item_id"`
-	NextItemID pgtype.Int8 `json:"next_item_id"`
+// Returns every item in a list with whether its status is attached to the list
+func (q *Queries) GetListItemsForIntegrityCheck(ctx context.Context, listUuid pgtype.UUID) ([]GetListItemsForIntegrityCheckRow, error) {
+	rows, err := q.db.Query(ctx, getListItemsForIntegrityCheck, listUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetListItemsForIntegrityCheckRow
+	for rows.Next() {
+		var i GetListItemsForIntegrityCheckRow
+		if err := rows.Scan(
+			&i.ListItemUuid,
+			&i.StatusUuid,
+			&i.Rank,
+			&i.StatusOnList,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-// Arguments: list_item_uuid, new_position, status_uuid
-func (q *Queries) MoveItemInList(ctx context.Context, arg MoveItemInListParams) (MoveItemInListRow, error) {
-	row := q.db.QueryRow(ctx, moveItemInList,
-		arg.ListItemUuid,
-		arg.NewPosition,
-		arg.StatusUuid,
-		arg.PrevPosition,
-		arg.NextPosition,
+const getListUuidForListItem = `-- name: GetListUuidForListItem :one
+SELECT l.uuid
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+WHERE li.uuid = $1
+`
+
+func (q *Queries) GetListUuidForListItem(ctx context.Context, listItemUuid pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getListUuidForListItem, listItemUuid)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const getNextListItem = `-- name: GetNextListItem :one
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.uuid AS status_uuid, s.label, li.rank,
+       (SELECT COUNT(*) FROM list_items o WHERE o.list_id = li.list_id AND o.status_id = li.status_id AND o.rank < li.rank)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+    AND li.rank > $3
+ORDER BY
+    li.rank
+LIMIT
+    1
+`
+
+type GetNextListItemParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Rank       string      `json:"rank"`
+}
+
+type GetNextListItemRow struct {
+	ListItemUuid pgtype.UUID `json:"list_item_uuid"`
+	ListUuid     pgtype.UUID `json:"list_uuid"`
+	ItemUuid     pgtype.UUID `json:"item_uuid"`
+	StatusUuid   pgtype.UUID `json:"status_uuid"`
+	Label        pgtype.Text `json:"label"`
+	Rank         string      `json:"rank"`
+	Position     int32       `json:"position"`
+}
+
+// Returns the item directly after a rank in a column
+func (q *Queries) GetNextListItem(ctx context.Context, arg GetNextListItemParams) (GetNextListItemRow, error) {
+	row := q.db.QueryRow(ctx, getNextListItem, arg.ListUuid, arg.StatusUuid, arg.Rank)
+	var i GetNextListItemRow
+	err := row.Scan(
+		&i.ListItemUuid,
+		&i.ListUuid,
+		&i.ItemUuid,
+		&i.StatusUuid,
+		&i.Label,
+		&i.Rank,
+		&i.Position,
 	)
-	var i MoveItemInListRow
+	return i, err
+}
+
+const getPreviousListItem = `-- name: GetPreviousListItem :one
+SELECT li.uuid AS list_item_uuid, l.uuid AS list_uuid, i.uuid AS item_uuid, s.uuid AS status_uuid, s.label, li.rank,
+       (SELECT COUNT(*) FROM list_items o WHERE o.list_id = li.list_id AND o.status_id = li.status_id AND o.rank < li.rank)::int AS position
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN items i ON i.item_id = li.item_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+    AND li.rank < $3
+ORDER BY
+    li.rank DESC
+LIMIT
+    1
+`
+
+type GetPreviousListItemParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Rank       string      `json:"rank"`
+}
+
+type GetPreviousListItemRow struct {
+	ListItemUuid pgtype.UUID `json:"list_item_uuid"`
+	ListUuid     pgtype.UUID `json:"list_uuid"`
+	ItemUuid     pgtype.UUID `json:"item_uuid"`
+	StatusUuid   pgtype.UUID `json:"status_uuid"`
+	Label        pgtype.Text `json:"label"`
+	Rank         string      `json:"rank"`
+	Position     int32       `json:"position"`
+}
+
+// Returns the item directly before a rank in a column
+func (q *Queries) GetPreviousListItem(ctx context.Context, arg GetPreviousListItemParams) (GetPreviousListItemRow, error) {
+	row := q.db.QueryRow(ctx, getPreviousListItem, arg.ListUuid, arg.StatusUuid, arg.Rank)
+	var i GetPreviousListItemRow
 	err := row.Scan(
-		&i.Uuid,
+		&i.ListItemUuid,
+		&i.ListUuid,
+		&i.ItemUuid,
+		&i.StatusUuid,
+		&i.Label,
+		&i.Rank,
 		&i.Position,
-		&i.PrevItemID,
-		&i.NextItemID,
 	)
 	return i, err
 }
+
+const getRanksAroundPosition = `-- name: GetRanksAroundPosition :many
+SELECT li.rank
+FROM list_items li
+JOIN lists l ON l.list_id = li.list_id
+JOIN statuses s ON s.status_id = li.status_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+    AND li.uuid IS DISTINCT FROM $3
+ORDER BY
+    li.rank
+LIMIT
+    2
+    OFFSET
+    GREATEST($4::int - 1, 0)
+`
+
+type GetRanksAroundPositionParams struct {
+	ListUuid    pgtype.UUID `json:"list_uuid"`
+	StatusUuid  pgtype.UUID `json:"status_uuid"`
+	ExcludeUuid pgtype.UUID `json:"exclude_uuid"`
+	Position    int32       `json:"position"`
+}
+
+// Returns the ranks of the items either side of a zero-based position in a column, skipping exclude_uuid
+func (q *Queries) GetRanksAroundPosition(ctx context.Context, arg GetRanksAroundPositionParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getRanksAroundPosition,
+		arg.ListUuid,
+		arg.StatusUuid,
+		arg.ExcludeUuid,
+		arg.Position,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var rank string
+		if err := rows.Scan(&rank); err != nil {
+			return nil, err
+		}
+		items = append(items, rank)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveItemInList = `-- name: MoveItemInList :one
+UPDATE list_items
+SET
+    status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $1),
+    rank = $2
+WHERE
+    list_items.uuid = $3
+RETURNING
+    uuid,
+    rank
+`
+
+type MoveItemInListParams struct {
+	StatusUuid   pgtype.UUID `json:"status_uuid"`
+	Rank         string      `json:"rank"`
+	ListItemUuid pgtype.UUID `json:"list_item_uuid"`
+}
+
+type MoveItemInListRow struct {
+	Uuid pgtype.UUID `json:"uuid"`
+	Rank string      `json:"rank"`
+}
+
+// Arguments: list_item_uuid, status_uuid, rank
+func (q *Queries) MoveItemInList(ctx context.Context, arg MoveItemInListParams) (MoveItemInListRow, error) {
+	row := q.db.QueryRow(ctx, moveItemInList, arg.StatusUuid, arg.Rank, arg.ListItemUuid)
+	var i MoveItemInListRow
+	err := row.Scan(&i.Uuid, &i.Rank)
+	return i, err
+}
+
+const reassignListItems = `-- name: ReassignListItems :exec
+UPDATE list_items
+SET
+    status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $1),
+    rank = updated.rank
+FROM (
+    SELECT
+        UNNEST($2::uuid[]) AS uuid,
+        UNNEST($3::text[]) AS rank
+) updated
+WHERE
+    list_items.uuid = updated.uuid
+`
+
+type ReassignListItemsParams struct {
+	StatusUuid    pgtype.UUID   `json:"status_uuid"`
+	ListItemUuids []pgtype.UUID `json:"list_item_uuids"`
+	Ranks         []string      `json:"ranks"`
+}
+
+// Moves each list item in list_item_uuids to a status with the matching entry in ranks
+func (q *Queries) ReassignListItems(ctx context.Context, arg ReassignListItemsParams) error {
+	_, err := q.db.Exec(ctx, reassignListItems, arg.StatusUuid, arg.ListItemUuids, arg.Ranks)
+	return err
+}
+
+const updateListItemRanks = `-- name: UpdateListItemRanks :exec
+UPDATE list_items
+SET
+    rank = updated.rank
+FROM (
+    SELECT
+        UNNEST($1::uuid[]) AS uuid,
+        UNNEST($2::text[]) AS rank
+) updated
+WHERE
+    list_items.uuid = updated.uuid
+`
+
+type UpdateListItemRanksParams struct {
+	ListItemUuids []pgtype.UUID `json:"list_item_uuids"`
+	Ranks         []string      `json:"ranks"`
+}
+
+// Sets the rank of each list item in list_item_uuids to the matching entry in ranks
+func (q *Queries) UpdateListItemRanks(ctx context.Context, arg UpdateListItemRanksParams) error {
+	_, err := q.db.Exec(ctx, updateListItemRanks, arg.ListItemUuids, arg.Ranks)
+	return err
+}
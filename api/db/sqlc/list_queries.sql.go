@@ -61,25 +61,95 @@ func (q *Queries) DeleteList(ctx context.Context, listUuid pgtype.UUID) error {
 	return err
 }
 
-const getListByUuid = `-- name: GetListByUuid :one
+const getAllListUuids = `-- name: GetAllListUuids :many
 SELECT
-    uuid,
-    name
+    uuid
 FROM
     lists
+ORDER BY
+    list_id
+`
+
+func (q *Queries) GetAllListUuids(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getAllListUuids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var uuid pgtype.UUID
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		items = append(items, uuid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDefaultListForUser = `-- name: GetDefaultListForUser :one
+SELECT
+    l.uuid,
+    l.name
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
 WHERE
-    uuid = $1
+    u.uuid = $1
+ORDER BY
+    l.created_date,
+    l.list_id
+LIMIT
+    1
 `
 
-type GetListByUuidRow struct {
+type GetDefaultListForUserRow struct {
 	Uuid pgtype.UUID `json:"uuid"`
 	Name string      `json:"name"`
 }
 
+// The default list is the user's oldest list, which is the watchlist created when they registered
+func (q *Queries) GetDefaultListForUser(ctx context.Context, userUuid pgtype.UUID) (GetDefaultListForUserRow, error) {
+	row := q.db.QueryRow(ctx, getDefaultListForUser, userUuid)
+	var i GetDefaultListForUserRow
+	err := row.Scan(&i.Uuid, &i.Name)
+	return i, err
+}
+
+const getListByUuid = `-- name: GetListByUuid :one
+SELECT
+    l.uuid,
+    l.name,
+    u.uuid AS user_uuid,
+    l.created_date
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    l.uuid = $1
+LIMIT
+    1
+`
+
+type GetListByUuidRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	Name        string             `json:"name"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
 func (q *Queries) GetListByUuid(ctx context.Context, listUuid pgtype.UUID) (GetListByUuidRow, error) {
 	row := q.db.QueryRow(ctx, getListByUuid, listUuid)
 	var i GetListByUuidRow
-	err := row.Scan(&i.Uuid, &i.Name)
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.UserUuid,
+		&i.CreatedDate,
+	)
 	return i, err
 }
 
@@ -131,6 +201,46 @@ func (q *Queries) GetListsByUser(ctx context.Context, arg GetListsByUserParams)
 	return items, nil
 }
 
+const getListsCountForUser = `-- name: GetListsCountForUser :one
+SELECT COUNT(*)
+FROM lists l
+JOIN users u
+ON u.user_id = l.user_id
+WHERE
+    u.uuid = $1
+`
+
+func (q *Queries) GetListsCountForUser(ctx context.Context, userUuid pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getListsCountForUser, userUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const lockListByUuid = `-- name: LockListByUuid :one
+SELECT
+    l.uuid,
+    u.uuid AS user_uuid
+FROM
+    lists l
+        JOIN users u ON u.user_id = l.user_id
+WHERE
+    l.uuid = $1
+FOR UPDATE OF l
+`
+
+type LockListByUuidRow struct {
+	Uuid     pgtype.UUID `json:"uuid"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
+}
+
+func (q *Queries) LockListByUuid(ctx context.Context, listUuid pgtype.UUID) (LockListByUuidRow, error) {
+	row := q.db.QueryRow(ctx, lockListByUuid, listUuid)
+	var i LockListByUuidRow
+	err := row.Scan(&i.Uuid, &i.UserUuid)
+	return i, err
+}
+
 const updateList = `-- name: UpdateList :one
 UPDATE lists
 SET
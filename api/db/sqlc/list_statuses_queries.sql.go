@@ -13,7 +13,7 @@ import (
 
 const addListStatus = `-- name: AddListStatus :one
 INSERT INTO
-    list_statuses (list_id, status_id)
+    list_statuses (list_id, status_id, rank)
 VALUES
     (
         (
@@ -31,7 +31,8 @@ VALUES
                 statuses s
             WHERE
                 s.uuid = $2
-        )
+        ),
+        $3
     )
 RETURNING
     uuid,
@@ -41,6 +42,7 @@ RETURNING
 type AddListStatusParams struct {
 	ListUuid   pgtype.UUID `json:"list_uuid"`
 	StatusUuid pgtype.UUID `json:"status_uuid"`
+	Rank       string      `json:"rank"`
 }
 
 type AddListStatusRow struct {
@@ -49,12 +51,35 @@ type AddListStatusRow struct {
 }
 
 func (q *Queries) AddListStatus(ctx context.Context, arg AddListStatusParams) (AddListStatusRow, error) {
-	row := q.db.QueryRow(ctx, addListStatus, arg.ListUuid, arg.StatusUuid)
+	row := q.db.QueryRow(ctx, addListStatus, arg.ListUuid, arg.StatusUuid, arg.Rank)
 	var i AddListStatusRow
 	err := row.Scan(&i.Uuid, &i.CreatedDate)
 	return i, err
 }
 
+const checkStatusOnList = `-- name: CheckStatusOnList :one
+SELECT COUNT(*)
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
+`
+
+type CheckStatusOnListParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) CheckStatusOnList(ctx context.Context, arg CheckStatusOnListParams) (int64, error) {
+	row := q.db.QueryRow(ctx, checkStatusOnList, arg.ListUuid, arg.StatusUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteListStatus = `-- name: DeleteListStatus :exec
 DELETE FROM list_statuses
 WHERE
@@ -66,6 +91,93 @@ func (q *Queries) DeleteListStatus(ctx context.Context, listStatusUuid pgtype.UU
 	return err
 }
 
+const deleteListStatusForList = `-- name: DeleteListStatusForList :exec
+DELETE FROM list_statuses
+WHERE
+    list_id = (SELECT list_id FROM lists WHERE lists.uuid = $1)
+    AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $2)
+`
+
+type DeleteListStatusForListParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) DeleteListStatusForList(ctx context.Context, arg DeleteListStatusForListParams) error {
+	_, err := q.db.Exec(ctx, deleteListStatusForList, arg.ListUuid, arg.StatusUuid)
+	return err
+}
+
+const getBoardColumnsForList = `-- name: GetBoardColumnsForList :many
+SELECT
+    ls.uuid AS list_status_uuid,
+    s.uuid AS status_uuid,
+    s.label,
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = $1
+ORDER BY
+    ls.rank,
+    ls.list_status_id
+`
+
+type GetBoardColumnsForListRow struct {
+	ListStatusUuid pgtype.UUID `json:"list_status_uuid"`
+	StatusUuid     pgtype.UUID `json:"status_uuid"`
+	Label          pgtype.Text `json:"label"`
+	WipLimit       pgtype.Int4 `json:"wip_limit"`
+}
+
+func (q *Queries) GetBoardColumnsForList(ctx context.Context, listUuid pgtype.UUID) ([]GetBoardColumnsForListRow, error) {
+	rows, err := q.db.Query(ctx, getBoardColumnsForList, listUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBoardColumnsForListRow
+	for rows.Next() {
+		var i GetBoardColumnsForListRow
+		if err := rows.Scan(
+			&i.ListStatusUuid,
+			&i.StatusUuid,
+			&i.Label,
+			&i.WipLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastListStatusRank = `-- name: GetLastListStatusRank :one
+SELECT
+    ls.rank
+FROM
+    list_statuses ls
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = $1
+ORDER BY
+    ls.rank DESC
+LIMIT
+    1
+`
+
+func (q *Queries) GetLastListStatusRank(ctx context.Context, listUuid pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getLastListStatusRank, listUuid)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
 const getListStatus = `-- name: GetListStatus :one
 SELECT
     uuid,
@@ -90,42 +202,86 @@ func (q *Queries) GetListStatus(ctx context.Context, listStatusUuid pgtype.UUID)
 	return i, err
 }
 
-const getStatusesForList = `-- name: GetStatusesForList :many
+const getListStatusRank = `-- name: GetListStatusRank :one
 SELECT
-    ls.uuid,
-    s.uuid,
-    s.label,
-    ls.created_date
+    ls.rank
 FROM
     list_statuses ls
         JOIN statuses s ON s.status_id = ls.status_id
         JOIN lists l ON l.list_id = ls.list_id
 WHERE
     l.uuid = $1
-ORDER BY
-    l.uuid,
-    ls.created_date
+    AND s.uuid = $2
+LIMIT
+    1
+`
+
+type GetListStatusRankParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) GetListStatusRank(ctx context.Context, arg GetListStatusRankParams) (string, error) {
+	row := q.db.QueryRow(ctx, getListStatusRank, arg.ListUuid, arg.StatusUuid)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getListStatusWipLimit = `-- name: GetListStatusWipLimit :one
+SELECT
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = $1
+    AND s.uuid = $2
 LIMIT
-    $3
-    OFFSET
-    $2
+    1
 `
 
-type GetStatusesForListParams struct {
-	ListUuid pgtype.UUID `json:"list_uuid"`
-	Page     int32       `json:"page"`
-	PageSize int32       `json:"page_size"`
+type GetListStatusWipLimitParams struct {
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) GetListStatusWipLimit(ctx context.Context, arg GetListStatusWipLimitParams) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, getListStatusWipLimit, arg.ListUuid, arg.StatusUuid)
+	var wip_limit pgtype.Int4
+	err := row.Scan(&wip_limit)
+	return wip_limit, err
 }
 
+const getStatusesForList = `-- name: GetStatusesForList :many
+SELECT
+    ls.uuid AS list_status_uuid,
+    s.uuid AS status_uuid,
+    s.label,
+    ls.rank,
+    ls.wip_limit
+FROM
+    list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        JOIN lists l ON l.list_id = ls.list_id
+WHERE
+    l.uuid = $1
+ORDER BY
+    ls.rank,
+    ls.list_status_id
+`
+
 type GetStatusesForListRow struct {
-	Uuid        pgtype.UUID        `json:"uuid"`
-	Uuid_2      pgtype.UUID        `json:"uuid_2"`
-	Label       pgtype.Text        `json:"label"`
-	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	ListStatusUuid pgtype.UUID `json:"list_status_uuid"`
+	StatusUuid     pgtype.UUID `json:"status_uuid"`
+	Label          pgtype.Text `json:"label"`
+	Rank           string      `json:"rank"`
+	WipLimit       pgtype.Int4 `json:"wip_limit"`
 }
 
-func (q *Queries) GetStatusesForList(ctx context.Context, arg GetStatusesForListParams) ([]GetStatusesForListRow, error) {
-	rows, err := q.db.Query(ctx, getStatusesForList, arg.ListUuid, arg.Page, arg.PageSize)
+func (q *Queries) GetStatusesForList(ctx context.Context, listUuid pgtype.UUID) ([]GetStatusesForListRow, error) {
+	rows, err := q.db.Query(ctx, getStatusesForList, listUuid)
 	if err != nil {
 		return nil, err
 	}
@@ -134,10 +290,11 @@ func (q *Queries) GetStatusesForList(ctx context.Context, arg GetStatusesForList
 	for rows.Next() {
 		var i GetStatusesForListRow
 		if err := rows.Scan(
-			&i.Uuid,
-			&i.Uuid_2,
+			&i.ListStatusUuid,
+			&i.StatusUuid,
 			&i.Label,
-			&i.CreatedDate,
+			&i.Rank,
+			&i.WipLimit,
 		); err != nil {
 			return nil, err
 		}
@@ -148,3 +305,50 @@ func (q *Queries) GetStatusesForList(ctx context.Context, arg GetStatusesForList
 	}
 	return items, nil
 }
+
+const updateListStatusRanks = `-- name: UpdateListStatusRanks :exec
+UPDATE list_statuses
+SET
+    rank = updated.rank
+FROM (
+    SELECT
+        UNNEST($2::uuid[]) AS uuid,
+        UNNEST($3::text[]) AS rank
+) updated
+    JOIN statuses s ON s.uuid = updated.uuid
+WHERE
+    list_statuses.status_id = s.status_id
+    AND list_statuses.list_id = (SELECT list_id FROM lists WHERE lists.uuid = $1)
+`
+
+type UpdateListStatusRanksParams struct {
+	ListUuid    pgtype.UUID   `json:"list_uuid"`
+	StatusUuids []pgtype.UUID `json:"status_uuids"`
+	Ranks       []string      `json:"ranks"`
+}
+
+// Sets the rank of each status in status_uuids on a list to the matching entry in ranks
+func (q *Queries) UpdateListStatusRanks(ctx context.Context, arg UpdateListStatusRanksParams) error {
+	_, err := q.db.Exec(ctx, updateListStatusRanks, arg.ListUuid, arg.StatusUuids, arg.Ranks)
+	return err
+}
+
+const updateListStatusWipLimit = `-- name: UpdateListStatusWipLimit :exec
+UPDATE list_statuses
+SET
+    wip_limit = $1
+WHERE
+    list_id = (SELECT list_id FROM lists WHERE lists.uuid = $2)
+    AND status_id = (SELECT status_id FROM statuses WHERE statuses.uuid = $3)
+`
+
+type UpdateListStatusWipLimitParams struct {
+	WipLimit   pgtype.Int4 `json:"wip_limit"`
+	ListUuid   pgtype.UUID `json:"list_uuid"`
+	StatusUuid pgtype.UUID `json:"status_uuid"`
+}
+
+func (q *Queries) UpdateListStatusWipLimit(ctx context.Context, arg UpdateListStatusWipLimitParams) error {
+	_, err := q.db.Exec(ctx, updateListStatusWipLimit, arg.WipLimit, arg.ListUuid, arg.StatusUuid)
+	return err
+}
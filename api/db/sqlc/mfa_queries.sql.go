@@ -0,0 +1,276 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: mfa_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addMfaChallenge = `-- name: AddMfaChallenge :exec
+INSERT INTO mfa_challenges (user_id, token_hash, device_name, expires_at)
+VALUES ($1, $2, $3, $4)
+`
+
+type AddMfaChallengeParams struct {
+	UserID     int64              `json:"user_id"`
+	TokenHash  string             `json:"token_hash"`
+	DeviceName pgtype.Text        `json:"device_name"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) AddMfaChallenge(ctx context.Context, arg AddMfaChallengeParams) error {
+	_, err := q.db.Exec(ctx, addMfaChallenge,
+		arg.UserID,
+		arg.TokenHash,
+		arg.DeviceName,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const addMfaChallengeAttempt = `-- name: AddMfaChallengeAttempt :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE mfa_challenge_id = $1
+  AND attempts < $2
+RETURNING attempts
+`
+
+type AddMfaChallengeAttemptParams struct {
+	MfaChallengeID pgtype.Int8 `json:"mfa_challenge_id"`
+	MaxAttempts    int32       `json:"max_attempts"`
+}
+
+// Counts an attempt before the code is checked. Returns no rows once the challenge has run out of attempts,
+// so concurrent requests can't make more than max_attempts guesses between them
+func (q *Queries) AddMfaChallengeAttempt(ctx context.Context, arg AddMfaChallengeAttemptParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addMfaChallengeAttempt, arg.MfaChallengeID, arg.MaxAttempts)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const addRecoveryCode = `-- name: AddRecoveryCode :exec
+INSERT INTO recovery_codes (user_id, code_hash)
+VALUES ($1, $2)
+`
+
+type AddRecoveryCodeParams struct {
+	UserID   int64  `json:"user_id"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) AddRecoveryCode(ctx context.Context, arg AddRecoveryCodeParams) error {
+	_, err := q.db.Exec(ctx, addRecoveryCode, arg.UserID, arg.CodeHash)
+	return err
+}
+
+const deleteExpiredMfaChallenges = `-- name: DeleteExpiredMfaChallenges :execrows
+DELETE FROM mfa_challenges
+WHERE expires_at < CURRENT_TIMESTAMP
+`
+
+func (q *Queries) DeleteExpiredMfaChallenges(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredMfaChallenges)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteMfaChallenge = `-- name: DeleteMfaChallenge :execrows
+DELETE FROM mfa_challenges
+WHERE mfa_challenge_id = $1
+`
+
+func (q *Queries) DeleteMfaChallenge(ctx context.Context, mfaChallengeID pgtype.Int8) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteMfaChallenge, mfaChallengeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, userID)
+	return err
+}
+
+const disableTotp = `-- name: DisableTotp :exec
+UPDATE users
+SET
+    totp_secret = NULL,
+    totp_enabled_at = NULL,
+    totp_last_used_step = NULL
+WHERE user_id = $1
+`
+
+func (q *Queries) DisableTotp(ctx context.Context, userID pgtype.Int8) error {
+	_, err := q.db.Exec(ctx, disableTotp, userID)
+	return err
+}
+
+const enableTotp = `-- name: EnableTotp :execrows
+UPDATE users
+SET
+    totp_enabled_at = CURRENT_TIMESTAMP,
+    totp_last_used_step = $1
+WHERE user_id = $2
+  AND totp_secret IS NOT NULL
+  AND totp_enabled_at IS NULL
+`
+
+type EnableTotpParams struct {
+	Step   pgtype.Int8 `json:"step"`
+	UserID pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) EnableTotp(ctx context.Context, arg EnableTotpParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enableTotp, arg.Step, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getMfaChallengeByHash = `-- name: GetMfaChallengeByHash :one
+SELECT
+    mc.mfa_challenge_id,
+    mc.user_id,
+    u.uuid AS user_uuid,
+    mc.device_name,
+    mc.attempts,
+    mc.expires_at
+FROM mfa_challenges mc
+JOIN users u ON mc.user_id = u.user_id
+WHERE mc.token_hash = $1
+`
+
+type GetMfaChallengeByHashRow struct {
+	MfaChallengeID pgtype.Int8        `json:"mfa_challenge_id"`
+	UserID         int64              `json:"user_id"`
+	UserUuid       pgtype.UUID        `json:"user_uuid"`
+	DeviceName     pgtype.Text        `json:"device_name"`
+	Attempts       int32              `json:"attempts"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) GetMfaChallengeByHash(ctx context.Context, tokenHash string) (GetMfaChallengeByHashRow, error) {
+	row := q.db.QueryRow(ctx, getMfaChallengeByHash, tokenHash)
+	var i GetMfaChallengeByHashRow
+	err := row.Scan(
+		&i.MfaChallengeID,
+		&i.UserID,
+		&i.UserUuid,
+		&i.DeviceName,
+		&i.Attempts,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getUserTotp = `-- name: GetUserTotp :one
+SELECT
+    user_id,
+    username,
+    hashed_password,
+    totp_secret,
+    totp_enabled_at,
+    totp_last_used_step
+FROM users
+WHERE uuid = $1
+`
+
+type GetUserTotpRow struct {
+	UserID           pgtype.Int8        `json:"user_id"`
+	Username         string             `json:"username"`
+	HashedPassword   string             `json:"hashed_password"`
+	TotpSecret       pgtype.Text        `json:"totp_secret"`
+	TotpEnabledAt    pgtype.Timestamptz `json:"totp_enabled_at"`
+	TotpLastUsedStep pgtype.Int8        `json:"totp_last_used_step"`
+}
+
+func (q *Queries) GetUserTotp(ctx context.Context, userUuid pgtype.UUID) (GetUserTotpRow, error) {
+	row := q.db.QueryRow(ctx, getUserTotp, userUuid)
+	var i GetUserTotpRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.HashedPassword,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastUsedStep,
+	)
+	return i, err
+}
+
+const setPendingTotpSecret = `-- name: SetPendingTotpSecret :execrows
+UPDATE users
+SET totp_secret = $1
+WHERE uuid = $2
+  AND totp_enabled_at IS NULL
+`
+
+type SetPendingTotpSecretParams struct {
+	TotpSecret pgtype.Text `json:"totp_secret"`
+	UserUuid   pgtype.UUID `json:"user_uuid"`
+}
+
+func (q *Queries) SetPendingTotpSecret(ctx context.Context, arg SetPendingTotpSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setPendingTotpSecret, arg.TotpSecret, arg.UserUuid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   int64  `json:"user_id"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useTotpStep = `-- name: UseTotpStep :execrows
+UPDATE users
+SET totp_last_used_step = $1
+WHERE user_id = $2
+  AND (totp_last_used_step IS NULL OR totp_last_used_step < $1)
+`
+
+type UseTotpStepParams struct {
+	Step   pgtype.Int8 `json:"step"`
+	UserID pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) UseTotpStep(ctx context.Context, arg UseTotpStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useTotpStep, arg.Step, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
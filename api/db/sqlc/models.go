@@ -8,11 +8,58 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type EmailVerificationToken struct {
+	TokenID   pgtype.Int8        `json:"token_id"`
+	UserID    int64              `json:"user_id"`
+	Email     string             `json:"email"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ImportJob struct {
+	ImportJobID  pgtype.Int8        `json:"import_job_id"`
+	Uuid         pgtype.UUID        `json:"uuid"`
+	UserID       int64              `json:"user_id"`
+	Source       string             `json:"source"`
+	State        string             `json:"state"`
+	ListID       pgtype.Int8        `json:"list_id"`
+	StatusID     pgtype.Int8        `json:"status_id"`
+	Error        pgtype.Text        `json:"error"`
+	CreatedDate  pgtype.Timestamptz `json:"created_date"`
+	StartedDate  pgtype.Timestamptz `json:"started_date"`
+	FinishedDate pgtype.Timestamptz `json:"finished_date"`
+}
+
+type ImportJobRow struct {
+	ImportJobRowID pgtype.Int8    `json:"import_job_row_id"`
+	ImportJobID    int64          `json:"import_job_id"`
+	File           string         `json:"file"`
+	Line           int32          `json:"line"`
+	Kind           string         `json:"kind"`
+	ExternalID     string         `json:"external_id"`
+	Title          string         `json:"title"`
+	Year           string         `json:"year"`
+	Rating         pgtype.Numeric `json:"rating"`
+	Outcome        pgtype.Text    `json:"outcome"`
+	Reason         pgtype.Text    `json:"reason"`
+}
+
 type Item struct {
-	ItemID      pgtype.Int8        `json:"item_id"`
-	Uuid        pgtype.UUID        `json:"uuid"`
-	Title       string             `json:"title"`
-	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	ItemID              pgtype.Int8        `json:"item_id"`
+	Uuid                pgtype.UUID        `json:"uuid"`
+	Title               string             `json:"title"`
+	CreatedDate         pgtype.Timestamptz `json:"created_date"`
+	TmdbID              pgtype.Int8        `json:"tmdb_id"`
+	ImdbID              pgtype.Text        `json:"imdb_id"`
+	ReleaseDate         pgtype.Date        `json:"release_date"`
+	Runtime             pgtype.Int4        `json:"runtime"`
+	Overview            pgtype.Text        `json:"overview"`
+	OriginalLanguage    pgtype.Text        `json:"original_language"`
+	PosterPath          pgtype.Text        `json:"poster_path"`
+	BackdropPath        pgtype.Text        `json:"backdrop_path"`
+	Genres              []string           `json:"genres"`
+	MetadataRefreshedAt pgtype.Timestamptz `json:"metadata_refreshed_at"`
 }
 
 type List struct {
@@ -28,11 +75,9 @@ type ListItem struct {
 	Uuid        pgtype.UUID        `json:"uuid"`
 	ListID      int64              `json:"list_id"`
 	ItemID      int64              `json:"item_id"`
-	Position    int32              `json:"position"`
-	PrevItemID  pgtype.Int8        `json:"prev_item_id"`
-	NextItemID  pgtype.Int8        `json:"next_item_id"`
 	StatusID    pgtype.Int8        `json:"status_id"`
 	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	Rank        string             `json:"rank"`
 }
 
 type ListStatus struct {
@@ -41,16 +86,58 @@ type ListStatus struct {
 	ListID       int64              `json:"list_id"`
 	StatusID     int64              `json:"status_id"`
 	CreatedDate  pgtype.Timestamptz `json:"created_date"`
+	Rank         string             `json:"rank"`
+	WipLimit     pgtype.Int4        `json:"wip_limit"`
 }
 
-type RefreshToken struct {
+type MfaChallenge struct {
+	MfaChallengeID pgtype.Int8        `json:"mfa_challenge_id"`
+	UserID         int64              `json:"user_id"`
+	TokenHash      string             `json:"token_hash"`
+	DeviceName     pgtype.Text        `json:"device_name"`
+	Attempts       int32              `json:"attempts"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type PasswordResetToken struct {
 	TokenID   pgtype.Int8        `json:"token_id"`
 	UserID    int64              `json:"user_id"`
-	Token     string             `json:"token"`
+	TokenHash string             `json:"token_hash"`
 	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type Permission struct {
+	PermissionID pgtype.Int8 `json:"permission_id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+}
+
+type RecoveryCode struct {
+	RecoveryCodeID pgtype.Int8        `json:"recovery_code_id"`
+	UserID         int64              `json:"user_id"`
+	CodeHash       string             `json:"code_hash"`
+	UsedAt         pgtype.Timestamptz `json:"used_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type RefreshToken struct {
+	TokenID    pgtype.Int8        `json:"token_id"`
+	UserID     int64              `json:"user_id"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	TokenHash  string             `json:"token_hash"`
+	FamilyID   pgtype.UUID        `json:"family_id"`
+	UsedAt     pgtype.Timestamptz `json:"used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	DeviceName pgtype.Text        `json:"device_name"`
+	UserAgent  pgtype.Text        `json:"user_agent"`
+	IpAddress  pgtype.Text        `json:"ip_address"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+}
+
 type Review struct {
 	ReviewID    pgtype.Int8        `json:"review_id"`
 	Uuid        pgtype.UUID        `json:"uuid"`
@@ -58,6 +145,20 @@ type Review struct {
 	UserID      int64              `json:"user_id"`
 	ItemID      int64              `json:"item_id"`
 	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+type Role struct {
+	RoleID      pgtype.Int8        `json:"role_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+type RolePermission struct {
+	RoleID       int64 `json:"role_id"`
+	PermissionID int64 `json:"permission_id"`
 }
 
 type Status struct {
@@ -68,14 +169,44 @@ type Status struct {
 	CreatedDate pgtype.Timestamptz `json:"created_date"`
 }
 
+type TmdbCache struct {
+	CacheKey    string             `json:"cache_key"`
+	Response    []byte             `json:"response"`
+	FetchedDate pgtype.Timestamptz `json:"fetched_date"`
+}
+
 type User struct {
-	UserID         pgtype.Int8        `json:"user_id"`
-	Uuid           pgtype.UUID        `json:"uuid"`
-	Username       string             `json:"username"`
-	HashedPassword string             `json:"hashed_password"`
-	Email          string             `json:"email"`
-	FullName       pgtype.Text        `json:"full_name"`
-	Bio            pgtype.Text        `json:"bio"`
-	Superuser      bool               `json:"superuser"`
-	CreatedDate    pgtype.Timestamptz `json:"created_date"`
+	UserID           pgtype.Int8        `json:"user_id"`
+	Uuid             pgtype.UUID        `json:"uuid"`
+	Username         string             `json:"username"`
+	HashedPassword   string             `json:"hashed_password"`
+	Email            string             `json:"email"`
+	FullName         pgtype.Text        `json:"full_name"`
+	Bio              pgtype.Text        `json:"bio"`
+	CreatedDate      pgtype.Timestamptz `json:"created_date"`
+	EmailVerifiedAt  pgtype.Timestamptz `json:"email_verified_at"`
+	TotpSecret       pgtype.Text        `json:"totp_secret"`
+	TotpEnabledAt    pgtype.Timestamptz `json:"totp_enabled_at"`
+	TotpLastUsedStep pgtype.Int8        `json:"totp_last_used_step"`
+}
+
+type UserRole struct {
+	UserID      int64              `json:"user_id"`
+	RoleID      int64              `json:"role_id"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+}
+
+type Watch struct {
+	WatchID     pgtype.Int8        `json:"watch_id"`
+	Uuid        pgtype.UUID        `json:"uuid"`
+	UserID      int64              `json:"user_id"`
+	ItemID      int64              `json:"item_id"`
+	ReviewID    pgtype.Int8        `json:"review_id"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	Location    pgtype.Text        `json:"location"`
+	Format      pgtype.Text        `json:"format"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
 }
@@ -13,7 +13,7 @@ import (
 
 const addReview = `-- name: AddReview :one
 INSERT INTO
-    reviews (item_id, user_id, content)
+    reviews (item_id, user_id, content, rating)
 VALUES
     (
         (
@@ -32,31 +32,52 @@ VALUES
             WHERE
                 users.uuid = $2
         ),
-        $3
+        $3,
+        $4
     )
 RETURNING
-    uuid,
-    content,
-    created_date
+    uuid
 `
 
 type AddReviewParams struct {
-	ItemUuid pgtype.UUID `json:"item_uuid"`
-	UserUuid pgtype.UUID `json:"user_uuid"`
-	Content  string      `json:"content"`
+	ItemUuid pgtype.UUID    `json:"item_uuid"`
+	UserUuid pgtype.UUID    `json:"user_uuid"`
+	Content  string         `json:"content"`
+	Rating   pgtype.Numeric `json:"rating"`
 }
 
-type AddReviewRow struct {
-	Uuid        pgtype.UUID        `json:"uuid"`
-	Content     string             `json:"content"`
-	CreatedDate pgtype.Timestamptz `json:"created_date"`
+func (q *Queries) AddReview(ctx context.Context, arg AddReviewParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, addReview,
+		arg.ItemUuid,
+		arg.UserUuid,
+		arg.Content,
+		arg.Rating,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
 }
 
-func (q *Queries) AddReview(ctx context.Context, arg AddReviewParams) (AddReviewRow, error) {
-	row := q.db.QueryRow(ctx, addReview, arg.ItemUuid, arg.UserUuid, arg.Content)
-	var i AddReviewRow
-	err := row.Scan(&i.Uuid, &i.Content, &i.CreatedDate)
-	return i, err
+const checkReviewExists = `-- name: CheckReviewExists :one
+SELECT COUNT(*)
+FROM reviews r
+JOIN items i ON i.item_id = r.item_id
+JOIN users u ON u.user_id = r.user_id
+WHERE
+    i.uuid = $1
+    AND u.uuid = $2
+`
+
+type CheckReviewExistsParams struct {
+	ItemUuid pgtype.UUID `json:"item_uuid"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
+}
+
+func (q *Queries) CheckReviewExists(ctx context.Context, arg CheckReviewExistsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, checkReviewExists, arg.ItemUuid, arg.UserUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
 const deleteReview = `-- name: DeleteReview :exec
@@ -72,42 +93,138 @@ func (q *Queries) DeleteReview(ctx context.Context, reviewUuid pgtype.UUID) erro
 
 const getReview = `-- name: GetReview :one
 SELECT
-    uuid,
-    content,
-    created_date
+    r.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
+    r.content,
+    r.rating,
+    r.created_date,
+    r.updated_date
 FROM
-    reviews
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
 WHERE
-    uuid = $1
+    r.uuid = $1
 LIMIT
     1
 `
 
 type GetReviewRow struct {
 	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ItemTitle   string             `json:"item_title"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	Username    string             `json:"username"`
 	Content     string             `json:"content"`
+	Rating      pgtype.Numeric     `json:"rating"`
 	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
 }
 
 func (q *Queries) GetReview(ctx context.Context, reviewUuid pgtype.UUID) (GetReviewRow, error) {
 	row := q.db.QueryRow(ctx, getReview, reviewUuid)
 	var i GetReviewRow
-	err := row.Scan(&i.Uuid, &i.Content, &i.CreatedDate)
+	err := row.Scan(
+		&i.Uuid,
+		&i.ItemUuid,
+		&i.ItemTitle,
+		&i.UserUuid,
+		&i.Username,
+		&i.Content,
+		&i.Rating,
+		&i.CreatedDate,
+		&i.UpdatedDate,
+	)
 	return i, err
 }
 
+const getReviewForUserItem = `-- name: GetReviewForUserItem :one
+SELECT
+    r.uuid,
+    r.content,
+    r.rating
+FROM
+    reviews r
+        JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
+WHERE
+    i.uuid = $1
+    AND u.uuid = $2
+LIMIT
+    1
+`
+
+type GetReviewForUserItemParams struct {
+	ItemUuid pgtype.UUID `json:"item_uuid"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
+}
+
+type GetReviewForUserItemRow struct {
+	Uuid    pgtype.UUID    `json:"uuid"`
+	Content string         `json:"content"`
+	Rating  pgtype.Numeric `json:"rating"`
+}
+
+func (q *Queries) GetReviewForUserItem(ctx context.Context, arg GetReviewForUserItemParams) (GetReviewForUserItemRow, error) {
+	row := q.db.QueryRow(ctx, getReviewForUserItem, arg.ItemUuid, arg.UserUuid)
+	var i GetReviewForUserItemRow
+	err := row.Scan(&i.Uuid, &i.Content, &i.Rating)
+	return i, err
+}
+
+const getReviewsCountForItem = `-- name: GetReviewsCountForItem :one
+SELECT COUNT(*)
+FROM reviews r
+JOIN items i ON i.item_id = r.item_id
+WHERE
+    i.uuid = $1
+`
+
+func (q *Queries) GetReviewsCountForItem(ctx context.Context, itemUuid pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getReviewsCountForItem, itemUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getReviewsCountForUser = `-- name: GetReviewsCountForUser :one
+SELECT COUNT(*)
+FROM reviews r
+JOIN users u ON u.user_id = r.user_id
+WHERE
+    u.uuid = $1
+`
+
+func (q *Queries) GetReviewsCountForUser(ctx context.Context, userUuid pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getReviewsCountForUser, userUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getReviewsForItem = `-- name: GetReviewsForItem :many
 SELECT
     r.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
     r.content,
-    r.created_date
+    r.rating,
+    r.created_date,
+    r.updated_date
 FROM
     reviews r
         JOIN items i ON i.item_id = r.item_id
+        JOIN users u ON u.user_id = r.user_id
 WHERE
     i.uuid = $1
 ORDER BY
-    r.created_date
+    r.created_date DESC,
+    r.review_id DESC
 LIMIT
     $3
     OFFSET
@@ -122,8 +239,14 @@ type GetReviewsForItemParams struct {
 
 type GetReviewsForItemRow struct {
 	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ItemTitle   string             `json:"item_title"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	Username    string             `json:"username"`
 	Content     string             `json:"content"`
+	Rating      pgtype.Numeric     `json:"rating"`
 	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
 }
 
 func (q *Queries) GetReviewsForItem(ctx context.Context, arg GetReviewsForItemParams) ([]GetReviewsForItemRow, error) {
@@ -135,7 +258,17 @@ func (q *Queries) GetReviewsForItem(ctx context.Context, arg GetReviewsForItemPa
 	var items []GetReviewsForItemRow
 	for rows.Next() {
 		var i GetReviewsForItemRow
-		if err := rows.Scan(&i.Uuid, &i.Content, &i.CreatedDate); err != nil {
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ItemUuid,
+			&i.ItemTitle,
+			&i.UserUuid,
+			&i.Username,
+			&i.Content,
+			&i.Rating,
+			&i.CreatedDate,
+			&i.UpdatedDate,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -149,15 +282,23 @@ func (q *Queries) GetReviewsForItem(ctx context.Context, arg GetReviewsForItemPa
 const getReviewsForUser = `-- name: GetReviewsForUser :many
 SELECT
     r.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
     r.content,
-    r.created_date
+    r.rating,
+    r.created_date,
+    r.updated_date
 FROM
     reviews r
+        JOIN items i ON i.item_id = r.item_id
         JOIN users u ON u.user_id = r.user_id
 WHERE
     u.uuid = $1
 ORDER BY
-    r.created_date
+    r.created_date DESC,
+    r.review_id DESC
 LIMIT
     $3
     OFFSET
@@ -172,8 +313,14 @@ type GetReviewsForUserParams struct {
 
 type GetReviewsForUserRow struct {
 	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ItemTitle   string             `json:"item_title"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	Username    string             `json:"username"`
 	Content     string             `json:"content"`
+	Rating      pgtype.Numeric     `json:"rating"`
 	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
 }
 
 func (q *Queries) GetReviewsForUser(ctx context.Context, arg GetReviewsForUserParams) ([]GetReviewsForUserRow, error) {
@@ -185,7 +332,17 @@ func (q *Queries) GetReviewsForUser(ctx context.Context, arg GetReviewsForUserPa
 	var items []GetReviewsForUserRow
 	for rows.Next() {
 		var i GetReviewsForUserRow
-		if err := rows.Scan(&i.Uuid, &i.Content, &i.CreatedDate); err != nil {
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ItemUuid,
+			&i.ItemTitle,
+			&i.UserUuid,
+			&i.Username,
+			&i.Content,
+			&i.Rating,
+			&i.CreatedDate,
+			&i.UpdatedDate,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -196,30 +353,23 @@ func (q *Queries) GetReviewsForUser(ctx context.Context, arg GetReviewsForUserPa
 	return items, nil
 }
 
-const updateReview = `-- name: UpdateReview :one
+const updateReview = `-- name: UpdateReview :exec
 UPDATE reviews
 SET
-    content = $1
+    content = $1,
+    rating = $2,
+    updated_date = CURRENT_TIMESTAMP
 WHERE
-    uuid = $2
-RETURNING
-    uuid,
-    content
+    uuid = $3
 `
 
 type UpdateReviewParams struct {
-	Content    string      `json:"content"`
-	ReviewUuid pgtype.UUID `json:"review_uuid"`
+	Content    string         `json:"content"`
+	Rating     pgtype.Numeric `json:"rating"`
+	ReviewUuid pgtype.UUID    `json:"review_uuid"`
 }
 
-type UpdateReviewRow struct {
-	Uuid    pgtype.UUID `json:"uuid"`
-	Content string      `json:"content"`
-}
-
-func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) (UpdateReviewRow, error) {
-	row := q.db.QueryRow(ctx, updateReview, arg.Content, arg.ReviewUuid)
-	var i UpdateReviewRow
-	err := row.Scan(&i.Uuid, &i.Content)
-	return i, err
+func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) error {
+	_, err := q.db.Exec(ctx, updateReview, arg.Content, arg.Rating, arg.ReviewUuid)
+	return err
 }
@@ -0,0 +1,198 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: role_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addUserRole = `-- name: AddUserRole :execrows
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.user_id, $1
+FROM users u
+WHERE u.uuid = $2
+ON CONFLICT DO NOTHING
+`
+
+type AddUserRoleParams struct {
+	RoleID   int64       `json:"role_id"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
+}
+
+func (q *Queries) AddUserRole(ctx context.Context, arg AddUserRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addUserRole, arg.RoleID, arg.UserUuid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addUserRoleByName = `-- name: AddUserRoleByName :exec
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.user_id, r.role_id
+FROM users u
+JOIN roles r ON r.name = $1
+WHERE u.uuid = $2
+ON CONFLICT DO NOTHING
+`
+
+type AddUserRoleByNameParams struct {
+	RoleName string      `json:"role_name"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
+}
+
+func (q *Queries) AddUserRoleByName(ctx context.Context, arg AddUserRoleByNameParams) error {
+	_, err := q.db.Exec(ctx, addUserRoleByName, arg.RoleName, arg.UserUuid)
+	return err
+}
+
+const getPermissionsForUser = `-- name: GetPermissionsForUser :many
+SELECT DISTINCT p.name
+FROM users u
+JOIN user_roles ur ON ur.user_id = u.user_id
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+JOIN permissions p ON p.permission_id = rp.permission_id
+WHERE u.uuid = $1
+ORDER BY p.name
+`
+
+func (q *Queries) GetPermissionsForUser(ctx context.Context, userUuid pgtype.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getPermissionsForUser, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoles = `-- name: GetRoles :many
+SELECT
+    r.name,
+    r.description,
+    COALESCE(ARRAY_AGG(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')::TEXT[] AS permissions
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role_id = r.role_id
+LEFT JOIN permissions p ON p.permission_id = rp.permission_id
+GROUP BY r.role_id
+ORDER BY r.role_id
+`
+
+type GetRolesRow struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (q *Queries) GetRoles(ctx context.Context) ([]GetRolesRow, error) {
+	rows, err := q.db.Query(ctx, getRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRolesRow
+	for rows.Next() {
+		var i GetRolesRow
+		if err := rows.Scan(&i.Name, &i.Description, &i.Permissions); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRolesForUser = `-- name: GetRolesForUser :many
+SELECT r.name
+FROM users u
+JOIN user_roles ur ON ur.user_id = u.user_id
+JOIN roles r ON r.role_id = ur.role_id
+WHERE u.uuid = $1
+ORDER BY r.role_id
+`
+
+func (q *Queries) GetRolesForUser(ctx context.Context, userUuid pgtype.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getRolesForUser, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserCountForRole = `-- name: GetUserCountForRole :one
+SELECT COUNT(*)
+FROM user_roles
+WHERE role_id = $1
+`
+
+func (q *Queries) GetUserCountForRole(ctx context.Context, roleID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getUserCountForRole, roleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const lockRoleByName = `-- name: LockRoleByName :one
+SELECT role_id
+FROM roles
+WHERE name = $1
+FOR UPDATE
+`
+
+// Locks a role so that concurrent changes to who holds it are applied one at a time
+func (q *Queries) LockRoleByName(ctx context.Context, roleName string) (pgtype.Int8, error) {
+	row := q.db.QueryRow(ctx, lockRoleByName, roleName)
+	var role_id pgtype.Int8
+	err := row.Scan(&role_id)
+	return role_id, err
+}
+
+const removeUserRole = `-- name: RemoveUserRole :execrows
+DELETE FROM user_roles ur
+USING users u
+WHERE ur.user_id = u.user_id
+  AND u.uuid = $1
+  AND ur.role_id = $2
+`
+
+type RemoveUserRoleParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	RoleID   int64       `json:"role_id"`
+}
+
+func (q *Queries) RemoveUserRole(ctx context.Context, arg RemoveUserRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeUserRole, arg.UserUuid, arg.RoleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -48,6 +48,41 @@ func (q *Queries) AddStatus(ctx context.Context, arg AddStatusParams) (AddStatus
 	return i, err
 }
 
+const checkStatusLabelForUser = `-- name: CheckStatusLabelForUser :one
+SELECT COUNT(*)
+FROM statuses s
+JOIN users u
+ON u.user_id = s.user_id
+WHERE
+    u.uuid = $1
+    AND s.label = $2
+    AND s.uuid IS DISTINCT FROM $3
+`
+
+type CheckStatusLabelForUserParams struct {
+	UserUuid    pgtype.UUID `json:"user_uuid"`
+	StatusLabel pgtype.Text `json:"status_label"`
+	ExcludeUuid pgtype.UUID `json:"exclude_uuid"`
+}
+
+func (q *Queries) CheckStatusLabelForUser(ctx context.Context, arg CheckStatusLabelForUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, checkStatusLabelForUser, arg.UserUuid, arg.StatusLabel, arg.ExcludeUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteStatus = `-- name: DeleteStatus :exec
+DELETE FROM statuses
+WHERE
+    uuid = $1
+`
+
+func (q *Queries) DeleteStatus(ctx context.Context, statusUuid pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteStatus, statusUuid)
+	return err
+}
+
 const getAllStatuses = `-- name: GetAllStatuses :many
 SELECT
     uuid,
@@ -104,27 +139,75 @@ func (q *Queries) GetAllStatusesCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const getListUuidsForStatus = `-- name: GetListUuidsForStatus :many
+SELECT
+    l.uuid
+FROM
+    lists l
+WHERE
+    EXISTS (
+        SELECT 1
+        FROM list_statuses ls
+        JOIN statuses s ON s.status_id = ls.status_id
+        WHERE ls.list_id = l.list_id
+          AND s.uuid = $1
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM list_items li
+        JOIN statuses s ON s.status_id = li.status_id
+        WHERE li.list_id = l.list_id
+          AND s.uuid = $1
+    )
+ORDER BY
+    l.list_id
+`
+
+// Returns every list that shows a status as a column or has items in it
+func (q *Queries) GetListUuidsForStatus(ctx context.Context, statusUuid pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getListUuidsForStatus, statusUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var uuid pgtype.UUID
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		items = append(items, uuid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getStatus = `-- name: GetStatus :one
 SELECT
-    uuid,
-    label
+    s.uuid,
+    s.label,
+    u.uuid AS user_uuid
 FROM
-    statuses
+    statuses s
+        JOIN users u ON u.user_id = s.user_id
 WHERE
-    uuid = $1
+    s.uuid = $1
 LIMIT
     1
 `
 
 type GetStatusRow struct {
-	Uuid  pgtype.UUID `json:"uuid"`
-	Label pgtype.Text `json:"label"`
+	Uuid     pgtype.UUID `json:"uuid"`
+	Label    pgtype.Text `json:"label"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
 }
 
 func (q *Queries) GetStatus(ctx context.Context, statusUuid pgtype.UUID) (GetStatusRow, error) {
 	row := q.db.QueryRow(ctx, getStatus, statusUuid)
 	var i GetStatusRow
-	err := row.Scan(&i.Uuid, &i.Label)
+	err := row.Scan(&i.Uuid, &i.Label, &i.UserUuid)
 	return i, err
 }
 
@@ -191,3 +274,31 @@ func (q *Queries) GetStatusesForUser(ctx context.Context, arg GetStatusesForUser
 	}
 	return items, nil
 }
+
+const updateStatusLabel = `-- name: UpdateStatusLabel :one
+UPDATE statuses
+SET
+    label = $1
+WHERE
+    uuid = $2
+RETURNING
+    uuid,
+    label
+`
+
+type UpdateStatusLabelParams struct {
+	StatusLabel pgtype.Text `json:"status_label"`
+	StatusUuid  pgtype.UUID `json:"status_uuid"`
+}
+
+type UpdateStatusLabelRow struct {
+	Uuid  pgtype.UUID `json:"uuid"`
+	Label pgtype.Text `json:"label"`
+}
+
+func (q *Queries) UpdateStatusLabel(ctx context.Context, arg UpdateStatusLabelParams) (UpdateStatusLabelRow, error) {
+	row := q.db.QueryRow(ctx, updateStatusLabel, arg.StatusLabel, arg.StatusUuid)
+	var i UpdateStatusLabelRow
+	err := row.Scan(&i.Uuid, &i.Label)
+	return i, err
+}
@@ -0,0 +1,74 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: tmdb_cache_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteTmdbCacheEntriesBefore = `-- name: DeleteTmdbCacheEntriesBefore :execrows
+DELETE FROM tmdb_cache
+WHERE
+    fetched_date < $1
+`
+
+func (q *Queries) DeleteTmdbCacheEntriesBefore(ctx context.Context, fetchedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTmdbCacheEntriesBefore, fetchedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTmdbCacheEntry = `-- name: GetTmdbCacheEntry :one
+SELECT
+    response,
+    fetched_date
+FROM
+    tmdb_cache
+WHERE
+    cache_key = $1
+LIMIT
+    1
+`
+
+type GetTmdbCacheEntryRow struct {
+	Response    []byte             `json:"response"`
+	FetchedDate pgtype.Timestamptz `json:"fetched_date"`
+}
+
+func (q *Queries) GetTmdbCacheEntry(ctx context.Context, cacheKey string) (GetTmdbCacheEntryRow, error) {
+	row := q.db.QueryRow(ctx, getTmdbCacheEntry, cacheKey)
+	var i GetTmdbCacheEntryRow
+	err := row.Scan(&i.Response, &i.FetchedDate)
+	return i, err
+}
+
+const upsertTmdbCacheEntry = `-- name: UpsertTmdbCacheEntry :exec
+INSERT INTO
+    tmdb_cache (cache_key, response)
+VALUES
+    (
+        $1,
+        $2
+    )
+ON CONFLICT (cache_key) DO UPDATE
+SET
+    response = EXCLUDED.response,
+    fetched_date = CURRENT_TIMESTAMP
+`
+
+type UpsertTmdbCacheEntryParams struct {
+	CacheKey string `json:"cache_key"`
+	Response []byte `json:"response"`
+}
+
+func (q *Queries) UpsertTmdbCacheEntry(ctx context.Context, arg UpsertTmdbCacheEntryParams) error {
+	_, err := q.db.Exec(ctx, upsertTmdbCacheEntry, arg.CacheKey, arg.Response)
+	return err
+}
@@ -12,59 +12,265 @@ import (
 )
 
 const addRefreshToken = `-- name: AddRefreshToken :one
-INSERT INTO refresh_tokens (user_id, token, expires_at)
+INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, device_name, user_agent, ip_address)
 VALUES (
-        $1, $2, $3
+        $1, $2, $3, $4, $5, $6, $7
        )
-RETURNING token, expires_at
+RETURNING family_id, expires_at
 `
 
 type AddRefreshTokenParams struct {
-	UserID    int64              `json:"user_id"`
-	Token     string             `json:"token"`
-	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UserID     int64              `json:"user_id"`
+	TokenHash  string             `json:"token_hash"`
+	FamilyID   pgtype.UUID        `json:"family_id"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	DeviceName pgtype.Text        `json:"device_name"`
+	UserAgent  pgtype.Text        `json:"user_agent"`
+	IpAddress  pgtype.Text        `json:"ip_address"`
 }
 
 type AddRefreshTokenRow struct {
-	Token     string             `json:"token"`
+	FamilyID  pgtype.UUID        `json:"family_id"`
 	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
 }
 
 func (q *Queries) AddRefreshToken(ctx context.Context, arg AddRefreshTokenParams) (AddRefreshTokenRow, error) {
-	row := q.db.QueryRow(ctx, addRefreshToken, arg.UserID, arg.Token, arg.ExpiresAt)
+	row := q.db.QueryRow(ctx, addRefreshToken,
+		arg.UserID,
+		arg.TokenHash,
+		arg.FamilyID,
+		arg.ExpiresAt,
+		arg.DeviceName,
+		arg.UserAgent,
+		arg.IpAddress,
+	)
 	var i AddRefreshTokenRow
-	err := row.Scan(&i.Token, &i.ExpiresAt)
+	err := row.Scan(&i.FamilyID, &i.ExpiresAt)
 	return i, err
 }
 
-const deleteRefreshToken = `-- name: DeleteRefreshToken :exec
+const deleteExpiredRefreshTokens = `-- name: DeleteExpiredRefreshTokens :execrows
 DELETE FROM refresh_tokens
-WHERE token = $1
+WHERE expires_at < CURRENT_TIMESTAMP
 `
 
-func (q *Queries) DeleteRefreshToken(ctx context.Context, token string) error {
-	_, err := q.db.Exec(ctx, deleteRefreshToken, token)
+func (q *Queries) DeleteExpiredRefreshTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredRefreshTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOtherSessionsForUser = `-- name: DeleteOtherSessionsForUser :execrows
+DELETE FROM refresh_tokens rt
+USING users u
+WHERE rt.user_id = u.user_id
+  AND u.uuid = $1
+  AND rt.family_id <> $2
+`
+
+type DeleteOtherSessionsForUserParams struct {
+	UserUuid        pgtype.UUID `json:"user_uuid"`
+	CurrentFamilyID pgtype.UUID `json:"current_family_id"`
+}
+
+func (q *Queries) DeleteOtherSessionsForUser(ctx context.Context, arg DeleteOtherSessionsForUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOtherSessionsForUser, arg.UserUuid, arg.CurrentFamilyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRefreshTokenFamily = `-- name: DeleteRefreshTokenFamily :exec
+DELETE FROM refresh_tokens
+WHERE family_id = $1
+`
+
+func (q *Queries) DeleteRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRefreshTokenFamily, familyID)
 	return err
 }
 
-const getRefreshTokenByToken = `-- name: GetRefreshTokenByToken :one
+const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
 SELECT
-    user_id,
-    token,
-    expires_at
-FROM refresh_tokens
-WHERE token = $1
+    rt.token_id,
+    rt.user_id,
+    u.uuid AS user_uuid,
+    rt.family_id,
+    rt.device_name,
+    rt.expires_at,
+    rt.used_at,
+    rt.revoked_at
+FROM refresh_tokens rt
+JOIN users u ON rt.user_id = u.user_id
+WHERE rt.token_hash = $1
 `
 
-type GetRefreshTokenByTokenRow struct {
-	UserID    int64              `json:"user_id"`
-	Token     string             `json:"token"`
-	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+type GetRefreshTokenByHashRow struct {
+	TokenID    pgtype.Int8        `json:"token_id"`
+	UserID     int64              `json:"user_id"`
+	UserUuid   pgtype.UUID        `json:"user_uuid"`
+	FamilyID   pgtype.UUID        `json:"family_id"`
+	DeviceName pgtype.Text        `json:"device_name"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	UsedAt     pgtype.Timestamptz `json:"used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
 }
 
-func (q *Queries) GetRefreshTokenByToken(ctx context.Context, token string) (GetRefreshTokenByTokenRow, error) {
-	row := q.db.QueryRow(ctx, getRefreshTokenByToken, token)
-	var i GetRefreshTokenByTokenRow
-	err := row.Scan(&i.UserID, &i.Token, &i.ExpiresAt)
+func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (GetRefreshTokenByHashRow, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByHash, tokenHash)
+	var i GetRefreshTokenByHashRow
+	err := row.Scan(
+		&i.TokenID,
+		&i.UserID,
+		&i.UserUuid,
+		&i.FamilyID,
+		&i.DeviceName,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.RevokedAt,
+	)
 	return i, err
 }
+
+const getSessionDeviceName = `-- name: GetSessionDeviceName :one
+SELECT device_name
+FROM refresh_tokens
+WHERE family_id = $1
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetSessionDeviceName(ctx context.Context, familyID pgtype.UUID) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getSessionDeviceName, familyID)
+	var device_name pgtype.Text
+	err := row.Scan(&device_name)
+	return device_name, err
+}
+
+const getSessionOwner = `-- name: GetSessionOwner :one
+SELECT DISTINCT u.uuid
+FROM refresh_tokens rt
+JOIN users u ON rt.user_id = u.user_id
+WHERE rt.family_id = $1
+`
+
+func (q *Queries) GetSessionOwner(ctx context.Context, familyID pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getSessionOwner, familyID)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const getSessionsForUser = `-- name: GetSessionsForUser :many
+SELECT
+    rt.family_id,
+    rt.device_name,
+    rt.user_agent,
+    rt.ip_address,
+    rt.last_used_at,
+    rt.expires_at
+FROM refresh_tokens rt
+JOIN users u ON rt.user_id = u.user_id
+WHERE u.uuid = $1
+  AND rt.used_at IS NULL
+  AND rt.revoked_at IS NULL
+  AND rt.expires_at > CURRENT_TIMESTAMP
+ORDER BY rt.last_used_at DESC
+`
+
+type GetSessionsForUserRow struct {
+	FamilyID   pgtype.UUID        `json:"family_id"`
+	DeviceName pgtype.Text        `json:"device_name"`
+	UserAgent  pgtype.Text        `json:"user_agent"`
+	IpAddress  pgtype.Text        `json:"ip_address"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) GetSessionsForUser(ctx context.Context, userUuid pgtype.UUID) ([]GetSessionsForUserRow, error) {
+	rows, err := q.db.Query(ctx, getSessionsForUser, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSessionsForUserRow
+	for rows.Next() {
+		var i GetSessionsForUserRow
+		if err := rows.Scan(
+			&i.FamilyID,
+			&i.DeviceName,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isSessionActive = `-- name: IsSessionActive :one
+SELECT EXISTS (
+    SELECT 1
+    FROM refresh_tokens
+    WHERE family_id = $1
+      AND revoked_at IS NULL
+      AND expires_at > CURRENT_TIMESTAMP
+)
+`
+
+func (q *Queries) IsSessionActive(ctx context.Context, familyID pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isSessionActive, familyID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const markRefreshTokenUsed = `-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE token_id = $1
+  AND used_at IS NULL
+  AND revoked_at IS NULL
+`
+
+func (q *Queries) MarkRefreshTokenUsed(ctx context.Context, tokenID pgtype.Int8) (int64, error) {
+	result, err := q.db.Exec(ctx, markRefreshTokenUsed, tokenID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE family_id = $1
+  AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokenFamily, familyID)
+	return err
+}
+
+const revokeRefreshTokensForUser = `-- name: RevokeRefreshTokensForUser :exec
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = $1
+  AND revoked_at IS NULL
+`
+
+// Revokes every session a user has. The tokens are kept until they expire so that reuse is still detected
+func (q *Queries) RevokeRefreshTokensForUser(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokensForUser, userID)
+	return err
+}
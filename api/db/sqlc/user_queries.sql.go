@@ -151,7 +151,8 @@ SELECT
     username,
     email,
     hashed_password,
-    superuser
+    email_verified_at,
+    totp_enabled_at
 FROM
     users
 WHERE
@@ -168,12 +169,13 @@ type GetExistingUserParams struct {
 }
 
 type GetExistingUserRow struct {
-	UserID         pgtype.Int8 `json:"user_id"`
-	Uuid           pgtype.UUID `json:"uuid"`
-	Username       string      `json:"username"`
-	Email          string      `json:"email"`
-	HashedPassword string      `json:"hashed_password"`
-	Superuser      bool        `json:"superuser"`
+	UserID          pgtype.Int8        `json:"user_id"`
+	Uuid            pgtype.UUID        `json:"uuid"`
+	Username        string             `json:"username"`
+	Email           string             `json:"email"`
+	HashedPassword  string             `json:"hashed_password"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+	TotpEnabledAt   pgtype.Timestamptz `json:"totp_enabled_at"`
 }
 
 func (q *Queries) GetExistingUser(ctx context.Context, arg GetExistingUserParams) (GetExistingUserRow, error) {
@@ -185,7 +187,44 @@ func (q *Queries) GetExistingUser(ctx context.Context, arg GetExistingUserParams
 		&i.Username,
 		&i.Email,
 		&i.HashedPassword,
-		&i.Superuser,
+		&i.EmailVerifiedAt,
+		&i.TotpEnabledAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT
+    user_id,
+    uuid,
+    username,
+    email,
+    email_verified_at
+FROM
+    users
+WHERE
+    email = $1
+LIMIT
+    1
+`
+
+type GetUserByEmailRow struct {
+	UserID          pgtype.Int8        `json:"user_id"`
+	Uuid            pgtype.UUID        `json:"uuid"`
+	Username        string             `json:"username"`
+	Email           string             `json:"email"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i GetUserByEmailRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Uuid,
+		&i.Username,
+		&i.Email,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -195,8 +234,7 @@ SELECT
     uuid,
     username,
     full_name,
-    bio,
-    superuser
+    bio
 FROM
     users
 WHERE
@@ -206,11 +244,10 @@ LIMIT
 `
 
 type GetUserByIdRow struct {
-	Uuid      pgtype.UUID `json:"uuid"`
-	Username  string      `json:"username"`
-	FullName  pgtype.Text `json:"full_name"`
-	Bio       pgtype.Text `json:"bio"`
-	Superuser bool        `json:"superuser"`
+	Uuid     pgtype.UUID `json:"uuid"`
+	Username string      `json:"username"`
+	FullName pgtype.Text `json:"full_name"`
+	Bio      pgtype.Text `json:"bio"`
 }
 
 func (q *Queries) GetUserById(ctx context.Context, userID pgtype.Int8) (GetUserByIdRow, error) {
@@ -221,7 +258,6 @@ func (q *Queries) GetUserById(ctx context.Context, userID pgtype.Int8) (GetUserB
 		&i.Username,
 		&i.FullName,
 		&i.Bio,
-		&i.Superuser,
 	)
 	return i, err
 }
@@ -231,8 +267,7 @@ SELECT
     uuid,
     username,
     full_name,
-    bio,
-    superuser
+    bio
 FROM
     users
 WHERE
@@ -242,11 +277,10 @@ LIMIT
 `
 
 type GetUserByUuidRow struct {
-	Uuid      pgtype.UUID `json:"uuid"`
-	Username  string      `json:"username"`
-	FullName  pgtype.Text `json:"full_name"`
-	Bio       pgtype.Text `json:"bio"`
-	Superuser bool        `json:"superuser"`
+	Uuid     pgtype.UUID `json:"uuid"`
+	Username string      `json:"username"`
+	FullName pgtype.Text `json:"full_name"`
+	Bio      pgtype.Text `json:"bio"`
 }
 
 func (q *Queries) GetUserByUuid(ctx context.Context, userUuid pgtype.UUID) (GetUserByUuidRow, error) {
@@ -257,7 +291,6 @@ func (q *Queries) GetUserByUuid(ctx context.Context, userUuid pgtype.UUID) (GetU
 		&i.Username,
 		&i.FullName,
 		&i.Bio,
-		&i.Superuser,
 	)
 	return i, err
 }
@@ -274,6 +307,99 @@ func (q *Queries) GetUserCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const getUserEmail = `-- name: GetUserEmail :one
+SELECT
+    uuid,
+    username,
+    email,
+    email_verified_at
+FROM
+    users
+WHERE
+    uuid = $1
+LIMIT
+    1
+`
+
+type GetUserEmailRow struct {
+	Uuid            pgtype.UUID        `json:"uuid"`
+	Username        string             `json:"username"`
+	Email           string             `json:"email"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+}
+
+func (q *Queries) GetUserEmail(ctx context.Context, userUuid pgtype.UUID) (GetUserEmailRow, error) {
+	row := q.db.QueryRow(ctx, getUserEmail, userUuid)
+	var i GetUserEmailRow
+	err := row.Scan(
+		&i.Uuid,
+		&i.Username,
+		&i.Email,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
+const getUserPassword = `-- name: GetUserPassword :one
+SELECT
+    user_id,
+    hashed_password
+FROM
+    users
+WHERE
+    uuid = $1
+LIMIT
+    1
+`
+
+type GetUserPasswordRow struct {
+	UserID         pgtype.Int8 `json:"user_id"`
+	HashedPassword string      `json:"hashed_password"`
+}
+
+func (q *Queries) GetUserPassword(ctx context.Context, userUuid pgtype.UUID) (GetUserPasswordRow, error) {
+	row := q.db.QueryRow(ctx, getUserPassword, userUuid)
+	var i GetUserPasswordRow
+	err := row.Scan(&i.UserID, &i.HashedPassword)
+	return i, err
+}
+
+const isEmailVerified = `-- name: IsEmailVerified :one
+SELECT (email_verified_at IS NOT NULL)::BOOLEAN AS verified
+FROM users
+WHERE uuid = $1
+`
+
+func (q *Queries) IsEmailVerified(ctx context.Context, userUuid pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isEmailVerified, userUuid)
+	var verified bool
+	err := row.Scan(&verified)
+	return verified, err
+}
+
+const markEmailVerified = `-- name: MarkEmailVerified :execrows
+UPDATE users
+SET
+    email_verified_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = $1
+AND
+    email = $2
+`
+
+type MarkEmailVerifiedParams struct {
+	UserID pgtype.Int8 `json:"user_id"`
+	Email  string      `json:"email"`
+}
+
+func (q *Queries) MarkEmailVerified(ctx context.Context, arg MarkEmailVerifiedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markEmailVerified, arg.UserID, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUserDetails = `-- name: UpdateUserDetails :one
 UPDATE users
 SET
@@ -319,3 +445,40 @@ func (q *Queries) UpdateUserDetails(ctx context.Context, arg UpdateUserDetailsPa
 	)
 	return i, err
 }
+
+const updateUserEmail = `-- name: UpdateUserEmail :exec
+UPDATE users
+SET
+    email = $1,
+    email_verified_at = NULL
+WHERE
+    uuid = $2
+`
+
+type UpdateUserEmailParams struct {
+	Email    string      `json:"email"`
+	UserUuid pgtype.UUID `json:"user_uuid"`
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) error {
+	_, err := q.db.Exec(ctx, updateUserEmail, arg.Email, arg.UserUuid)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET
+    hashed_password = $1
+WHERE
+    user_id = $2
+`
+
+type UpdateUserPasswordParams struct {
+	HashedPassword string      `json:"hashed_password"`
+	UserID         pgtype.Int8 `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.HashedPassword, arg.UserID)
+	return err
+}
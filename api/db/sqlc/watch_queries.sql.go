@@ -0,0 +1,295 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: watch_queries.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addWatch = `-- name: AddWatch :one
+INSERT INTO
+    watches (user_id, item_id, review_id, watched_date, rewatch, rating, location, format)
+VALUES
+    (
+        (SELECT user_id FROM users WHERE users.uuid = $1),
+        (SELECT item_id FROM items WHERE items.uuid = $2),
+        (SELECT review_id FROM reviews WHERE reviews.uuid = $3),
+        $4,
+        $5,
+        $6,
+        $7,
+        $8
+    )
+RETURNING
+    uuid
+`
+
+type AddWatchParams struct {
+	UserUuid    pgtype.UUID    `json:"user_uuid"`
+	ItemUuid    pgtype.UUID    `json:"item_uuid"`
+	ReviewUuid  pgtype.UUID    `json:"review_uuid"`
+	WatchedDate pgtype.Date    `json:"watched_date"`
+	Rewatch     bool           `json:"rewatch"`
+	Rating      pgtype.Numeric `json:"rating"`
+	Location    pgtype.Text    `json:"location"`
+	Format      pgtype.Text    `json:"format"`
+}
+
+func (q *Queries) AddWatch(ctx context.Context, arg AddWatchParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, addWatch,
+		arg.UserUuid,
+		arg.ItemUuid,
+		arg.ReviewUuid,
+		arg.WatchedDate,
+		arg.Rewatch,
+		arg.Rating,
+		arg.Location,
+		arg.Format,
+	)
+	var uuid pgtype.UUID
+	err := row.Scan(&uuid)
+	return uuid, err
+}
+
+const checkWatchExists = `-- name: CheckWatchExists :one
+SELECT COUNT(*)
+FROM watches w
+JOIN items i ON i.item_id = w.item_id
+JOIN users u ON u.user_id = w.user_id
+WHERE
+    u.uuid = $1
+    AND i.uuid = $2
+    AND w.watched_date = $3
+`
+
+type CheckWatchExistsParams struct {
+	UserUuid    pgtype.UUID `json:"user_uuid"`
+	ItemUuid    pgtype.UUID `json:"item_uuid"`
+	WatchedDate pgtype.Date `json:"watched_date"`
+}
+
+func (q *Queries) CheckWatchExists(ctx context.Context, arg CheckWatchExistsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, checkWatchExists, arg.UserUuid, arg.ItemUuid, arg.WatchedDate)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteWatch = `-- name: DeleteWatch :exec
+DELETE FROM watches
+WHERE
+    uuid = $1
+`
+
+func (q *Queries) DeleteWatch(ctx context.Context, watchUuid pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteWatch, watchUuid)
+	return err
+}
+
+const getWatch = `-- name: GetWatch :one
+SELECT
+    w.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
+    r.uuid AS review_uuid,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.location,
+    w.format,
+    w.created_date,
+    w.updated_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+        LEFT JOIN reviews r ON r.review_id = w.review_id
+WHERE
+    w.uuid = $1
+LIMIT
+    1
+`
+
+type GetWatchRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ItemTitle   string             `json:"item_title"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	Username    string             `json:"username"`
+	ReviewUuid  pgtype.UUID        `json:"review_uuid"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	Location    pgtype.Text        `json:"location"`
+	Format      pgtype.Text        `json:"format"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+func (q *Queries) GetWatch(ctx context.Context, watchUuid pgtype.UUID) (GetWatchRow, error) {
+	row := q.db.QueryRow(ctx, getWatch, watchUuid)
+	var i GetWatchRow
+	err := row.Scan(
+		&i.Uuid,
+		&i.ItemUuid,
+		&i.ItemTitle,
+		&i.UserUuid,
+		&i.Username,
+		&i.ReviewUuid,
+		&i.WatchedDate,
+		&i.Rewatch,
+		&i.Rating,
+		&i.Location,
+		&i.Format,
+		&i.CreatedDate,
+		&i.UpdatedDate,
+	)
+	return i, err
+}
+
+const getWatchesCountForUser = `-- name: GetWatchesCountForUser :one
+SELECT COUNT(*)
+FROM watches w
+JOIN users u ON u.user_id = w.user_id
+WHERE
+    u.uuid = $1
+`
+
+func (q *Queries) GetWatchesCountForUser(ctx context.Context, userUuid pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getWatchesCountForUser, userUuid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getWatchesForUser = `-- name: GetWatchesForUser :many
+SELECT
+    w.uuid,
+    i.uuid AS item_uuid,
+    i.title AS item_title,
+    u.uuid AS user_uuid,
+    u.username,
+    r.uuid AS review_uuid,
+    w.watched_date,
+    w.rewatch,
+    w.rating,
+    w.location,
+    w.format,
+    w.created_date,
+    w.updated_date
+FROM
+    watches w
+        JOIN items i ON i.item_id = w.item_id
+        JOIN users u ON u.user_id = w.user_id
+        LEFT JOIN reviews r ON r.review_id = w.review_id
+WHERE
+    u.uuid = $1
+ORDER BY
+    w.watched_date DESC,
+    w.watch_id DESC
+LIMIT
+    $3
+    OFFSET
+    $2
+`
+
+type GetWatchesForUserParams struct {
+	UserUuid pgtype.UUID `json:"user_uuid"`
+	Page     int32       `json:"page"`
+	PageSize int32       `json:"page_size"`
+}
+
+type GetWatchesForUserRow struct {
+	Uuid        pgtype.UUID        `json:"uuid"`
+	ItemUuid    pgtype.UUID        `json:"item_uuid"`
+	ItemTitle   string             `json:"item_title"`
+	UserUuid    pgtype.UUID        `json:"user_uuid"`
+	Username    string             `json:"username"`
+	ReviewUuid  pgtype.UUID        `json:"review_uuid"`
+	WatchedDate pgtype.Date        `json:"watched_date"`
+	Rewatch     bool               `json:"rewatch"`
+	Rating      pgtype.Numeric     `json:"rating"`
+	Location    pgtype.Text        `json:"location"`
+	Format      pgtype.Text        `json:"format"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UpdatedDate pgtype.Timestamptz `json:"updated_date"`
+}
+
+// Returns a user's diary, most recently watched first
+func (q *Queries) GetWatchesForUser(ctx context.Context, arg GetWatchesForUserParams) ([]GetWatchesForUserRow, error) {
+	rows, err := q.db.Query(ctx, getWatchesForUser, arg.UserUuid, arg.Page, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWatchesForUserRow
+	for rows.Next() {
+		var i GetWatchesForUserRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ItemUuid,
+			&i.ItemTitle,
+			&i.UserUuid,
+			&i.Username,
+			&i.ReviewUuid,
+			&i.WatchedDate,
+			&i.Rewatch,
+			&i.Rating,
+			&i.Location,
+			&i.Format,
+			&i.CreatedDate,
+			&i.UpdatedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateWatch = `-- name: UpdateWatch :exec
+UPDATE watches
+SET
+    review_id = (SELECT review_id FROM reviews WHERE reviews.uuid = $1),
+    watched_date = $2,
+    rewatch = $3,
+    rating = $4,
+    location = $5,
+    format = $6,
+    updated_date = CURRENT_TIMESTAMP
+WHERE
+    watches.uuid = $7
+`
+
+type UpdateWatchParams struct {
+	ReviewUuid  pgtype.UUID    `json:"review_uuid"`
+	WatchedDate pgtype.Date    `json:"watched_date"`
+	Rewatch     bool           `json:"rewatch"`
+	Rating      pgtype.Numeric `json:"rating"`
+	Location    pgtype.Text    `json:"location"`
+	Format      pgtype.Text    `json:"format"`
+	WatchUuid   pgtype.UUID    `json:"watch_uuid"`
+}
+
+func (q *Queries) UpdateWatch(ctx context.Context, arg UpdateWatchParams) error {
+	_, err := q.db.Exec(ctx, updateWatch,
+		arg.ReviewUuid,
+		arg.WatchedDate,
+		arg.Rewatch,
+		arg.Rating,
+		arg.Location,
+		arg.Format,
+		arg.WatchUuid,
+	)
+	return err
+}
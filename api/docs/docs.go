@@ -23,61 +23,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
+        "/admin/items/{uuid}/refresh": {
             "post": {
-                "description": "Log in to user account using email or username",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Log in",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Login details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.LoginUserRequest"
-                        }
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "Successful login",
-                        "schema": {
-                            "$ref": "#/definitions/types.TokenResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Missing mandatory fields",
-                        "schema": {
-                            "$ref": "#/definitions/types.MissingFieldResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "$ref": "#/definitions/types.UserNotFoundResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/logout": {
-            "post": {
-                "description": "Log out of the app",
+                "description": "Re-fetch the TMDB details of an item immediately instead of waiting for the scheduled refresh.\nRequires the items:refresh permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,73 +38,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "items"
                 ],
-                "summary": "Log out",
+                "summary": "Refresh item metadata",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Refresh token",
-                        "name": "Refresh-Token",
-                        "in": "header",
+                        "description": "Item UUID",
+                        "name": "uuid",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Logout successful",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.LogoutSuccessResponse"
+                            "$ref": "#/definitions/types.ItemDetailsResponse"
                         }
                     },
                     "400": {
-                        "description": "Missing refresh token",
+                        "description": "Item was not imported from TMDB",
                         "schema": {
-                            "$ref": "#/definitions/types.RefreshTokenMissingResponse"
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/auth/refresh": {
-            "post": {
-                "description": "Log out of the app",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Log out",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Refresh token",
-                        "name": "Refresh-Token",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Logout successful",
-                        "schema": {
-                            "$ref": "#/definitions/types.LogoutSuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Missing refresh token",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/types.RefreshTokenMissingResponse"
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -159,13 +80,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account",
+        "/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every role and the permissions it grants. Requires the roles:assign permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -173,31 +105,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Register a new user account",
-                "parameters": [
-                    {
-                        "description": "User details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.RegisterUserRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Get roles",
                 "responses": {
                     "200": {
-                        "description": "User registered successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.UserResponse"
+                            "$ref": "#/definitions/types.RolesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Missing mandatory fields",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.MissingFieldResponse"
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -209,14 +130,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/items": {
+        "/admin/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all items in a paginated list",
+                "description": "Get all users in a paginated list. Requires the users:list permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -224,9 +145,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "users"
                 ],
-                "summary": "Get all items",
+                "summary": "Get all users",
                 "parameters": [
                     {
                         "type": "integer",
@@ -245,7 +166,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.PaginatedItemsResponse"
+                            "$ref": "#/definitions/types.PaginatedUsersResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -255,14 +182,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/users/{uuid}/roles": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new item",
+                "description": "Get the roles assigned to a user and the permissions they grant. Requires the roles:assign permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -270,31 +199,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "admin"
                 ],
-                "summary": "Add a new item",
+                "summary": "Get user roles",
                 "parameters": [
                     {
-                        "description": "Item details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/types.AddItemRequest"
-                        }
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item added successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.ItemsResponse"
+                            "$ref": "#/definitions/types.UserRolesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Missing mandatory fields",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/types.MissingFieldResponse"
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -304,16 +237,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/items/{uuid}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get an item by UUID",
+                "description": "Give a user a role. The user's new permissions apply to their next request.\nRequires the roles:assign permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -321,23 +252,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "admin"
                 ],
-                "summary": "Get item by UUID",
+                "summary": "Assign role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Item UUID",
+                        "description": "User UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Role to assign",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AssignRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.ItemsResponse"
+                            "$ref": "#/definitions/types.UserRolesResponse"
                         }
                     },
                     "400": {
@@ -346,6 +286,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -353,14 +305,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{uuid}/roles/{role}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an item by UUID",
+                "description": "Take a role away from a user. The user loses its permissions on their next request.\nThe last admin can't lose the admin role. Requires the roles:assign permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -368,23 +322,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "admin"
                 ],
-                "summary": "Delete item",
+                "summary": "Remove role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Board UUID",
+                        "description": "User UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Board deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.ItemDeletedResponse"
+                            "$ref": "#/definitions/types.UserRolesResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Removing the last admin",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -394,14 +373,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update item details by UUID",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Log in to user account using email or username.\nUsers who haven't verified their email can't log in if the instance requires verification.\nUsers with two-factor authentication get an MFA token to exchange at /auth/login/mfa instead of tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -409,40 +385,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "auth"
                 ],
-                "summary": "Update item details",
+                "summary": "Log in",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Item UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Item details to update",
+                        "description": "Login details",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UpdateItemRequest"
+                            "$ref": "#/definitions/types.LoginUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successful login",
                         "schema": {
-                            "$ref": "#/definitions/types.ItemsResponse"
+                            "$ref": "#/definitions/types.TokenResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Second factor required",
+                        "schema": {
+                            "$ref": "#/definitions/types.MfaChallengeResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email address not verified",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.UserNotFoundResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -452,14 +439,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/list_items": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all list items in a paginated list",
+        "/auth/login/mfa": {
+            "post": {
+                "description": "Exchange the MFA token from /auth/login and a code from the authenticator app or a recovery code\nfor an access token and refresh token. Each recovery code can only be used once.\nAfter too many wrong codes the MFA token stops working and the user has to log in again",
                 "consumes": [
                     "application/json"
                 ],
@@ -467,28 +449,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "list_items"
+                    "auth"
                 ],
-                "summary": "Get all list items",
+                "summary": "Log in with a second factor",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "MFA token and code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.MfaLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successful login",
                         "schema": {
-                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                            "$ref": "#/definitions/types.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code, or invalid or expired MFA token",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -500,14 +491,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/lists/{uuid}/items": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all items in a list as a paginated list",
+        "/auth/logout": {
+            "post": {
+                "description": "Log out of the app",
                 "consumes": [
                     "application/json"
                 ],
@@ -515,35 +501,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lists"
+                    "auth"
                 ],
-                "summary": "Get all items in a list",
+                "summary": "Log out",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "List UUID",
-                        "name": "uuid",
-                        "in": "path",
+                        "description": "Refresh token",
+                        "name": "Refresh-Token",
+                        "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Logout successful",
                         "schema": {
-                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                            "$ref": "#/definitions/types.LogoutSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/types.RefreshTokenMissingResponse"
                         }
                     },
                     "500": {
@@ -555,14 +535,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/search": {
-            "get": {
+        "/auth/mfa/recovery-codes": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Search for movies on TMDB",
+                "description": "Replace the authenticated user's recovery codes. Earlier codes stop working.\nA code from the authenticator app or a recovery code is needed",
                 "consumes": [
                     "application/json"
                 ],
@@ -570,42 +550,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "auth"
                 ],
-                "summary": "Search for movies on TMDB",
+                "summary": "Regenerate recovery codes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "List UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Code from the authenticator app or a recovery code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.TotpCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                            "$ref": "#/definitions/types.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication isn't enabled",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -617,40 +592,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/statuses": {
-            "get": {
+        "/auth/mfa/totp": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetch all statuses as a paginated list",
+                "description": "Create a TOTP secret for the authenticated user to add to an authenticator app.\nTwo-factor authentication isn't turned on until a code is confirmed at /auth/mfa/totp/confirm",
                 "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "statuses"
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Fetch all statuses",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                "tags": [
+                    "auth"
                 ],
+                "summary": "Start TOTP enrollment",
                 "responses": {
                     "200": {
-                        "description": "Statuses",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.PaginatedStatusesResponse"
+                            "$ref": "#/definitions/types.TotpEnrollmentResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -661,13 +631,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new status",
+                "description": "Turn off two-factor authentication for the authenticated user and delete their recovery codes.\nThe user's password and a code from the authenticator app or a recovery code are needed",
                 "consumes": [
                     "application/json"
                 ],
@@ -675,31 +645,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "statuses"
+                    "auth"
                 ],
-                "summary": "Add a new status",
+                "summary": "Disable TOTP",
                 "parameters": [
                     {
-                        "description": "Status details",
+                        "description": "Password and code",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.AddStatusRequest"
+                            "$ref": "#/definitions/types.DisableTotpRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Status added successfully",
+                        "description": "Two-factor authentication disabled",
                         "schema": {
-                            "$ref": "#/definitions/types.StatusesResponse"
+                            "$ref": "#/definitions/types.TotpDisabledResponse"
                         }
                     },
                     "400": {
-                        "description": "Missing mandatory fields",
+                        "description": "Incorrect password or invalid code",
                         "schema": {
-                            "$ref": "#/definitions/types.MissingFieldResponse"
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication isn't enabled",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -711,14 +687,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
+        "/auth/mfa/totp/confirm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all users in a paginated list",
+                "description": "Turn on two-factor authentication by entering a code from the authenticator app.\nThe response holds the user's recovery codes, which are only shown once",
                 "consumes": [
                     "application/json"
                 ],
@@ -726,28 +702,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "Get all users",
+                "summary": "Confirm TOTP enrollment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Code from the authenticator app",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.TotpCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.PaginatedUsersResponse"
+                            "$ref": "#/definitions/types.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already enabled, or enrollment not started",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -759,14 +744,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{uuid}": {
-            "get": {
+        "/auth/password": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a user by UUID",
+                "description": "Change the authenticated user's password. The current password must be given.\nEvery other session is logged out, and the current session gets new tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -774,27 +759,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "Get user by UUID",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Current and new password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ChangePasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "New access and refresh tokens for the current session",
                         "schema": {
-                            "$ref": "#/definitions/types.UserResponse"
+                            "$ref": "#/definitions/types.TokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Incorrect current password",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
@@ -806,14 +799,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Email a link to reset the password of the account with the given email.\nThe response is the same whether or not the email belongs to an account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Forgot password",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "Account email",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ForgotPasswordRequest"
+                        }
                     }
                 ],
-                "description": "Delete a user by UUID",
+                "responses": {
+                    "202": {
+                        "description": "Reset link sent if the email belongs to an account",
+                        "schema": {
+                            "$ref": "#/definitions/types.PasswordResetRequestedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Set a new password with the token from a password reset link.\nEach token can only be used once, and every session is logged out",
                 "consumes": [
                     "application/json"
                 ],
@@ -821,23 +857,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "Delete user",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Reset token and new password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ResetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Password reset",
                         "schema": {
-                            "$ref": "#/definitions/types.UserDeletedResponse"
+                            "$ref": "#/definitions/types.PasswordResetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, used, or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
                     "500": {
@@ -847,14 +891,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update user details by UUID",
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and refresh token.\nEach refresh token can only be used once. Reusing one logs out the session it belongs to",
                 "consumes": [
                     "application/json"
                 ],
@@ -862,38 +903,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "Update user details",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UUID",
-                        "name": "uuid",
-                        "in": "path",
+                        "description": "Refresh token",
+                        "name": "Refresh-Token",
+                        "in": "header",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/types.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/types.RefreshTokenMissingResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired, or reused refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account. A link to verify the email address is sent to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user account",
+                "parameters": [
                     {
-                        "description": "User details to update",
+                        "description": "User details",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/types.UpdateUserRequest"
+                            "$ref": "#/definitions/types.RegisterUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "User registered successfully",
                         "schema": {
                             "$ref": "#/definitions/types.UserResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Missing mandatory fields",
                         "schema": {
-                            "$ref": "#/definitions/types.ErrorResponse"
+                            "$ref": "#/definitions/types.MissingFieldResponse"
                         }
                     },
                     "500": {
@@ -904,68 +988,5137 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "types.AddItemRequest": {
-            "description": "a request body for adding a new item",
-            "type": "object",
-            "properties": {
-                "title": {
-                    "type": "string",
-                    "example": "Item title"
-                }
-            }
         },
-        "types.AddStatusRequest": {
-            "description": "A request body for adding a new status",
-            "type": "object",
-            "required": [
-                "label"
-            ],
-            "properties": {
-                "label": {
-                    "type": "string",
-                    "example": "test"
+        "/auth/verify-email": {
+            "post": {
+                "description": "Verify an email address with the token from the link sent on registration or when the email changes.\nEach token can only be used once, and only for the address it was sent to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email verified",
+                        "schema": {
+                            "$ref": "#/definitions/types.EmailVerifiedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, used, or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "types.AlreadyLoggedOutResponse": {
-            "description": "user already logged out",
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "already logged out"
+        "/auth/verify-email/resend": {
+            "post": {
+                "description": "Email a new verification link to the account with the given email if it isn't verified yet.\nEarlier links stop working. The response is the same whether or not a link was sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Verification link sent if the email belongs to an unverified account",
+                        "schema": {
+                            "$ref": "#/definitions/types.VerificationSentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "types.ErrorResponse": {
-            "description": "an unknown error",
+        "/imports/imdb": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload ratings.csv and/or WATCHLIST.csv from IMDb's data export. Titles are matched to TMDB movies by\nIMDb ID and imported as items. Ratings out of ten become reviews rated out of five stars, and the\nwatchlist is added to the given list and status, or to the first column of the user's default list.\nThe import runs in the background. Its progress can be followed with GET /imports/jobs/{uuid}",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import from IMDb",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "IMDb ratings.csv",
+                        "name": "ratings",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "IMDb WATCHLIST.csv",
+                        "name": "watchlist",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List to add the watchlist to",
+                        "name": "list_uuid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status to add the watchlist to",
+                        "name": "status_uuid",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Import job",
+                        "schema": {
+                            "$ref": "#/definitions/types.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Upload too large",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the authenticated user's background imports, most recent first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "List import jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import jobs",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedImportJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/jobs/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the state and progress of one of the authenticated user's background imports, with the rows\nthat couldn't be imported",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Get an import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import job",
+                        "schema": {
+                            "$ref": "#/definitions/types.ImportJobResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/jobs/{uuid}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a failed import to carry on from the first row that wasn't processed. Rows that were already\nimported are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Resume an import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Import job",
+                        "schema": {
+                            "$ref": "#/definitions/types.ImportJobResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The import job hasn't failed",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/letterboxd": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import the zip archive from Letterboxd's data export. Films are matched to TMDB by title and year.\nDiary entries and watched films become watches, reviews and ratings become reviews, and the\nwatchlist is added to the first column of the user's default list. Rows that were already imported\nare skipped. Set dry_run to match the films and report the result without importing anything",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import from Letterboxd",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Letterboxd export archive",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Match films without importing them",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import report",
+                        "schema": {
+                            "$ref": "#/definitions/types.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Upload or unzipped export too large",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all items in a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Get all items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedItemsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Add a new item",
+                "parameters": [
+                    {
+                        "description": "Item details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AddItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ItemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/import/tmdb/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a movie using the details held by TMDB. If the movie has already been imported the existing\nitem is returned with a 200 status instead of adding it again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Import a movie from TMDB",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie already imported",
+                        "schema": {
+                            "$ref": "#/definitions/types.ItemDetailsResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Movie imported successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ItemDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found on TMDB",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an item by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Get item by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ItemDetailsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an item by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Delete item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Board UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Board deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ItemDeletedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update item details by UUID. Requires the items:edit permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Update item details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item details to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ItemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/{uuid}/reviews": {
+            "get": {
+                "description": "Fetch the reviews of an item as a paginated list, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Fetch reviews for an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedReviewsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Review an item with content, a rating out of five stars in half-star steps, or both.\nEach user can review an item once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Review an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AddReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Review added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/list_items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all list items in a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list_items"
+                ],
+                "summary": "Get all list items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/list_items/{uuid}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from a list. Only the owner of the list can remove items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list_items"
+                ],
+                "summary": "Remove a list item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List item UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The former neighbours of the removed item",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListItemPlacementResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a list item to a new status and position. Only the owner of the list can move items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list_items"
+                ],
+                "summary": "Move a list item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List item UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status and position",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.MoveListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item moved and its new neighbours",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListItemPlacementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status column at its WIP limit",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch all lists owned by the authenticated user as a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Fetch all lists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedListsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new list owned by the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Add a new list",
+                "parameters": [
+                    {
+                        "description": "List details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AddListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "List added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all items in a list as a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Get all items in a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a list by UUID. Only the owner of the list can delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Delete list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListDeletedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update list details by UUID. Only the owner of the list can update it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Update list details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List details to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all list items in a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list_items"
+                ],
+                "summary": "Get all list items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}/board": {
+            "get": {
+                "description": "Get a list as a kanban board. Each status attached to the list is returned as a column\ncontaining its cards ordered by position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Get list board",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.BoardResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}/details": {
+            "get": {
+                "description": "Get a list by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Get list by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}/items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all items in a list as a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Get all items in a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedListItemsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an item to a status column of a list. Only the owner of the list can add items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Add an item to a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List item details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AddListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Item added and its neighbours",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListItemPlacementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item already in list or status column at its WIP limit",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}/statuses": {
+            "get": {
+                "description": "Get the statuses attached to a list in column order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Get list statuses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListStatusesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the column order of a list. The request must contain every status attached to the list exactly once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Reorder list statuses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status UUIDs in column order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.ReorderListStatusesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListStatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a status to a list as its last column. Only the owner of the list and status can attach it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Attach a status to a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status to attach",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AddListStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListStatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status already attached to the list",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{uuid}/statuses/{status_uuid}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Detach a status from a list. The status column must not contain any list items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Detach a status from a list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status UUID",
+                        "name": "status_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListStatusesResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status still has items on the list",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the settings of a status column on a list, such as its WIP limit.\nA null wip_limit removes the limit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Update a list status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status UUID",
+                        "name": "status_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Column settings",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateListStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ListStatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{uuid}": {
+            "get": {
+                "description": "Get a review by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get review by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a review by UUID. Only the author of the review or users with the reviews:moderate\npermission can delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewDeletedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the content and rating of a review. Only the author of the review can update it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Update review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search for movies on TMDB. Results are cached, and a stale copy is returned when TMDB can't be reached.\nThe X-Cache response header is HIT, MISS or STALE depending on where the results came from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search for movies on TMDB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result language, such as en-US",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TMDB search results",
+                        "schema": {
+                            "type": "object"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or STALE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached and nothing was cached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/movie/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the details of a movie on TMDB without importing it. Details are cached, and a stale copy is\nreturned when TMDB can't be reached. The X-Cache response header is HIT, MISS or STALE depending on\nwhere the details came from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get movie details from TMDB",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result language, such as en-US",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TMDB movie details",
+                        "schema": {
+                            "type": "object"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or STALE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found on TMDB",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached and nothing was cached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/movie/{id}/credits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the cast and crew of a movie on TMDB. Credits are cached, and a stale copy is returned when\nTMDB can't be reached. The X-Cache response header is HIT, MISS or STALE depending on where the\ncredits came from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get movie credits from TMDB",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result language, such as en-US",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TMDB movie credits",
+                        "schema": {
+                            "type": "object"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or STALE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found on TMDB",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached and nothing was cached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/movie/{id}/images": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the posters, backdrops and logos of a movie on TMDB. Images are cached, and a stale copy is returned when\nTMDB can't be reached. The X-Cache response header is HIT, MISS or STALE depending on where the\nimages came from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Get movie images from TMDB",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "TMDB movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result language, such as en-US",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TMDB movie images",
+                        "schema": {
+                            "type": "object"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or STALE"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found on TMDB",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "TMDB could not be reached and nothing was cached",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Log out a session by ID. Access tokens issued to the session stop working straight away.\nUsers can only revoke their own sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Revoke session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.SessionRevokedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/statuses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch all statuses as a paginated list",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statuses"
+                ],
+                "summary": "Fetch all statuses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statuses",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedStatusesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statuses"
+                ],
+                "summary": "Add a new status",
+                "parameters": [
+                    {
+                        "description": "Status details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AddStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.StatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing mandatory fields",
+                        "schema": {
+                            "$ref": "#/definitions/types.MissingFieldResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/statuses/{uuid}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a status by UUID. Every list item using the status is moved to the end of the target status\ncolumn on its list in a single transaction. Only the owner of both statuses can delete a status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statuses"
+                ],
+                "summary": "Delete a status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the status that receives the list items",
+                        "name": "target_status_uuid",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.StatusDeletedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a status by UUID. Only the owner of the status can rename it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statuses"
+                ],
+                "summary": "Rename a status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status label",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.StatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A status with this label already exists",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a user by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user by UUID. Users can delete their own account, and users with the users:delete permission can delete anyone's.\nThe last admin can't be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.UserDeletedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is the last admin",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user details by UUID. Users can update their own details, and users with the users:edit permission can update anyone's",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User details to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a full backup of the user's profile, items, statuses, lists with their columns and cards,\nreviews and watches. The file can be imported again with POST /users/{uuid}/import. Users can only\nexport their own data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export account data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account export",
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountExport"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/export/letterboxd": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a zip archive with the same files and columns as a Letterboxd export: watchlist.csv from\nthe items in one status column, diary.csv from their watches, and ratings.csv and reviews.csv from their\nreviews. The watchlist is the first status of the user's default list, where imported watchlists are\nadded, unless list_uuid and status_uuid are given. The archive can be imported into Letterboxd or back\ninto eigakanban. Users can only export their own data",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export to Letterboxd",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "List to export as the watchlist",
+                        "name": "list_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status to export as the watchlist",
+                        "name": "status_uuid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Letterboxd export archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recreate the contents of an account export in the user's account. Boards keep their columns and card\npositions. Items are imported from TMDB by their TMDB ID and statuses are matched by label. Items\nwithout a TMDB ID that don't already exist are listed in the report, and their cards, reviews and\nwatches are skipped. Records whose UUID belongs to someone else are given a new UUID, which is listed\nin the report. Lists, reviews and watches the user already has are skipped. Nothing is imported if any\nrecord is invalid. Users can only import into their own account.\nExports larger than 16 MB are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Import account data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account export",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountExport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import report",
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Export too large",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the reviews written by a user as a paginated list, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Fetch reviews by a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedReviewsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the devices a user is logged in on, most recently used first.\nThe session the request was made from is marked as current. Users can only view their own sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.SessionsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/sessions/others": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Log out of every session except the one the request was made from.\nAccess tokens issued to those sessions stop working straight away",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Log out other sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.RevokedSessionsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{uuid}/watches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the diary entries of a user as a paginated list, most recently watched first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Fetch a user's diary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.PaginatedWatchesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/watches": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a diary entry for a viewing of an item. Optionally move the item's card on one of the\nuser's lists to a status, such as \"watched\", at the same time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Log a watch",
+                "parameters": [
+                    {
+                        "description": "Watch details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.LogWatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Watch logged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.WatchLoggedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/watches/{uuid}": {
+            "get": {
+                "description": "Get a diary entry by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Get watch by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watch UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WatchResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a diary entry by UUID. Only the user who logged the watch can delete it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Delete watch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watch UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watch deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/types.WatchDeletedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the details of a diary entry. Only the user who logged the watch can update it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watches"
+                ],
+                "summary": "Update watch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watch UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watch details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.UpdateWatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "types.AccountExport": {
+            "description": "A versioned backup of a user's profile, items, statuses, boards, reviews and watches. Every record keeps its UUID so that references between records survive an import. Dates are RFC 3339 timestamps, except release_date and watched_date which are YYYY-MM-DD",
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "exported_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportItem"
+                    }
+                },
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportList"
+                    }
+                },
+                "profile": {
+                    "$ref": "#/definitions/types.AccountExportProfile"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportReview"
+                    }
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportStatus"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "watches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportWatch"
+                    }
+                }
+            }
+        },
+        "types.AccountExportCard": {
+            "description": "a card on a list. status_uuid is null when the card has no status",
+            "type": "object",
+            "properties": {
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "rank": {
+                    "type": "string",
+                    "example": "i"
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.AccountExportItem": {
+            "description": "an item referenced by the export. On import, items with a tmdb_id are imported from TMDB and the other fields are ignored. Items without one are only matched to an existing item with the same uuid",
+            "type": "object",
+            "properties": {
+                "backdrop_path": {
+                    "type": "string",
+                    "example": "/hZkgoQYus5vegHoetLkCJzb17zJ.jpg"
+                },
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Drama"
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string",
+                    "example": "tt0137523"
+                },
+                "original_language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "overview": {
+                    "type": "string",
+                    "example": "A ticking-time-bomb insomniac..."
+                },
+                "poster_path": {
+                    "type": "string",
+                    "example": "/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "1999-10-15"
+                },
+                "runtime": {
+                    "type": "integer",
+                    "example": 139
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fight Club"
+                },
+                "tmdb_id": {
+                    "type": "integer",
+                    "example": 550
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.AccountExportList": {
+            "description": "a list with its status columns and cards in rank order",
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportCard"
+                    }
+                },
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Watchlist"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AccountExportListStatus"
+                    }
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.AccountExportListStatus": {
+            "description": "a status column on a list. wip_limit is null when the column has no WIP limit",
+            "type": "object",
+            "properties": {
+                "rank": {
+                    "type": "string",
+                    "example": "i"
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "wip_limit": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.AccountExportProfile": {
+            "description": "the exported user's profile. Only full_name and bio are restored on import",
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string",
+                    "example": "I watch a lot of films"
+                },
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "eiga@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Eiga Fan"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "eiga_fan"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.AccountExportReview": {
+            "description": "a review written by the user",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "A masterpiece"
+                },
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "updated_date": {
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.AccountExportStatus": {
+            "description": "a status owned by the user. Statuses are matched to existing statuses by label on import",
+            "type": "object",
+            "properties": {
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "backlog"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.AccountExportWatch": {
+            "description": "a diary entry logged by the user",
+            "type": "object",
+            "properties": {
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "35mm"
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Prince Charles Cinema"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "review_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "rewatch": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_date": {
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "watched_date": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                }
+            }
+        },
+        "types.AccountImportReport": {
+            "description": "a summary of an account import. Records whose UUID was already taken by someone else are created with a new UUID and listed in remapped. Items that couldn't be matched are listed in unmatched_items",
+            "type": "object",
+            "properties": {
+                "cards_created": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "items_created": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "lists_created": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "remapped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.RemappedUUID"
+                    }
+                },
+                "reviews_created": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "statuses_created": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "unmatched_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.UnmatchedItem"
+                    }
+                },
+                "watches_created": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "types.AddItemRequest": {
+            "description": "a request body for adding a new item",
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "Item title"
+                }
+            }
+        },
+        "types.AddListItemRequest": {
+            "description": "a request body for adding an item to a list. position is the zero-based index in the status column. the item is added to the end of the status column if no position is provided. set ignore_wip_limit to add the item even if the status column is at its WIP limit",
+            "type": "object",
+            "required": [
+                "item_uuid",
+                "status_uuid"
+            ],
+            "properties": {
+                "ignore_wip_limit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000003"
+                }
+            }
+        },
+        "types.AddListRequest": {
+            "description": "A request body for adding a new list",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Watchlist"
+                }
+            }
+        },
+        "types.AddListStatusRequest": {
+            "description": "a request body for attaching a status to a list. The status is added as the last column",
+            "type": "object",
+            "required": [
+                "status_uuid"
+            ],
+            "properties": {
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                }
+            }
+        },
+        "types.AddReviewRequest": {
+            "description": "a request body for reviewing an item. The rating is out of five stars in half-star steps. A review needs content, a rating, or both",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "The first rule is you do not talk about it."
+                },
+                "rating": {
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0.5,
+                    "example": 4.5
+                }
+            }
+        },
+        "types.AddStatusRequest": {
+            "description": "A request body for adding a new status",
+            "type": "object",
+            "required": [
+                "label"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "test"
+                }
+            }
+        },
+        "types.AlreadyLoggedOutResponse": {
+            "description": "user already logged out",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "already logged out"
+                }
+            }
+        },
+        "types.AssignRoleRequest": {
+            "description": "a request body for assigning a role to a user",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "moderator"
+                }
+            }
+        },
+        "types.BoardCardResponse": {
+            "description": "a list item enriched with item details",
+            "type": "object",
+            "properties": {
+                "added_date": {
+                    "type": "string",
+                    "example": "2025-02-15T11:59:01Z"
+                },
+                "item_created_date": {
+                    "type": "string",
+                    "example": "2025-02-15T11:59:01Z"
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "poster_path": {
+                    "type": "string",
+                    "example": "/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"
+                },
+                "rank": {
+                    "type": "string",
+                    "example": "0001"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "1999-10-15"
+                },
+                "runtime": {
+                    "type": "integer",
+                    "example": 139
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Item title"
+                },
+                "tmdb_id": {
+                    "type": "integer",
+                    "example": 550
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.BoardColumnResponse": {
+            "description": "a status column containing cards ordered by position. wip_limit is null when the column has no WIP limit",
+            "type": "object",
+            "properties": {
+                "at_limit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "card_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BoardCardResponse"
+                    }
+                },
+                "label": {
+                    "type": "string",
+                    "example": "backlog"
+                },
+                "over_limit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "wip_limit": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.BoardResponse": {
+            "description": "a list with its statuses as columns and its items as cards",
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BoardColumnResponse"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Watchlist"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.ChangePasswordRequest": {
+            "description": "request body for changing a password. The current password must be given",
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "correct horse battery staple"
+                }
+            }
+        },
+        "types.DisableTotpRequest": {
+            "description": "request body for turning off two-factor authentication. code is a code from the authenticator app or a recovery code",
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                }
+            }
+        },
+        "types.EmailVerifiedResponse": {
+            "description": "email address verified",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "email verified"
+                }
+            }
+        },
+        "types.ErrorResponse": {
+            "description": "an unknown error",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "internal server error"
+                }
+            }
+        },
+        "types.ForgotPasswordRequest": {
+            "description": "request body for asking for a password reset link to be emailed",
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "test@test.com"
+                }
+            }
+        },
+        "types.ImportJobResponse": {
+            "description": "an import that runs in the background. state is pending, running, completed or failed. A failed job keeps the rows it has already imported and can be resumed. The report counts the rows processed so far, and unmatched rows are only included when a single job is fetched",
+            "type": "object",
+            "properties": {
+                "created_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to find movie"
+                },
+                "finished_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:05:00Z"
+                },
+                "list_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "processed": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "report": {
+                    "$ref": "#/definitions/types.ImportReport"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "imdb"
+                },
+                "started_date": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:01Z"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.ImportReport": {
+            "description": "a summary of an import. In a dry run nothing is written and the created counts are the number of matched rows that would be imported, before duplicates are skipped",
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "matched": {
+                    "type": "integer",
+                    "example": 117
+                },
+                "ratings_created": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "reviews_created": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "unmatched": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.UnmatchedRow"
+                    }
+                },
+                "watches_created": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "watchlist_added": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.ItemDeletedResponse": {
+            "description": "A success message confirming the item was deleted",
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "string",
+                    "example": "Item deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                }
+            }
+        },
+        "types.ItemDetailsResponse": {
+            "description": "an item with the metadata imported from TMDB. Metadata fields are null for items added by title. average_rating is null until the item has been rated",
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "backdrop_path": {
+                    "type": "string",
+                    "example": "/hZkgoQYus5vegHoetLkCJzb17zJ.jpg"
+                },
+                "created_date": {
+                    "type": "string",
+                    "example": "2025-02-15T11:59:01Z"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Drama",
+                        "Thriller"
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string",
+                    "example": "tt0137523"
+                },
+                "metadata_refreshed_at": {
+                    "type": "string",
+                    "example": "2025-02-15T11:59:01Z"
+                },
+                "original_language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "overview": {
+                    "type": "string",
+                    "example": "A ticking-time-bomb insomniac and a slippery soap salesman channel primal male aggression into a shocking new form of therapy."
+                },
+                "poster_path": {
+                    "type": "string",
+                    "example": "/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"
+                },
+                "rating_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "1999-10-15"
+                },
+                "review_count": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "runtime": {
+                    "type": "integer",
+                    "example": 139
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fight Club"
+                },
+                "tmdb_id": {
+                    "type": "integer",
+                    "example": 550
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.ItemsResponse": {
+            "type": "object",
+            "properties": {
+                "poster_path": {
+                    "type": "string",
+                    "example": "/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "1999-10-15"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Item title"
+                },
+                "tmdb_id": {
+                    "type": "integer",
+                    "example": 550
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.ListDeletedResponse": {
+            "description": "A success message confirming the list was deleted",
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "string",
+                    "example": "list deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                }
+            }
+        },
+        "types.ListItemPlacementResponse": {
+            "description": "the affected list item and its neighbours in the status column",
+            "type": "object",
+            "properties": {
+                "list_item": {
+                    "$ref": "#/definitions/types.ListItemsResponse"
+                },
+                "next": {
+                    "$ref": "#/definitions/types.ListItemsResponse"
+                },
+                "previous": {
+                    "$ref": "#/definitions/types.ListItemsResponse"
+                }
+            }
+        },
+        "types.ListItemsResponse": {
+            "type": "object",
+            "properties": {
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "list_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "rank": {
+                    "type": "string",
+                    "example": "0001"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Backlog"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.ListStatusResponse": {
+            "description": "a status column on a list",
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "backlog"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "wip_limit": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.ListStatusesResponse": {
+            "description": "the status columns of a list in board order",
+            "type": "object",
+            "properties": {
+                "list_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ListStatusResponse"
+                    }
+                }
+            }
+        },
+        "types.ListsResponse": {
+            "description": "list details",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Watchlist"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.LogWatchRequest": {
+            "description": "a request body for logging a viewing of an item. The rating is out of five stars in half-star steps. review_uuid must be the authenticated user's review of the same item. set list_uuid and status_uuid to move the item's card on that list to the status, adding it to the list if needed. set ignore_wip_limit to move the card even if the status column is at its WIP limit",
+            "type": "object",
+            "required": [
+                "item_uuid",
+                "watched_date"
+            ],
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "example": "35mm"
+                },
+                "ignore_wip_limit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "list_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000004"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Prince Charles Cinema"
+                },
+                "rating": {
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0.5,
+                    "example": 4.5
+                },
+                "review_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000003"
+                },
+                "rewatch": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000005"
+                },
+                "watched_date": {
+                    "type": "string",
+                    "example": "2025-02-14"
+                }
+            }
+        },
+        "types.LoginUserRequest": {
+            "description": "request body for a login request. either email or username must be provided. device_name optionally names the session so it can be recognised in the list of sessions",
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "device_name": {
+                    "type": "string",
+                    "example": "Living room laptop"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "test@test.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "test"
+                }
+            }
+        },
+        "types.LogoutSuccessResponse": {
+            "description": "user logged out successfully",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "logged out successfully"
+                }
+            }
+        },
+        "types.MfaChallengeResponse": {
+            "description": "a short-lived token to exchange for access and refresh tokens along with a code from the user's authenticator app or one of their recovery codes",
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2025-02-15 11:04:01.837871 +0100 CET m=+303.614509085"
+                },
+                "mfa_required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                }
+            }
+        },
+        "types.MfaLoginRequest": {
+            "description": "request body for finishing a login. code is a code from the authenticator app or a recovery code",
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                }
+            }
+        },
+        "types.MissingFieldResponse": {
+            "description": "an example of a missing field response an example of a missing field response",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "properties": {
+                        "username": {
+                            "type": "string",
+                            "example": "This field is required"
+                        }
+                    }
+                }
+            }
+        },
+        "types.MoveListItemRequest": {
+            "description": "a request body for moving a list item to a new status and position. position is the zero-based index in the status column. set ignore_wip_limit to move the item even if the new status column is at its WIP limit",
+            "type": "object",
+            "required": [
+                "position",
+                "status_uuid"
+            ],
+            "properties": {
+                "ignore_wip_limit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "status_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000003"
+                }
+            }
+        },
+        "types.PaginatedImportJobsResponse": {
+            "description": "a paginated list of import jobs, most recent first",
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ImportJobResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                }
+            }
+        },
+        "types.PaginatedItemsResponse": {
+            "description": "a response containing a list of items and a pagination object",
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ItemsResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                }
+            }
+        },
+        "types.PaginatedListItemsResponse": {
+            "type": "object",
+            "properties": {
+                "list_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ListItemsResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                }
+            }
+        },
+        "types.PaginatedListsResponse": {
+            "description": "a paginated list of lists",
+            "type": "object",
+            "properties": {
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ListsResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                }
+            }
+        },
+        "types.PaginatedReviewsResponse": {
+            "description": "a paginated list of reviews, newest first",
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ReviewResponse"
+                    }
+                }
+            }
+        },
+        "types.PaginatedStatusesResponse": {
+            "description": "a paginated list of statuses",
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.StatusesResponse"
+                    }
+                }
+            }
+        },
+        "types.PaginatedUsersResponse": {
+            "description": "a response containing a list of users and a pagination object",
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.UserResponse"
+                    }
+                }
+            }
+        },
+        "types.PaginatedWatchesResponse": {
+            "description": "a paginated list of diary entries, most recently watched first",
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/types.Pagination"
+                },
+                "watches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.WatchResponse"
+                    }
+                }
+            }
+        },
+        "types.Pagination": {
+            "description": "pagination information",
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "types.PasswordResetRequestedResponse": {
+            "description": "password reset link sent if the email belongs to a user",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "if the email belongs to an account, a reset link has been sent to it"
+                }
+            }
+        },
+        "types.PasswordResetResponse": {
+            "description": "password reset successfully",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "password reset, please log in again"
+                }
+            }
+        },
+        "types.RecoveryCodesResponse": {
+            "description": "one-time codes for logging in without the authenticator app. Earlier codes stop working",
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mbfhj-b724h",
+                        "q7rte-9xk2m"
+                    ]
+                }
+            }
+        },
+        "types.RefreshTokenMissingResponse": {
+            "description": "refresh token missing",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "refresh token missing"
+                }
+            }
+        },
+        "types.RegisterUserRequest": {
+            "description": "A request body for registering a new user",
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "test@test.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "test"
+                }
+            }
+        },
+        "types.RemappedUUID": {
+            "description": "a record that was imported under a new UUID",
+            "type": "object",
+            "properties": {
+                "new_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "old_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "list"
+                }
+            }
+        },
+        "types.ReorderListStatusesRequest": {
+            "description": "a request body listing every status attached to a list in the desired column order",
+            "type": "object",
+            "required": [
+                "status_uuids"
+            ],
+            "properties": {
+                "status_uuids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.ResendVerificationRequest": {
+            "description": "request body for asking for a new verification link to be emailed",
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "test@test.com"
+                }
+            }
+        },
+        "types.ResetPasswordRequest": {
+            "description": "request body for setting a new password with the token from a reset link",
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "example": "correct horse battery staple"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                }
+            }
+        },
+        "types.ReviewDeletedResponse": {
+            "description": "A success message confirming the review was deleted",
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "string",
+                    "example": "review deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                }
+            }
+        },
+        "types.ReviewResponse": {
+            "description": "a review of an item. rating is null for reviews without a star rating",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "The first rule is you do not talk about it."
+                },
+                "created_date": {
+                    "type": "string",
+                    "example": "2025-02-15T11:59:01Z"
+                },
+                "item_title": {
+                    "type": "string",
+                    "example": "Fight Club"
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "updated_date": {
+                    "type": "string",
+                    "example": "2025-02-16T11:59:01Z"
+                },
+                "user_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "tyler"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.RevokedSessionsResponse": {
+            "description": "the number of sessions that were logged out",
+            "type": "object",
+            "properties": {
+                "revoked": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "types.RoleResponse": {
+            "description": "a named role and the permissions it grants",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Keeps the item catalogue and reviews tidy"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "moderator"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "items:edit",
+                        "items:refresh",
+                        "reviews:moderate"
+                    ]
+                }
+            }
+        },
+        "types.RolesResponse": {
+            "description": "the roles that can be assigned to users",
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.RoleResponse"
+                    }
+                }
+            }
+        },
+        "types.SessionResponse": {
+            "description": "a device the user is logged in on",
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "device_name": {
+                    "type": "string",
+                    "example": "Living room laptop"
+                },
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2025-02-22T11:59:01Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "192.0.2.1"
+                },
+                "last_used_date": {
+                    "type": "string",
+                    "example": "2025-02-15T11:59:01Z"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (X11; Linux x86_64; rv:135.0) Gecko/20100101 Firefox/135.0"
+                }
+            }
+        },
+        "types.SessionRevokedResponse": {
+            "description": "A success message confirming the session was logged out",
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "string",
+                    "example": "session revoked: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                }
+            }
+        },
+        "types.SessionsResponse": {
+            "description": "the devices a user is logged in on",
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SessionResponse"
+                    }
+                }
+            }
+        },
+        "types.StatusDeletedResponse": {
+            "description": "A success message confirming the status was deleted",
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "string",
+                    "example": "status deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                }
+            }
+        },
+        "types.StatusesResponse": {
+            "description": "status details",
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "backlog"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.TokenResponse": {
+            "description": "a response containing a JWT for authentication and a refresh token",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2025-02-15 11:59:01.837871 +0100 CET m=+3603.614509085"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "types.TotpCodeRequest": {
+            "description": "request body containing a code from an authenticator app, or a recovery code where accepted",
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "types.TotpDisabledResponse": {
+            "description": "A success message confirming two-factor authentication was turned off",
             "type": "object",
             "properties": {
-                "error": {
+                "message": {
                     "type": "string",
-                    "example": "internal server error"
+                    "example": "two-factor authentication disabled"
                 }
             }
         },
-        "types.ItemDeletedResponse": {
-            "description": "A success message confirming the item was deleted",
+        "types.TotpEnrollmentResponse": {
+            "description": "a TOTP secret to add to an authenticator app, as text, as an otpauth URI, and as a QR code. qr_code is a base64 encoded PNG",
             "type": "object",
             "properties": {
-                "success": {
+                "otpauth_uri": {
                     "type": "string",
-                    "example": "Item deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                    "example": "otpauth://totp/eigakanban:test?algorithm=SHA1\u0026digits=6\u0026issuer=eigakanban\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                },
+                "qr_code": {
+                    "type": "string",
+                    "format": "byte"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "types.ItemsResponse": {
+        "types.UnmatchedItem": {
+            "description": "an exported item that couldn't be matched to an item. Cards, reviews and watches of the item are skipped",
             "type": "object",
             "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "item has no TMDB ID"
+                },
                 "title": {
                     "type": "string",
-                    "example": "Item title"
+                    "example": "Fight Club"
                 },
                 "uuid": {
                     "type": "string",
@@ -973,286 +6126,325 @@ const docTemplate = `{
                 }
             }
         },
-        "types.ListItemsResponse": {
+        "types.UnmatchedRow": {
+            "description": "a row of an import file that couldn't be matched to an item or imported",
             "type": "object",
             "properties": {
-                "item_uuid": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000002"
-                },
-                "list_uuid": {
+                "file": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000001"
+                    "example": "diary.csv"
                 },
-                "position": {
+                "line": {
                     "type": "integer",
-                    "example": 0
+                    "example": 14
                 },
-                "status": {
+                "name": {
                     "type": "string",
-                    "example": "Backlog"
+                    "example": "Fight Club"
                 },
-                "uuid": {
+                "reason": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "no match found"
+                },
+                "year": {
+                    "type": "string",
+                    "example": "1999"
                 }
             }
         },
-        "types.LoginUserRequest": {
-            "description": "request body for a login request. either email or username must be provided",
+        "types.UpdateItemRequest": {
+            "description": "a request body for updating an item",
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "test@test.com"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "password"
-                },
-                "username": {
+                "title": {
                     "type": "string",
-                    "example": "test"
+                    "example": "Item title"
                 }
             }
         },
-        "types.LogoutSuccessResponse": {
-            "description": "user logged out successfully",
+        "types.UpdateListRequest": {
+            "description": "a request body for updating a list",
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "message": {
+                "name": {
                     "type": "string",
-                    "example": "logged out successfully"
+                    "example": "Watchlist"
                 }
             }
         },
-        "types.MissingFieldResponse": {
-            "description": "an example of a missing field response an example of a missing field response",
+        "types.UpdateListStatusRequest": {
+            "description": "a request body for updating a status column. A null wip_limit removes the limit",
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "object",
-                    "properties": {
-                        "username": {
-                            "type": "string",
-                            "example": "This field is required"
-                        }
-                    }
+                "wip_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5
                 }
             }
         },
-        "types.PaginatedItemsResponse": {
-            "description": "a response containing a list of items and a pagination object",
+        "types.UpdateReviewRequest": {
+            "description": "a request body for updating a review. The content and rating replace the existing values",
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.ItemsResponse"
-                    }
+                "content": {
+                    "type": "string",
+                    "example": "Better on a second watch."
                 },
-                "pagination": {
-                    "$ref": "#/definitions/types.Pagination"
+                "rating": {
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0.5,
+                    "example": 5
                 }
             }
         },
-        "types.PaginatedListItemsResponse": {
+        "types.UpdateStatusRequest": {
+            "description": "a request body for renaming a status",
             "type": "object",
+            "required": [
+                "label"
+            ],
             "properties": {
-                "list_items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.ListItemsResponse"
-                    }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/types.Pagination"
+                "label": {
+                    "type": "string",
+                    "example": "watching"
                 }
             }
         },
-        "types.PaginatedStatusesResponse": {
-            "description": "a paginated list of statuses",
+        "types.UpdateUserRequest": {
+            "description": "a request body for updating a user. changing the email marks it as unverified and sends a verification link to the new address",
             "type": "object",
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/types.Pagination"
+                "bio": {
+                    "type": "string",
+                    "example": "This is a bio"
                 },
-                "statuses": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.StatusesResponse"
-                    }
+                "email": {
+                    "type": "string",
+                    "example": "new@test.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Tim Test"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "new_username"
                 }
             }
         },
-        "types.PaginatedUsersResponse": {
-            "description": "a response containing a list of users and a pagination object",
+        "types.UpdateWatchRequest": {
+            "description": "a request body for updating a diary entry. The fields replace the existing values",
             "type": "object",
+            "required": [
+                "watched_date"
+            ],
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/types.Pagination"
+                "format": {
+                    "type": "string",
+                    "example": "Blu-ray"
                 },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/types.UserResponse"
-                    }
+                "location": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "rating": {
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0.5,
+                    "example": 5
+                },
+                "review_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000003"
+                },
+                "rewatch": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "watched_date": {
+                    "type": "string",
+                    "example": "2025-02-14"
                 }
             }
         },
-        "types.Pagination": {
-            "description": "pagination information",
+        "types.UserDeletedResponse": {
+            "description": "A success message confirming the user was deleted",
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "page_size": {
-                    "type": "integer",
-                    "example": 50
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 2
+                "success": {
+                    "type": "string",
+                    "example": "user deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
                 }
             }
         },
-        "types.RefreshTokenMissingResponse": {
-            "description": "refresh token missing",
+        "types.UserNotFoundResponse": {
+            "description": "User not found",
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "refresh token missing"
+                    "example": "user not found"
                 }
             }
         },
-        "types.RegisterUserRequest": {
-            "description": "A request body for registering a new user",
+        "types.UserResponse": {
+            "description": "JSON representation of a user in the system",
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "bio": {
                     "type": "string",
-                    "example": "test@test.com"
+                    "example": "This is a bio"
                 },
-                "password": {
+                "full_name": {
                     "type": "string",
-                    "example": "password"
+                    "example": "Tim Test"
                 },
                 "username": {
                     "type": "string",
-                    "example": "test"
-                }
-            }
-        },
-        "types.StatusesResponse": {
-            "description": "status details",
-            "type": "object",
-            "properties": {
-                "label": {
-                    "type": "string",
-                    "example": "backlog"
+                    "example": "username"
                 },
                 "uuid": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "77b62cff-0020-43d9-a90c-5d35bff89f7a"
                 }
             }
         },
-        "types.TokenResponse": {
-            "description": "a response containing a JWT for authentication and a refresh token",
+        "types.UserRolesResponse": {
+            "description": "the roles assigned to a user and the permissions they add up to",
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "items:edit",
+                        "items:refresh",
+                        "reviews:moderate"
+                    ]
                 },
-                "expiry_date": {
-                    "type": "string",
-                    "example": "2025-02-15 11:59:01.837871 +0100 CET m=+3603.614509085"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "member",
+                        "moderator"
+                    ]
                 },
-                "refresh_token": {
+                "user_uuid": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "types.UpdateItemRequest": {
-            "description": "a request body for updating an item",
+        "types.VerificationSentResponse": {
+            "description": "verification link sent if the email belongs to an unverified user",
             "type": "object",
             "properties": {
-                "title": {
+                "message": {
                     "type": "string",
-                    "example": "Item title"
+                    "example": "if the email belongs to an unverified account, a verification link has been sent to it"
                 }
             }
         },
-        "types.UpdateUserRequest": {
-            "description": "a request body for updating a user",
+        "types.VerifyEmailRequest": {
+            "description": "request body for verifying an email address with the token from a verification link",
             "type": "object",
+            "required": [
+                "token"
+            ],
             "properties": {
-                "bio": {
-                    "type": "string",
-                    "example": "This is a bio"
-                },
-                "full_name": {
-                    "type": "string",
-                    "example": "Tim Test"
-                },
-                "username": {
+                "token": {
                     "type": "string",
-                    "example": "new_username"
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
                 }
             }
         },
-        "types.UserDeletedResponse": {
-            "description": "A success message confirming the user was deleted",
+        "types.WatchDeletedResponse": {
+            "description": "A success message confirming the diary entry was deleted",
             "type": "object",
             "properties": {
                 "success": {
                     "type": "string",
-                    "example": "user deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                    "example": "watch deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"
                 }
             }
         },
-        "types.UserNotFoundResponse": {
-            "description": "User not found",
+        "types.WatchLoggedResponse": {
+            "description": "the new diary entry, and the item's card if it was moved on a list",
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "user not found"
+                "list_item": {
+                    "$ref": "#/definitions/types.ListItemsResponse"
+                },
+                "watch": {
+                    "$ref": "#/definitions/types.WatchResponse"
                 }
             }
         },
-        "types.UserResponse": {
-            "description": "JSON representation of a user in the system",
+        "types.WatchResponse": {
+            "description": "a diary entry for a single viewing of an item. rating, review_uuid, location and format are null when they weren't recorded",
             "type": "object",
             "properties": {
-                "bio": {
+                "created_date": {
                     "type": "string",
-                    "example": "This is a bio"
+                    "example": "2025-02-15T11:59:01Z"
                 },
-                "full_name": {
+                "format": {
                     "type": "string",
-                    "example": "Tim Test"
+                    "example": "35mm"
+                },
+                "item_title": {
+                    "type": "string",
+                    "example": "Fight Club"
+                },
+                "item_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000001"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Prince Charles Cinema"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "review_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000003"
+                },
+                "rewatch": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_date": {
+                    "type": "string",
+                    "example": "2025-02-16T11:59:01Z"
+                },
+                "user_uuid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000002"
                 },
                 "username": {
                     "type": "string",
-                    "example": "username"
+                    "example": "tyler"
                 },
                 "uuid": {
                     "type": "string",
-                    "example": "77b62cff-0020-43d9-a90c-5d35bff89f7a"
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "watched_date": {
+                    "type": "string",
+                    "example": "2025-02-14"
                 }
             }
         }
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// maxAccountImportSize is the largest account export, in bytes, that can be imported. The whole export is
+// read into memory before anything is imported
+const maxAccountImportSize = 16 << 20
+
+type AccountHandler struct {
+	accountDataService *services.AccountDataService
+}
+
+func NewAccountHandler(accountDataService *services.AccountDataService) *AccountHandler {
+	return &AccountHandler{
+		accountDataService: accountDataService,
+	}
+}
+
+// ExportAccount exports everything a user owns as versioned JSON
+//
+//	@Summary		Export account data
+//	@Description	Download a full backup of the user's profile, items, statuses, lists with their columns and cards,
+//	@Description	reviews and watches. The file can be imported again with POST /users/{uuid}/import. Users can only
+//	@Description	export their own data
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			uuid	path		string				true	"User UUID"
+//	@Success		200		{object}	types.AccountExport	"Account export"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/export [get]
+func (h *AccountHandler) ExportAccount(c *gin.Context) {
+	requesterUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	export, err := h.accountDataService.Export(c.Request.Context(), c.Param("uuid"), *requesterUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("eigakanban-%s-%s.json", export.Profile.Username, time.Now().UTC().Format(time.DateOnly))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.JSON(http.StatusOK, export)
+}
+
+// ImportAccount imports an account export into a user's account
+//
+//	@Summary		Import account data
+//	@Description	Recreate the contents of an account export in the user's account. Boards keep their columns and card
+//	@Description	positions. Items are imported from TMDB by their TMDB ID and statuses are matched by label. Items
+//	@Description	without a TMDB ID that don't already exist are listed in the report, and their cards, reviews and
+//	@Description	watches are skipped. Records whose UUID belongs to someone else are given a new UUID, which is listed
+//	@Description	in the report. Lists, reviews and watches the user already has are skipped. Nothing is imported if any
+//	@Description	record is invalid. Users can only import into their own account.
+//	@Description	Exports larger than 16 MB are rejected
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"User UUID"
+//	@Param			request	body		types.AccountExport			true	"Account export"
+//	@Success		200		{object}	types.AccountImportReport	"Import report"
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		413		{object}	types.ErrorResponse	"Export too large"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/import [post]
+func (h *AccountHandler) ImportAccount(c *gin.Context) {
+	requesterUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	helpers.LimitRequestBody(c, maxAccountImportSize)
+
+	var request types.AccountExport
+	if err := c.ShouldBindJSON(&request); err != nil {
+		if tooLarge := helpers.RequestTooLargeError(err); tooLarge != nil {
+			helpers.HandleAPIError(c, tooLarge)
+			return
+		}
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	report, err := h.accountDataService.Import(c.Request.Context(), c.Param("uuid"), *requesterUuid, request)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
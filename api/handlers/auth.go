@@ -21,7 +21,7 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 // RegisterUser adds a new user to the system
 //
 //	@Summary		Register a new user account
-//	@Description	Register a new user account
+//	@Description	Register a new user account. A link to verify the email address is sent to it
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -50,13 +50,17 @@ func (h *AuthHandler) RegisterUser(c *gin.Context) {
 // LoginUser logs in a user
 //
 //	@Summary		Log in
-//	@Description	Log in to user account using email or username
+//	@Description	Log in to user account using email or username.
+//	@Description	Users who haven't verified their email can't log in if the instance requires verification.
+//	@Description	Users with two-factor authentication get an MFA token to exchange at /auth/login/mfa instead of tokens
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			body	body		types.LoginUserRequest		true	"Login details"
 //	@Success		200		{object}	types.TokenResponse			"Successful login"
+//	@Success		202		{object}	types.MfaChallengeResponse	"Second factor required"
 //	@Failure		400		{object}	types.MissingFieldResponse	"Missing mandatory fields"
+//	@Failure		403		{object}	types.ErrorResponse			"Email address not verified"
 //	@Failure		404		{object}	types.UserNotFoundResponse	"User not found"
 //	@Failure		500		{object}	types.ErrorResponse
 //	@Router			/auth/login [post]
@@ -67,14 +71,19 @@ func (h *AuthHandler) LoginUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.authService.LoginUser(c.Request.Context(), req.Email, req.Username, req.Password)
+	user, challenge, err := h.authService.LoginUser(c.Request.Context(), req.Email, req.Username, req.Password, helpers.GetSessionClient(c, req.DeviceName))
 	if err != nil {
 		helpers.HandleAPIError(c, err)
 		return
 	}
 
+	// Users with two-factor authentication finish logging in with a code
+	if challenge != nil {
+		c.JSON(http.StatusAccepted, challenge)
+		return
+	}
+
 	c.Set("user_uuid", user.Uuid)
-	c.Set("superuser", user.SuperUser)
 
 	response := types.TokenResponse{
 		AccessToken:  user.AccessToken,
@@ -86,16 +95,50 @@ func (h *AuthHandler) LoginUser(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// RefreshToken refreshes a user's access token by passing in a valid refresh token
+// LoginMfa finishes logging in a user with two-factor authentication
 //
-//	@Summary		Log out
-//	@Description	Log out of the app
+//	@Summary		Log in with a second factor
+//	@Description	Exchange the MFA token from /auth/login and a code from the authenticator app or a recovery code
+//	@Description	for an access token and refresh token. Each recovery code can only be used once.
+//	@Description	After too many wrong codes the MFA token stops working and the user has to log in again
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.MfaLoginRequest		true	"MFA token and code"
+//	@Success		200		{object}	types.TokenResponse			"Successful login"
+//	@Failure		400		{object}	types.MissingFieldResponse	"Missing mandatory fields"
+//	@Failure		401		{object}	types.ErrorResponse			"Invalid code, or invalid or expired MFA token"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/login/mfa [post]
+func (h *AuthHandler) LoginMfa(c *gin.Context) {
+	var req types.MfaLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	tokenResponse, err := h.authService.CompleteMfaLogin(c.Request.Context(), req.MfaToken, req.Code, helpers.GetSessionClient(c, ""))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse)
+}
+
+// RefreshToken exchanges a refresh token for a new access token and refresh token. The refresh token
+// passed in can't be used again
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchange a refresh token for a new access token and refresh token.
+//	@Description	Each refresh token can only be used once. Reusing one logs out the session it belongs to
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			Refresh-Token	header		string								true	"Refresh token"
-//	@Success		200				{object}	types.AccessTokenResponse			"New access token"
+//	@Success		200				{object}	types.TokenResponse					"New access and refresh tokens"
 //	@Failure		400				{object}	types.RefreshTokenMissingResponse	"Missing refresh token"
+//	@Failure		401				{object}	types.ErrorResponse					"Invalid, expired, or reused refresh token"
 //	@Failure		500				{object}	types.ErrorResponse
 //	@Router			/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
@@ -105,7 +148,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	tokenResponse, err := h.authService.CreateNewAccessToken(c, refreshToken)
+	tokenResponse, err := h.authService.RefreshTokens(c.Request.Context(), *refreshToken, helpers.GetSessionClient(c, ""))
 	if err != nil {
 		helpers.HandleAPIError(c, err)
 		return
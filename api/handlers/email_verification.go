@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationService *services.EmailVerificationService
+}
+
+func NewEmailVerificationHandler(emailVerificationService *services.EmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerificationService: emailVerificationService,
+	}
+}
+
+// VerifyEmail verifies an email address with the token from a verification link
+//
+//	@Summary		Verify email
+//	@Description	Verify an email address with the token from the link sent on registration or when the email changes.
+//	@Description	Each token can only be used once, and only for the address it was sent to
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	types.EmailVerifiedResponse	"Email verified"
+//	@Failure		400		{object}	types.ErrorResponse			"Invalid, used, or expired token"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/verify-email [post]
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	var req types.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.emailVerificationService.VerifyEmail(c.Request.Context(), req); err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email verified"})
+}
+
+// ResendVerification emails a new verification link
+//
+//	@Summary		Resend verification email
+//	@Description	Email a new verification link to the account with the given email if it isn't verified yet.
+//	@Description	Earlier links stop working. The response is the same whether or not a link was sent
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.ResendVerificationRequest	true	"Account email"
+//	@Success		202		{object}	types.VerificationSentResponse	"Verification link sent if the email belongs to an unverified account"
+//	@Failure		400		{object}	types.MissingFieldResponse		"Missing mandatory fields"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/verify-email/resend [post]
+func (h *EmailVerificationHandler) ResendVerification(c *gin.Context) {
+	var req types.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.emailVerificationService.ResendVerification(c.Request.Context(), req.Email); err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email belongs to an unverified account, a verification link has been sent to it"})
+}
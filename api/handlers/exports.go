@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"github.com/gin-gonic/gin"
+	"log"
+	"mime"
+	"net/http"
+)
+
+type ExportsHandler struct {
+	letterboxdExportService *services.LetterboxdExportService
+}
+
+func NewExportsHandler(letterboxdExportService *services.LetterboxdExportService) *ExportsHandler {
+	return &ExportsHandler{
+		letterboxdExportService: letterboxdExportService,
+	}
+}
+
+// ExportLetterboxd streams a Letterboxd-compatible export of a user's data
+//
+//	@Summary		Export to Letterboxd
+//	@Description	Download a zip archive with the same files and columns as a Letterboxd export: watchlist.csv from
+//	@Description	the items in one status column, diary.csv from their watches, and ratings.csv and reviews.csv from their
+//	@Description	reviews. The watchlist is the first status of the user's default list, where imported watchlists are
+//	@Description	added, unless list_uuid and status_uuid are given. The archive can be imported into Letterboxd or back
+//	@Description	into eigakanban. Users can only export their own data
+//	@Tags			exports
+//	@Security		BearerAuth
+//	@Produce		application/zip
+//	@Param			uuid		path		string	true	"User UUID"
+//	@Param			list_uuid	query		string	false	"List to export as the watchlist"
+//	@Param			status_uuid	query		string	false	"Status to export as the watchlist"
+//	@Success		200			{file}		file	"Letterboxd export archive"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		403			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/export/letterboxd [get]
+func (h *ExportsHandler) ExportLetterboxd(c *gin.Context) {
+	requesterUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	userUuid := c.Param("uuid")
+	export, err := h.letterboxdExportService.NewExport(c.Request.Context(), userUuid, *requesterUuid, c.Query("list_uuid"), c.Query("status_uuid"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.Filename}))
+	c.Status(http.StatusOK)
+
+	// The status has already been sent, so a failure part way through can only cut the archive short
+	if err := export.WriteTo(c.Request.Context(), c.Writer); err != nil {
+		log.Printf("Couldn't stream letterboxd export for user %s: %v", userUuid, err)
+		c.Abort()
+	}
+}
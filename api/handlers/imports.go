@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// maxImportUploadSize is the largest upload, in bytes, that an import accepts. Letterboxd and IMDb exports
+// are usually well under a megabyte
+const maxImportUploadSize = 32 << 20
+
+type ImportsHandler struct {
+	letterboxdImportService *services.LetterboxdImportService
+	imdbImportService       *services.ImdbImportService
+	importJobsService       *services.ImportJobsService
+}
+
+func NewImportsHandler(letterboxdImportService *services.LetterboxdImportService, imdbImportService *services.ImdbImportService, importJobsService *services.ImportJobsService) *ImportsHandler {
+	return &ImportsHandler{
+		letterboxdImportService: letterboxdImportService,
+		imdbImportService:       imdbImportService,
+		importJobsService:       importJobsService,
+	}
+}
+
+// ImportLetterboxd imports a Letterboxd export for the authenticated user
+//
+//	@Summary		Import from Letterboxd
+//	@Description	Import the zip archive from Letterboxd's data export. Films are matched to TMDB by title and year.
+//	@Description	Diary entries and watched films become watches, reviews and ratings become reviews, and the
+//	@Description	watchlist is added to the first column of the user's default list. Rows that were already imported
+//	@Description	are skipped. Set dry_run to match the films and report the result without importing anything
+//	@Tags			imports
+//	@Security		BearerAuth
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file				true	"Letterboxd export archive"
+//	@Param			dry_run	query		bool				false	"Match films without importing them"
+//	@Success		200		{object}	types.ImportReport	"Import report"
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		413		{object}	types.ErrorResponse	"Upload or unzipped export too large"
+//	@Failure		502		{object}	types.ErrorResponse	"TMDB could not be reached"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/imports/letterboxd [post]
+func (h *ImportsHandler) ImportLetterboxd(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "invalid dry_run"))
+		return
+	}
+
+	helpers.LimitRequestBody(c, maxImportUploadSize)
+
+	header, err := c.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "file is required"))
+		return
+	}
+	if err != nil {
+		helpers.HandleAPIError(c, formFileError(err, "file"))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "couldn't read file"))
+		return
+	}
+	defer file.Close()
+
+	report, err := h.letterboxdImportService.Import(c.Request.Context(), *userUuid, file, header.Size, dryRun)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ImportImdb starts a background import of an IMDb export for the authenticated user
+//
+//	@Summary		Import from IMDb
+//	@Description	Upload ratings.csv and/or WATCHLIST.csv from IMDb's data export. Titles are matched to TMDB movies by
+//	@Description	IMDb ID and imported as items. Ratings out of ten become reviews rated out of five stars, and the
+//	@Description	watchlist is added to the given list and status, or to the first column of the user's default list.
+//	@Description	The import runs in the background. Its progress can be followed with GET /imports/jobs/{uuid}
+//	@Tags			imports
+//	@Security		BearerAuth
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			ratings		formData	file					false	"IMDb ratings.csv"
+//	@Param			watchlist	formData	file					false	"IMDb WATCHLIST.csv"
+//	@Param			list_uuid	formData	string					false	"List to add the watchlist to"
+//	@Param			status_uuid	formData	string					false	"Status to add the watchlist to"
+//	@Success		202			{object}	types.ImportJobResponse	"Import job"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		403			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		413			{object}	types.ErrorResponse	"Upload too large"
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/imports/imdb [post]
+func (h *ImportsHandler) ImportImdb(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	helpers.LimitRequestBody(c, maxImportUploadSize)
+
+	ratings, err := openFormFile(c, "ratings")
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+	if ratings != nil {
+		defer ratings.Close()
+	}
+
+	watchlist, err := openFormFile(c, "watchlist")
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+	if watchlist != nil {
+		defer watchlist.Close()
+	}
+
+	job, err := h.imdbImportService.CreateImportJob(
+		c.Request.Context(),
+		*userUuid,
+		readerOrNil(ratings),
+		readerOrNil(watchlist),
+		c.PostForm("list_uuid"),
+		c.PostForm("status_uuid"),
+	)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetImportJobs lists the authenticated user's background imports
+//
+//	@Summary		List import jobs
+//	@Description	List the authenticated user's background imports, most recent first
+//	@Tags			imports
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			page		query		int									false	"Page"
+//	@Param			page_size	query		int									false	"Page size"
+//	@Success		200			{object}	types.PaginatedImportJobsResponse	"Import jobs"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/imports/jobs [get]
+func (h *ImportsHandler) GetImportJobs(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	pagination, err := helpers.ValidatePagination(c)
+	if err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	jobs, err := h.importJobsService.GetImportJobsForUser(c.Request.Context(), *userUuid, pagination)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetImportJob reports the progress of a background import
+//
+//	@Summary		Get an import job
+//	@Description	Get the state and progress of one of the authenticated user's background imports, with the rows
+//	@Description	that couldn't be imported
+//	@Tags			imports
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			uuid	path		string					true	"Import job UUID"
+//	@Success		200		{object}	types.ImportJobResponse	"Import job"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/imports/jobs/{uuid} [get]
+func (h *ImportsHandler) GetImportJob(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	job, err := h.importJobsService.GetImportJob(c.Request.Context(), c.Param("uuid"), *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// ResumeImportJob resumes a failed background import
+//
+//	@Summary		Resume an import job
+//	@Description	Queue a failed import to carry on from the first row that wasn't processed. Rows that were already
+//	@Description	imported are kept
+//	@Tags			imports
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			uuid	path		string					true	"Import job UUID"
+//	@Success		202		{object}	types.ImportJobResponse	"Import job"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"The import job hasn't failed"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/imports/jobs/{uuid}/resume [post]
+func (h *ImportsHandler) ResumeImportJob(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	job, err := h.importJobsService.ResumeImportJob(c.Request.Context(), c.Param("uuid"), *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// openFormFile opens an optional file from a multipart form, returning nil if it wasn't uploaded
+func openFormFile(c *gin.Context, name string) (multipart.File, error) {
+	header, err := c.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, formFileError(err, name)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("couldn't read %s", name))
+	}
+
+	return file, nil
+}
+
+// formFileError converts an error from reading an uploaded file to an API error
+func formFileError(err error, name string) error {
+	if tooLarge := helpers.RequestTooLargeError(err); tooLarge != nil {
+		return tooLarge
+	}
+
+	return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("couldn't read %s", name))
+}
+
+// readerOrNil converts a missing file to a nil reader
+func readerOrNil(file multipart.File) io.Reader {
+	if file == nil {
+		return nil
+	}
+	return file
+}
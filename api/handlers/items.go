@@ -7,15 +7,18 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 )
 
 type ItemsHandler struct {
-	itemsService *services.ItemsService
+	itemsService      *services.ItemsService
+	metadataRefresher *services.MetadataRefresher
 }
 
-func NewItemsHandler(itemsService *services.ItemsService) *ItemsHandler {
+func NewItemsHandler(itemsService *services.ItemsService, metadataRefresher *services.MetadataRefresher) *ItemsHandler {
 	return &ItemsHandler{
-		itemsService: itemsService,
+		itemsService:      itemsService,
+		metadataRefresher: metadataRefresher,
 	}
 }
 
@@ -57,8 +60,8 @@ func (h *ItemsHandler) GetAllItems(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			uuid	path		string	true	"Item UUID"
-//	@Success		200		{object}	types.ItemsResponse
-//	@Failure		400		{object}	types.ErrorResponse
+//	@Success		200		{object}	types.ItemDetailsResponse
+//	@Failure		404		{object}	types.ErrorResponse
 //	@Failure		500		{object}	types.ErrorResponse
 //	@Router			/items/{uuid} [get]
 func (h *ItemsHandler) GetItemByUuid(c *gin.Context) {
@@ -101,10 +104,81 @@ func (h *ItemsHandler) AddItem(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"item": item})
 }
 
+// ImportTmdbMovie adds a movie using its TMDB details
+//
+//	@Summary		Import a movie from TMDB
+//	@Description	Add a movie using the details held by TMDB. If the movie has already been imported the existing
+//	@Description	item is returned with a 200 status instead of adding it again
+//	@Tags			items
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int							true	"TMDB movie ID"
+//	@Success		200	{object}	types.ItemDetailsResponse	"Movie already imported"
+//	@Success		201	{object}	types.ItemDetailsResponse	"Movie imported successfully"
+//	@Failure		400	{object}	types.ErrorResponse
+//	@Failure		404	{object}	types.ErrorResponse	"Movie not found on TMDB"
+//	@Failure		502	{object}	types.ErrorResponse	"TMDB could not be reached"
+//	@Failure		500	{object}	types.ErrorResponse
+//	@Router			/items/import/tmdb/{id} [post]
+func (h *ItemsHandler) ImportTmdbMovie(c *gin.Context) {
+	tmdbId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || tmdbId <= 0 {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "invalid tmdb id"))
+		return
+	}
+
+	item, created, err := h.itemsService.ImportTmdbMovie(c.Request.Context(), tmdbId)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	c.JSON(status, gin.H{"item": item})
+}
+
+// RefreshItemMetadata re-fetches the TMDB details of an item
+//
+//	@Summary		Refresh item metadata
+//	@Description	Re-fetch the TMDB details of an item immediately instead of waiting for the scheduled refresh.
+//	@Description	Requires the items:refresh permission
+//	@Tags			items
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"Item UUID"
+//	@Success		200		{object}	types.ItemDetailsResponse
+//	@Failure		400		{object}	types.ErrorResponse	"Item was not imported from TMDB"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		502		{object}	types.ErrorResponse	"TMDB could not be reached"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/admin/items/{uuid}/refresh [post]
+func (h *ItemsHandler) RefreshItemMetadata(c *gin.Context) {
+	itemUuid, err := helpers.ValidateAndConvertUUID(c.Param("uuid"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	item, err := h.metadataRefresher.RefreshItem(c.Request.Context(), *itemUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"item": item})
+}
+
 // UpdateItem updates item details
 //
 //	@Summary		Update item details
-//	@Description	Update item details by UUID
+//	@Description	Update item details by UUID. Requires the items:edit permission
 //	@Security		BearerAuth
 //	@Tags			items
 //	@Accept			json
@@ -113,6 +187,7 @@ func (h *ItemsHandler) AddItem(c *gin.Context) {
 //	@Param			body	body		types.UpdateItemRequest	true	"Item details to update"
 //	@Success		200		{object}	types.ItemsResponse
 //	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
 //	@Failure		500		{object}	types.ErrorResponse
 //	@Router			/items/{uuid} [patch]
 func (h *ItemsHandler) UpdateItem(c *gin.Context) {
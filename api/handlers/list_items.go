@@ -3,6 +3,7 @@ package handlers
 import (
 	"codeberg.org/sporiff/eigakanban/helpers"
 	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -30,6 +31,7 @@ func NewListItemsHandler(listItemsService *services.ListItemsService) *ListItems
 //	@Success		200			{object}	types.PaginatedListItemsResponse
 //	@Failure		500			{object}	types.ErrorResponse
 //	@Router			/list_items [get]
+//	@Router			/lists/{uuid}/ [get]
 func (h *ListItemsHandler) GetAllListItems(c *gin.Context) {
 	pagination, err := helpers.ValidatePagination(c)
 	if err != nil {
@@ -58,7 +60,9 @@ func (h *ListItemsHandler) GetAllListItems(c *gin.Context) {
 //	@Param			page		query		int		false	"Page"
 //	@Param			page_size	query		int		false	"Page size"
 //	@Success		200			{object}	types.PaginatedListItemsResponse
+//	@Failure		404			{object}	types.ErrorResponse
 //	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/lists/{uuid} [get]
 //	@Router			/lists/{uuid}/items [get]
 func (h *ListItemsHandler) GetListItemsForList(c *gin.Context) {
 	listUuid := c.Param("uuid")
@@ -77,3 +81,114 @@ func (h *ListItemsHandler) GetListItemsForList(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// AddItemToList adds an item to a list
+//
+//	@Summary		Add an item to a list
+//	@Description	Add an item to a status column of a list. Only the owner of the list can add items
+//	@Security		BearerAuth
+//	@Tags			lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string							true	"List UUID"
+//	@Param			body	body		types.AddListItemRequest		true	"List item details"
+//	@Success		201		{object}	types.ListItemPlacementResponse	"Item added and its neighbours"
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"Item already in list or status column at its WIP limit"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/items [post]
+func (h *ListItemsHandler) AddItemToList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.AddListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	response, err := h.listItemsService.AddItemToList(c.Request.Context(), listUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// MoveListItem moves a list item to a new status and position
+//
+//	@Summary		Move a list item
+//	@Description	Move a list item to a new status and position. Only the owner of the list can move items
+//	@Security		BearerAuth
+//	@Tags			list_items
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string							true	"List item UUID"
+//	@Param			body	body		types.MoveListItemRequest		true	"New status and position"
+//	@Success		200		{object}	types.ListItemPlacementResponse	"Item moved and its new neighbours"
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"Status column at its WIP limit"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/list_items/{uuid} [patch]
+func (h *ListItemsHandler) MoveListItem(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.MoveListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	listItemUuid := c.Param("uuid")
+	response, err := h.listItemsService.MoveListItem(c.Request.Context(), listItemUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteListItem removes an item from a list
+//
+//	@Summary		Remove a list item
+//	@Description	Remove an item from a list. Only the owner of the list can remove items
+//	@Security		BearerAuth
+//	@Tags			list_items
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string							true	"List item UUID"
+//	@Success		200		{object}	types.ListItemPlacementResponse	"The former neighbours of the removed item"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/list_items/{uuid} [delete]
+func (h *ListItemsHandler) DeleteListItem(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	listItemUuid := c.Param("uuid")
+	response, err := h.listItemsService.DeleteListItem(c.Request.Context(), listItemUuid, *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
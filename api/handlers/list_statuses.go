@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListStatusesHandler struct {
+	listStatusesService *services.ListStatusesService
+}
+
+func NewListStatusesHandler(listStatusesService *services.ListStatusesService) *ListStatusesHandler {
+	return &ListStatusesHandler{
+		listStatusesService: listStatusesService,
+	}
+}
+
+// GetStatusesForList returns the status columns of a list
+//
+//	@Summary		Get list statuses
+//	@Description	Get the statuses attached to a list in column order
+//	@Tags			lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"List UUID"
+//	@Success		200		{object}	types.ListStatusesResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/statuses [get]
+func (h *ListStatusesHandler) GetStatusesForList(c *gin.Context) {
+	listUuid := c.Param("uuid")
+	result, err := h.listStatusesService.GetStatusesForList(c.Request.Context(), listUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AddStatusToList attaches a status to a list
+//
+//	@Summary		Attach a status to a list
+//	@Description	Attach a status to a list as its last column. Only the owner of the list and status can attach it
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"List UUID"
+//	@Param			body	body		types.AddListStatusRequest	true	"Status to attach"
+//	@Success		201		{object}	types.ListStatusesResponse
+//	@Failure		400		{object}	types.MissingFieldResponse	"Missing mandatory fields"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"Status already attached to the list"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/statuses [post]
+func (h *ListStatusesHandler) AddStatusToList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.AddListStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	result, err := h.listStatusesService.AddStatusToList(c.Request.Context(), listUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// ReorderStatusesForList sets the column order of a list
+//
+//	@Summary		Reorder list statuses
+//	@Description	Set the column order of a list. The request must contain every status attached to the list exactly once
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string								true	"List UUID"
+//	@Param			body	body		types.ReorderListStatusesRequest	true	"Status UUIDs in column order"
+//	@Success		200		{object}	types.ListStatusesResponse
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/statuses [put]
+func (h *ListStatusesHandler) ReorderStatusesForList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.ReorderListStatusesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	result, err := h.listStatusesService.ReorderStatusesForList(c.Request.Context(), listUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateStatusOnList updates a status column on a list
+//
+//	@Summary		Update a list status
+//	@Description	Update the settings of a status column on a list, such as its WIP limit.
+//	@Description	A null wip_limit removes the limit
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid		path		string							true	"List UUID"
+//	@Param			status_uuid	path		string							true	"Status UUID"
+//	@Param			body		body		types.UpdateListStatusRequest	true	"Column settings"
+//	@Success		200			{object}	types.ListStatusesResponse
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		403			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/statuses/{status_uuid} [patch]
+func (h *ListStatusesHandler) UpdateStatusOnList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.UpdateListStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	statusUuid := c.Param("status_uuid")
+	result, err := h.listStatusesService.UpdateStatusOnList(c.Request.Context(), listUuid, statusUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveStatusFromList detaches a status from a list
+//
+//	@Summary		Detach a status from a list
+//	@Description	Detach a status from a list. The status column must not contain any list items
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid		path		string	true	"List UUID"
+//	@Param			status_uuid	path		string	true	"Status UUID"
+//	@Success		200			{object}	types.ListStatusesResponse
+//	@Failure		403			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		409			{object}	types.ErrorResponse	"Status still has items on the list"
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/statuses/{status_uuid} [delete]
+func (h *ListStatusesHandler) RemoveStatusFromList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	statusUuid := c.Param("status_uuid")
+	result, err := h.listStatusesService.RemoveStatusFromList(c.Request.Context(), listUuid, statusUuid, *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListsHandler struct {
+	listsService *services.ListsService
+}
+
+func NewListsHandler(listsService *services.ListsService) *ListsHandler {
+	return &ListsHandler{
+		listsService: listsService,
+	}
+}
+
+// AddList adds a new list for the authenticated user
+//
+//	@Summary		Add a new list
+//	@Description	Add a new list owned by the authenticated user
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.AddListRequest		true	"List details"
+//	@Success		201		{object}	types.ListsResponse			"List added successfully"
+//	@Failure		400		{object}	types.MissingFieldResponse	"Missing mandatory fields"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists [post]
+func (h *ListsHandler) AddList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.AddListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	list, err := h.listsService.AddList(c.Request.Context(), req, *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"list": list})
+}
+
+// GetListsForUser fetches all lists belonging to the authenticated user
+//
+//	@Summary		Fetch all lists
+//	@Description	Fetch all lists owned by the authenticated user as a paginated list
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			page		query		int	false	"Page"
+//	@Param			page_size	query		int	false	"Page size"
+//	@Success		200			{object}	types.PaginatedListsResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/lists [get]
+func (h *ListsHandler) GetListsForUser(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	pagination, err := helpers.ValidatePagination(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	result, err := h.listsService.GetListsForUser(c.Request.Context(), *userUuid, pagination)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetListByUuid returns a list by UUID
+//
+//	@Summary		Get list by UUID
+//	@Description	Get a list by UUID
+//	@Tags			lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"List UUID"
+//	@Success		200		{object}	types.ListsResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/details [get]
+func (h *ListsHandler) GetListByUuid(c *gin.Context) {
+	listUuid := c.Param("uuid")
+	list, err := h.listsService.GetListByUuid(c.Request.Context(), listUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"list": list})
+}
+
+// UpdateList renames a list
+//
+//	@Summary		Update list details
+//	@Description	Update list details by UUID. Only the owner of the list can update it
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string					true	"List UUID"
+//	@Param			body	body		types.UpdateListRequest	true	"List details to update"
+//	@Success		200		{object}	types.ListsResponse
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid} [patch]
+func (h *ListsHandler) UpdateList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.UpdateListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	list, err := h.listsService.UpdateList(c.Request.Context(), listUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"list": list})
+}
+
+// DeleteList deletes a list by UUID
+//
+//	@Summary		Delete list
+//	@Description	Delete a list by UUID. Only the owner of the list can delete it
+//	@Tags			lists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"List UUID"
+//	@Success		200		{object}	types.ListDeletedResponse	"List deleted successfully"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid} [delete]
+func (h *ListsHandler) DeleteList(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	listUuid := c.Param("uuid")
+	err = h.listsService.DeleteList(c.Request.Context(), listUuid, *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": "list deleted: " + listUuid})
+}
+
+// GetBoard returns a list as a kanban board
+//
+//	@Summary		Get list board
+//	@Description	Get a list as a kanban board. Each status attached to the list is returned as a column
+//	@Description	containing its cards ordered by position
+//	@Tags			lists
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"List UUID"
+//	@Success		200		{object}	types.BoardResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/lists/{uuid}/board [get]
+func (h *ListsHandler) GetBoard(c *gin.Context) {
+	listUuid := c.Param("uuid")
+	board, err := h.listsService.GetBoard(c.Request.Context(), listUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
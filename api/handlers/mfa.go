@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type MfaHandler struct {
+	mfaService *services.MfaService
+}
+
+func NewMfaHandler(mfaService *services.MfaService) *MfaHandler {
+	return &MfaHandler{
+		mfaService: mfaService,
+	}
+}
+
+// StartTotpEnrollment starts setting up two-factor authentication
+//
+//	@Summary		Start TOTP enrollment
+//	@Description	Create a TOTP secret for the authenticated user to add to an authenticator app.
+//	@Description	Two-factor authentication isn't turned on until a code is confirmed at /auth/mfa/totp/confirm
+//	@Tags			auth
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	types.TotpEnrollmentResponse
+//	@Failure		409	{object}	types.ErrorResponse	"Two-factor authentication already enabled"
+//	@Failure		500	{object}	types.ErrorResponse
+//	@Router			/auth/mfa/totp [post]
+func (h *MfaHandler) StartTotpEnrollment(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	enrollment, err := h.mfaService.StartTotpEnrollment(c.Request.Context(), *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTotpEnrollment turns on two-factor authentication
+//
+//	@Summary		Confirm TOTP enrollment
+//	@Description	Turn on two-factor authentication by entering a code from the authenticator app.
+//	@Description	The response holds the user's recovery codes, which are only shown once
+//	@Tags			auth
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.TotpCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	types.RecoveryCodesResponse
+//	@Failure		400		{object}	types.ErrorResponse	"Invalid code"
+//	@Failure		409		{object}	types.ErrorResponse	"Already enabled, or enrollment not started"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/mfa/totp/confirm [post]
+func (h *MfaHandler) ConfirmTotpEnrollment(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.TotpCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	recoveryCodes, err := h.mfaService.ConfirmTotpEnrollment(c.Request.Context(), *userUuid, req.Code)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recoveryCodes)
+}
+
+// DisableTotp turns off two-factor authentication
+//
+//	@Summary		Disable TOTP
+//	@Description	Turn off two-factor authentication for the authenticated user and delete their recovery codes.
+//	@Description	The user's password and a code from the authenticator app or a recovery code are needed
+//	@Tags			auth
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.DisableTotpRequest	true	"Password and code"
+//	@Success		200		{object}	types.TotpDisabledResponse	"Two-factor authentication disabled"
+//	@Failure		400		{object}	types.ErrorResponse			"Incorrect password or invalid code"
+//	@Failure		409		{object}	types.ErrorResponse			"Two-factor authentication isn't enabled"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/mfa/totp [delete]
+func (h *MfaHandler) DisableTotp(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.DisableTotpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.mfaService.DisableTotp(c.Request.Context(), *userUuid, req); err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes replaces the authenticated user's recovery codes
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Replace the authenticated user's recovery codes. Earlier codes stop working.
+//	@Description	A code from the authenticator app or a recovery code is needed
+//	@Tags			auth
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.TotpCodeRequest	true	"Code from the authenticator app or a recovery code"
+//	@Success		200		{object}	types.RecoveryCodesResponse
+//	@Failure		400		{object}	types.ErrorResponse	"Invalid code"
+//	@Failure		409		{object}	types.ErrorResponse	"Two-factor authentication isn't enabled"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/mfa/recovery-codes [post]
+func (h *MfaHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.TotpCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	recoveryCodes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), *userUuid, req.Code)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recoveryCodes)
+}
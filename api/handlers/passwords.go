@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PasswordsHandler struct {
+	passwordsService *services.PasswordsService
+}
+
+func NewPasswordsHandler(passwordsService *services.PasswordsService) *PasswordsHandler {
+	return &PasswordsHandler{
+		passwordsService: passwordsService,
+	}
+}
+
+// ChangePassword changes the authenticated user's password
+//
+//	@Summary		Change password
+//	@Description	Change the authenticated user's password. The current password must be given.
+//	@Description	Every other session is logged out, and the current session gets new tokens
+//	@Tags			auth
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200		{object}	types.TokenResponse			"New access and refresh tokens for the current session"
+//	@Failure		400		{object}	types.ErrorResponse			"Incorrect current password"
+//	@Failure		401		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/password [post]
+func (h *PasswordsHandler) ChangePassword(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	sessionId, err := helpers.GetSessionIdFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	tokenResponse, err := h.passwordsService.ChangePassword(c.Request.Context(), *userUuid, sessionId, req, helpers.GetSessionClient(c, ""))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse)
+}
+
+// ForgotPassword emails a password reset link
+//
+//	@Summary		Forgot password
+//	@Description	Email a link to reset the password of the account with the given email.
+//	@Description	The response is the same whether or not the email belongs to an account
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.ForgotPasswordRequest				true	"Account email"
+//	@Success		202		{object}	types.PasswordResetRequestedResponse	"Reset link sent if the email belongs to an account"
+//	@Failure		400		{object}	types.MissingFieldResponse				"Missing mandatory fields"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/password/forgot [post]
+func (h *PasswordsHandler) ForgotPassword(c *gin.Context) {
+	var req types.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.passwordsService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email belongs to an account, a reset link has been sent to it"})
+}
+
+// ResetPassword sets a new password with the token from a reset link
+//
+//	@Summary		Reset password
+//	@Description	Set a new password with the token from a password reset link.
+//	@Description	Each token can only be used once, and every session is logged out
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.ResetPasswordRequest	true	"Reset token and new password"
+//	@Success		200		{object}	types.PasswordResetResponse	"Password reset"
+//	@Failure		400		{object}	types.ErrorResponse			"Invalid, used, or expired token"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/auth/password/reset [post]
+func (h *PasswordsHandler) ResetPassword(c *gin.Context) {
+	var req types.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.passwordsService.ResetPassword(c.Request.Context(), req); err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password reset, please log in again"})
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ReviewsHandler struct {
+	reviewsService *services.ReviewsService
+}
+
+func NewReviewsHandler(reviewsService *services.ReviewsService) *ReviewsHandler {
+	return &ReviewsHandler{
+		reviewsService: reviewsService,
+	}
+}
+
+// AddReview reviews an item as the authenticated user
+//
+//	@Summary		Review an item
+//	@Description	Review an item with content, a rating out of five stars in half-star steps, or both.
+//	@Description	Each user can review an item once
+//	@Tags			reviews
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string					true	"Item UUID"
+//	@Param			body	body		types.AddReviewRequest	true	"Review details"
+//	@Success		201		{object}	types.ReviewResponse	"Review added successfully"
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/items/{uuid}/reviews [post]
+func (h *ReviewsHandler) AddReview(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.AddReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	itemUuid := c.Param("uuid")
+	review, err := h.reviewsService.AddReview(c.Request.Context(), itemUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"review": review})
+}
+
+// GetReviewsForItem fetches the reviews of an item
+//
+//	@Summary		Fetch reviews for an item
+//	@Description	Fetch the reviews of an item as a paginated list, newest first
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid		path		string	true	"Item UUID"
+//	@Param			page		query		int		false	"Page"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	types.PaginatedReviewsResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/items/{uuid}/reviews [get]
+func (h *ReviewsHandler) GetReviewsForItem(c *gin.Context) {
+	pagination, err := helpers.ValidatePagination(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	itemUuid := c.Param("uuid")
+	result, err := h.reviewsService.GetReviewsForItem(c.Request.Context(), itemUuid, pagination)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetReviewsForUser fetches the reviews written by a user
+//
+//	@Summary		Fetch reviews by a user
+//	@Description	Fetch the reviews written by a user as a paginated list, newest first
+//	@Tags			reviews
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid		path		string	true	"User UUID"
+//	@Param			page		query		int		false	"Page"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	types.PaginatedReviewsResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/reviews [get]
+func (h *ReviewsHandler) GetReviewsForUser(c *gin.Context) {
+	pagination, err := helpers.ValidatePagination(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	userUuid := c.Param("uuid")
+	result, err := h.reviewsService.GetReviewsForUser(c.Request.Context(), userUuid, pagination)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetReview returns a review by UUID
+//
+//	@Summary		Get review by UUID
+//	@Description	Get a review by UUID
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"Review UUID"
+//	@Success		200		{object}	types.ReviewResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/reviews/{uuid} [get]
+func (h *ReviewsHandler) GetReview(c *gin.Context) {
+	reviewUuid := c.Param("uuid")
+	review, err := h.reviewsService.GetReview(c.Request.Context(), reviewUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"review": review})
+}
+
+// UpdateReview updates a review
+//
+//	@Summary		Update review
+//	@Description	Replace the content and rating of a review. Only the author of the review can update it
+//	@Tags			reviews
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"Review UUID"
+//	@Param			body	body		types.UpdateReviewRequest	true	"Review details"
+//	@Success		200		{object}	types.ReviewResponse
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/reviews/{uuid} [patch]
+func (h *ReviewsHandler) UpdateReview(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	reviewUuid := c.Param("uuid")
+	review, err := h.reviewsService.UpdateReview(c.Request.Context(), reviewUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"review": review})
+}
+
+// DeleteReview deletes a review
+//
+//	@Summary		Delete review
+//	@Description	Delete a review by UUID. Only the author of the review or users with the reviews:moderate
+//	@Description	permission can delete it
+//	@Tags			reviews
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"Review UUID"
+//	@Success		200		{object}	types.ReviewDeletedResponse	"Review deleted successfully"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/reviews/{uuid} [delete]
+func (h *ReviewsHandler) DeleteReview(c *gin.Context) {
+	actor, err := helpers.GetActorFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	reviewUuid := c.Param("uuid")
+	err = h.reviewsService.DeleteReview(c.Request.Context(), reviewUuid, *actor)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": "review deleted: " + reviewUuid})
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type RolesHandler struct {
+	rolesService *services.RolesService
+}
+
+func NewRolesHandler(rolesService *services.RolesService) *RolesHandler {
+	return &RolesHandler{
+		rolesService: rolesService,
+	}
+}
+
+// GetRoles returns every role and the permissions it grants
+//
+//	@Summary		Get roles
+//	@Description	Get every role and the permissions it grants. Requires the roles:assign permission
+//	@Tags			admin
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	types.RolesResponse
+//	@Failure		403	{object}	types.ErrorResponse
+//	@Failure		500	{object}	types.ErrorResponse
+//	@Router			/admin/roles [get]
+func (h *RolesHandler) GetRoles(c *gin.Context) {
+	roles, err := h.rolesService.GetRoles(c.Request.Context())
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// GetRolesForUser returns the roles assigned to a user
+//
+//	@Summary		Get user roles
+//	@Description	Get the roles assigned to a user and the permissions they grant. Requires the roles:assign permission
+//	@Tags			admin
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"User UUID"
+//	@Success		200		{object}	types.UserRolesResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/admin/users/{uuid}/roles [get]
+func (h *RolesHandler) GetRolesForUser(c *gin.Context) {
+	roles, err := h.rolesService.GetRolesForUser(c.Request.Context(), c.Param("uuid"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// AssignRole gives a user a role
+//
+//	@Summary		Assign role
+//	@Description	Give a user a role. The user's new permissions apply to their next request.
+//	@Description	Requires the roles:assign permission
+//	@Tags			admin
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string					true	"User UUID"
+//	@Param			body	body		types.AssignRoleRequest	true	"Role to assign"
+//	@Success		200		{object}	types.UserRolesResponse
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/admin/users/{uuid}/roles [post]
+func (h *RolesHandler) AssignRole(c *gin.Context) {
+	var req types.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	roles, err := h.rolesService.AssignRole(c.Request.Context(), c.Param("uuid"), req.Role)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// RemoveRole takes a role away from a user
+//
+//	@Summary		Remove role
+//	@Description	Take a role away from a user. The user loses its permissions on their next request.
+//	@Description	The last admin can't lose the admin role. Requires the roles:assign permission
+//	@Tags			admin
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"User UUID"
+//	@Param			role	path		string	true	"Role name"
+//	@Success		200		{object}	types.UserRolesResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"Removing the last admin"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/admin/users/{uuid}/roles/{role} [delete]
+func (h *RolesHandler) RemoveRole(c *gin.Context) {
+	roles, err := h.rolesService.RemoveRole(c.Request.Context(), c.Param("uuid"), c.Param("role"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
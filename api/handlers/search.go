@@ -3,8 +3,10 @@ package handlers
 import (
 	"codeberg.org/sporiff/eigakanban/helpers"
 	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 )
 
 type SearchHandler struct {
@@ -20,16 +22,20 @@ func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
 // SearchMovie searches for movies on TMDB
 //
 //	@Summary		Search for movies on TMDB
-//	@Description	Search for movies on TMDB
+//	@Description	Search for movies on TMDB. Results are cached, and a stale copy is returned when TMDB can't be reached.
+//	@Description	The X-Cache response header is HIT, MISS or STALE depending on where the results came from
 //	@Security		BearerAuth
 //	@Tags			search
 //	@Accept			json
 //	@Produce		json
-//	@Param			uuid		path		string	true	"List UUID"
 //	@Param			q			query		string	true	"Search query"
+//	@Param			language	query		string	false	"Result language, such as en-US"
 //	@Param			page		query		int		false	"Page"
 //	@Param			page_size	query		int		false	"Page size"
-//	@Success		200			{object}	types.PaginatedListItemsResponse
+//	@Success		200			{object}	object	"TMDB search results"
+//	@Header			200			{string}	X-Cache	"HIT, MISS or STALE"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		502			{object}	types.ErrorResponse	"TMDB could not be reached and nothing was cached"
 //	@Failure		500			{object}	types.ErrorResponse
 //	@Router			/search [get]
 func (h *SearchHandler) SearchMovie(c *gin.Context) {
@@ -45,11 +51,120 @@ func (h *SearchHandler) SearchMovie(c *gin.Context) {
 		return
 	}
 
-	results, err := h.searchService.SearchMovie(pagination, query)
+	results, cacheStatus, err := h.searchService.SearchMovie(c.Request.Context(), pagination, query, c.Query("language"))
 	if err != nil {
 		helpers.HandleAPIError(c, err)
 		return
 	}
 
+	c.Header("X-Cache", cacheStatus)
 	c.JSON(http.StatusOK, results)
 }
+
+// GetMovieDetails fetches the details of a movie on TMDB
+//
+//	@Summary		Get movie details from TMDB
+//	@Description	Get the details of a movie on TMDB without importing it. Details are cached, and a stale copy is
+//	@Description	returned when TMDB can't be reached. The X-Cache response header is HIT, MISS or STALE depending on
+//	@Description	where the details came from
+//	@Security		BearerAuth
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"TMDB movie ID"
+//	@Param			language	query		string	false	"Result language, such as en-US"
+//	@Success		200			{object}	object	"TMDB movie details"
+//	@Header			200			{string}	X-Cache	"HIT, MISS or STALE"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse	"Movie not found on TMDB"
+//	@Failure		502			{object}	types.ErrorResponse	"TMDB could not be reached and nothing was cached"
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/search/movie/{id} [get]
+func (h *SearchHandler) GetMovieDetails(c *gin.Context) {
+	tmdbId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || tmdbId <= 0 {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "invalid tmdb id"))
+		return
+	}
+
+	details, cacheStatus, err := h.searchService.GetMovieDetails(c.Request.Context(), tmdbId, c.Query("language"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.Header("X-Cache", cacheStatus)
+	c.JSON(http.StatusOK, details)
+}
+
+// GetMovieCredits fetches the cast and crew of a movie on TMDB
+//
+//	@Summary		Get movie credits from TMDB
+//	@Description	Get the cast and crew of a movie on TMDB. Credits are cached, and a stale copy is returned when
+//	@Description	TMDB can't be reached. The X-Cache response header is HIT, MISS or STALE depending on where the
+//	@Description	credits came from
+//	@Security		BearerAuth
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"TMDB movie ID"
+//	@Param			language	query		string	false	"Result language, such as en-US"
+//	@Success		200			{object}	object	"TMDB movie credits"
+//	@Header			200			{string}	X-Cache	"HIT, MISS or STALE"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse	"Movie not found on TMDB"
+//	@Failure		502			{object}	types.ErrorResponse	"TMDB could not be reached and nothing was cached"
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/search/movie/{id}/credits [get]
+func (h *SearchHandler) GetMovieCredits(c *gin.Context) {
+	tmdbId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || tmdbId <= 0 {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "invalid tmdb id"))
+		return
+	}
+
+	credits, cacheStatus, err := h.searchService.GetMovieCredits(c.Request.Context(), tmdbId, c.Query("language"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.Header("X-Cache", cacheStatus)
+	c.JSON(http.StatusOK, credits)
+}
+
+// GetMovieImages fetches the posters, backdrops and logos of a movie on TMDB
+//
+//	@Summary		Get movie images from TMDB
+//	@Description	Get the posters, backdrops and logos of a movie on TMDB. Images are cached, and a stale copy is returned when
+//	@Description	TMDB can't be reached. The X-Cache response header is HIT, MISS or STALE depending on where the
+//	@Description	images came from
+//	@Security		BearerAuth
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"TMDB movie ID"
+//	@Param			language	query		string	false	"Result language, such as en-US"
+//	@Success		200			{object}	object	"TMDB movie images"
+//	@Header			200			{string}	X-Cache	"HIT, MISS or STALE"
+//	@Failure		400			{object}	types.ErrorResponse
+//	@Failure		404			{object}	types.ErrorResponse	"Movie not found on TMDB"
+//	@Failure		502			{object}	types.ErrorResponse	"TMDB could not be reached and nothing was cached"
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/search/movie/{id}/images [get]
+func (h *SearchHandler) GetMovieImages(c *gin.Context) {
+	tmdbId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || tmdbId <= 0 {
+		helpers.HandleAPIError(c, types.NewAPIError(http.StatusBadRequest, "invalid tmdb id"))
+		return
+	}
+
+	images, cacheStatus, err := h.searchService.GetMovieImages(c.Request.Context(), tmdbId, c.Query("language"))
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.Header("X-Cache", cacheStatus)
+	c.JSON(http.StatusOK, images)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SessionsHandler struct {
+	sessionsService *services.SessionsService
+}
+
+func NewSessionsHandler(sessionsService *services.SessionsService) *SessionsHandler {
+	return &SessionsHandler{
+		sessionsService: sessionsService,
+	}
+}
+
+// GetSessionsForUser returns the sessions a user is logged in with
+//
+//	@Summary		Get sessions
+//	@Description	Get the devices a user is logged in on, most recently used first.
+//	@Description	The session the request was made from is marked as current. Users can only view their own sessions
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"User UUID"
+//	@Success		200		{object}	types.SessionsResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/sessions [get]
+func (h *SessionsHandler) GetSessionsForUser(c *gin.Context) {
+	requesterUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	sessionId, err := helpers.GetSessionIdFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	sessions, err := h.sessionsService.GetSessionsForUser(c.Request.Context(), c.Param("uuid"), *requesterUuid, sessionId)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeOtherSessions logs a user out everywhere except the session the request was made from
+//
+//	@Summary		Log out other sessions
+//	@Description	Log out of every session except the one the request was made from.
+//	@Description	Access tokens issued to those sessions stop working straight away
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"User UUID"
+//	@Success		200		{object}	types.RevokedSessionsResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/sessions/others [delete]
+func (h *SessionsHandler) RevokeOtherSessions(c *gin.Context) {
+	requesterUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	sessionId, err := helpers.GetSessionIdFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	result, err := h.sessionsService.RevokeOtherSessions(c.Request.Context(), c.Param("uuid"), *requesterUuid, sessionId)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RevokeSession logs out a single session
+//
+//	@Summary		Revoke session
+//	@Description	Log out a session by ID. Access tokens issued to the session stop working straight away.
+//	@Description	Users can only revoke their own sessions
+//	@Tags			sessions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string							true	"Session ID"
+//	@Success		200	{object}	types.SessionRevokedResponse	"Session revoked successfully"
+//	@Failure		403	{object}	types.ErrorResponse
+//	@Failure		404	{object}	types.ErrorResponse
+//	@Failure		500	{object}	types.ErrorResponse
+//	@Router			/sessions/{id} [delete]
+func (h *SessionsHandler) RevokeSession(c *gin.Context) {
+	requesterUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	sessionId := c.Param("id")
+	err = h.sessionsService.RevokeSession(c.Request.Context(), sessionId, *requesterUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": "session revoked: " + sessionId})
+}
@@ -86,3 +86,77 @@ func (h *StatusesHandler) GetStatusesForUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// UpdateStatus renames a status
+//
+//	@Summary		Rename a status
+//	@Description	Rename a status by UUID. Only the owner of the status can rename it
+//	@Tags			statuses
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"Status UUID"
+//	@Param			body	body		types.UpdateStatusRequest	true	"New status label"
+//	@Success		200		{object}	types.StatusesResponse
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"A status with this label already exists"
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/statuses/{uuid} [patch]
+func (h *StatusesHandler) UpdateStatus(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	statusUuid := c.Param("uuid")
+	result, err := h.statusesService.UpdateStatus(c.Request.Context(), statusUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteStatus deletes a status and reassigns its list items
+//
+//	@Summary		Delete a status
+//	@Description	Delete a status by UUID. Every list item using the status is moved to the end of the target status
+//	@Description	column on its list in a single transaction. Only the owner of both statuses can delete a status
+//	@Tags			statuses
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid				path		string						true	"Status UUID"
+//	@Param			target_status_uuid	query		string						true	"UUID of the status that receives the list items"
+//	@Success		200					{object}	types.StatusDeletedResponse	"Status deleted successfully"
+//	@Failure		400					{object}	types.ErrorResponse
+//	@Failure		403					{object}	types.ErrorResponse
+//	@Failure		404					{object}	types.ErrorResponse
+//	@Failure		500					{object}	types.ErrorResponse
+//	@Router			/statuses/{uuid} [delete]
+func (h *StatusesHandler) DeleteStatus(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	statusUuid := c.Param("uuid")
+	err = h.statusesService.DeleteStatus(c.Request.Context(), statusUuid, c.Query("target_status_uuid"), *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": "status deleted: " + statusUuid})
+}
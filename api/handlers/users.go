@@ -21,7 +21,7 @@ func NewUsersHandler(usersService *services.UsersService) *UsersHandler {
 // GetAllUsers returns a paginated array of users
 //
 //	@Summary		Get all users
-//	@Description	Get all users in a paginated list
+//	@Description	Get all users in a paginated list. Requires the users:list permission
 //	@Security		BearerAuth
 //	@Tags			users
 //	@Accept			json
@@ -29,8 +29,9 @@ func NewUsersHandler(usersService *services.UsersService) *UsersHandler {
 //	@Param			page		query		int	false	"Page"
 //	@Param			page_size	query		int	false	"Page size"
 //	@Success		200			{object}	types.PaginatedUsersResponse
+//	@Failure		403			{object}	types.ErrorResponse
 //	@Failure		500			{object}	types.ErrorResponse
-//	@Router			/users [get]
+//	@Router			/admin/users [get]
 func (h *UsersHandler) GetAllUsers(c *gin.Context) {
 	pagination, err := helpers.ValidatePagination(c)
 	if err != nil {
@@ -74,7 +75,7 @@ func (h *UsersHandler) GetUserByUuid(c *gin.Context) {
 // UpdateUser updates user details
 //
 //	@Summary		Update user details
-//	@Description	Update user details by UUID
+//	@Description	Update user details by UUID. Users can update their own details, and users with the users:edit permission can update anyone's
 //	@Security		BearerAuth
 //	@Tags			users
 //	@Accept			json
@@ -83,9 +84,17 @@ func (h *UsersHandler) GetUserByUuid(c *gin.Context) {
 //	@Param			body	body		types.UpdateUserRequest	true	"User details to update"
 //	@Success		200		{object}	types.UserResponse
 //	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
 //	@Failure		500		{object}	types.ErrorResponse
 //	@Router			/users/{uuid} [patch]
 func (h *UsersHandler) UpdateUser(c *gin.Context) {
+	actor, err := helpers.GetActorFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
 	userUuid := c.Param("uuid")
 	var req types.UpdateUserRequest
 
@@ -94,7 +103,7 @@ func (h *UsersHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.usersService.UpdateUser(c.Request.Context(), userUuid, req)
+	user, err := h.usersService.UpdateUser(c.Request.Context(), userUuid, *actor, req)
 	if err != nil {
 		helpers.HandleAPIError(c, err)
 		return
@@ -106,18 +115,28 @@ func (h *UsersHandler) UpdateUser(c *gin.Context) {
 // DeleteUser deletes a user from the database by UUID
 //
 //	@Summary		Delete user
-//	@Description	Delete a user by UUID
+//	@Description	Delete a user by UUID. Users can delete their own account, and users with the users:delete permission can delete anyone's.
+//	@Description	The last admin can't be deleted
 //	@Security		BearerAuth
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
 //	@Param			uuid	path		string						true	"User UUID"
 //	@Success		200		{object}	types.UserDeletedResponse	"User deleted successfully"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse	"User is the last admin"
 //	@Failure		500		{object}	types.ErrorResponse
 //	@Router			/users/{uuid} [delete]
 func (h *UsersHandler) DeleteUser(c *gin.Context) {
+	actor, err := helpers.GetActorFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
 	userUuid := c.Param("uuid")
-	err := h.usersService.DeleteUser(c.Request.Context(), userUuid)
+	err = h.usersService.DeleteUser(c.Request.Context(), userUuid, *actor)
 	if err != nil {
 		helpers.HandleAPIError(c, err)
 		return
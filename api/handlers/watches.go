@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type WatchesHandler struct {
+	watchesService *services.WatchesService
+}
+
+func NewWatchesHandler(watchesService *services.WatchesService) *WatchesHandler {
+	return &WatchesHandler{
+		watchesService: watchesService,
+	}
+}
+
+// LogWatch adds a diary entry for the authenticated user
+//
+//	@Summary		Log a watch
+//	@Description	Add a diary entry for a viewing of an item. Optionally move the item's card on one of the
+//	@Description	user's lists to a status, such as "watched", at the same time
+//	@Tags			watches
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		types.LogWatchRequest		true	"Watch details"
+//	@Success		201		{object}	types.WatchLoggedResponse	"Watch logged successfully"
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		409		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/watches [post]
+func (h *WatchesHandler) LogWatch(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.LogWatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	result, err := h.watchesService.LogWatch(c.Request.Context(), *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetWatch returns a diary entry by UUID
+//
+//	@Summary		Get watch by UUID
+//	@Description	Get a diary entry by UUID
+//	@Tags			watches
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string	true	"Watch UUID"
+//	@Success		200		{object}	types.WatchResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/watches/{uuid} [get]
+func (h *WatchesHandler) GetWatch(c *gin.Context) {
+	watchUuid := c.Param("uuid")
+	watch, err := h.watchesService.GetWatch(c.Request.Context(), watchUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"watch": watch})
+}
+
+// GetWatchesForUser fetches a user's diary
+//
+//	@Summary		Fetch a user's diary
+//	@Description	Fetch the diary entries of a user as a paginated list, most recently watched first
+//	@Tags			watches
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid		path		string	true	"User UUID"
+//	@Param			page		query		int		false	"Page"
+//	@Param			page_size	query		int		false	"Page size"
+//	@Success		200			{object}	types.PaginatedWatchesResponse
+//	@Failure		404			{object}	types.ErrorResponse
+//	@Failure		500			{object}	types.ErrorResponse
+//	@Router			/users/{uuid}/watches [get]
+func (h *WatchesHandler) GetWatchesForUser(c *gin.Context) {
+	pagination, err := helpers.ValidatePagination(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	userUuid := c.Param("uuid")
+	result, err := h.watchesService.GetWatchesForUser(c.Request.Context(), userUuid, pagination)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateWatch updates a diary entry
+//
+//	@Summary		Update watch
+//	@Description	Replace the details of a diary entry. Only the user who logged the watch can update it
+//	@Tags			watches
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"Watch UUID"
+//	@Param			body	body		types.UpdateWatchRequest	true	"Watch details"
+//	@Success		200		{object}	types.WatchResponse
+//	@Failure		400		{object}	types.ErrorResponse
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/watches/{uuid} [patch]
+func (h *WatchesHandler) UpdateWatch(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	var req types.UpdateWatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.HandleValidationError(c, err)
+		return
+	}
+
+	watchUuid := c.Param("uuid")
+	watch, err := h.watchesService.UpdateWatch(c.Request.Context(), watchUuid, *userUuid, req)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"watch": watch})
+}
+
+// DeleteWatch deletes a diary entry
+//
+//	@Summary		Delete watch
+//	@Description	Delete a diary entry by UUID. Only the user who logged the watch can delete it
+//	@Tags			watches
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			uuid	path		string						true	"Watch UUID"
+//	@Success		200		{object}	types.WatchDeletedResponse	"Watch deleted successfully"
+//	@Failure		403		{object}	types.ErrorResponse
+//	@Failure		404		{object}	types.ErrorResponse
+//	@Failure		500		{object}	types.ErrorResponse
+//	@Router			/watches/{uuid} [delete]
+func (h *WatchesHandler) DeleteWatch(c *gin.Context) {
+	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	watchUuid := c.Param("uuid")
+	err = h.watchesService.DeleteWatch(c.Request.Context(), watchUuid, *userUuid)
+	if err != nil {
+		helpers.HandleAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": "watch deleted: " + watchUuid})
+}
@@ -1,17 +1,13 @@
 package helpers
 
 import (
-	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
 	"codeberg.org/sporiff/eigakanban/types"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 	"net/http"
-	"time"
 )
 
 // HashPassword creates a hashed password from a provided password string
@@ -26,50 +22,22 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-// GenerateAccessToken generates an access token for the user
-func GenerateAccessToken(user interface{}) (string, string, error) {
-	var uuid pgtype.UUID
-	var superuser bool
-
-	// Use type assertions to handle both types
-	switch u := user.(type) {
-	case queries.GetExistingUserRow:
-		uuid = u.Uuid
-		superuser = u.Superuser
-	case queries.GetUserByUuidRow:
-		uuid = u.Uuid
-		superuser = u.Superuser
-	default:
-		return "", "", errors.New("unsupported user type")
-	}
-
-	expiryDate := time.Now().UTC().Add(1 * time.Hour)
-
-	// Generate a JWT token
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_uuid":   uuid.String(),
-		"superuser":   superuser,
-		"expiry_date": expiryDate.Unix(),
-	})
-
-	// TODO: Look into getting users to set their own key
-	accessTokenString, err := accessToken.SignedString([]byte("your-secret-key"))
-	if err != nil {
-		return "", "", errors.New("error generating access token")
-	}
-
-	return accessTokenString, expiryDate.String(), nil
-}
-
-// GenerateRefreshToken creates a new refresh token for logged-in users
-func GenerateRefreshToken(length int) (*string, error) {
+// GenerateToken creates a random token of length bytes, hex encoded, for refresh tokens, links and other secrets
+func GenerateToken(length int) (*string, error) {
 	b := make([]byte, length)
 	_, err := rand.Read(b)
 	if err != nil {
 		return nil, err
 	}
-	refreshToken := hex.EncodeToString(b)
-	return &refreshToken, nil
+	token := hex.EncodeToString(b)
+	return &token, nil
+}
+
+// HashToken hashes a token made by GenerateToken for storage. The tokens are long and random, so a fast hash
+// is enough to keep them from being used if the database leaks
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
 }
 
 // ValidateUserUuidFromClaims validates the user UUID from the claims is present and of the correct type
@@ -89,6 +57,16 @@ func ValidateUserUuidFromClaims(c *gin.Context) (*string, error) {
 	}
 }
 
+// GetActorFromClaims returns the user making the request and the permissions their roles grant
+func GetActorFromClaims(c *gin.Context) (*types.Actor, error) {
+	userUuid, err := ValidateUserUuidFromClaims(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Actor{UserUuid: *userUuid, Permissions: c.GetStringSlice("permissions")}, nil
+}
+
 // GetRefreshToken retrieves the refresh token from the browser cookies.
 // Falls back to using the Refresh-Token header for API clients
 func GetRefreshToken(c *gin.Context) (*string, error) {
@@ -106,3 +84,21 @@ func GetRefreshToken(c *gin.Context) (*string, error) {
 
 	return &refreshToken, nil
 }
+
+// GetSessionClient describes the client making a request, for recording against its session
+func GetSessionClient(c *gin.Context, deviceName string) types.SessionClient {
+	return types.SessionClient{
+		DeviceName: deviceName,
+		UserAgent:  c.Request.UserAgent(),
+		IPAddress:  c.ClientIP(),
+	}
+}
+
+// GetSessionIdFromClaims returns the session the access token was issued for
+func GetSessionIdFromClaims(c *gin.Context) (string, error) {
+	sessionId := c.GetString("session_id")
+	if sessionId == "" {
+		return "", types.NewAPIError(http.StatusBadRequest, "missing session id")
+	}
+	return sessionId, nil
+}
@@ -3,6 +3,7 @@ package helpers
 import (
 	"codeberg.org/sporiff/eigakanban/types"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"net/http"
@@ -53,3 +54,20 @@ func HandleAPIError(c *gin.Context, err error) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "unexpected error occurred"})
 	}
 }
+
+// LimitRequestBody stops more than maxBytes of the request body from being read, so that a large body
+// can't use up the server's memory or disk
+func LimitRequestBody(c *gin.Context, maxBytes int64) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+}
+
+// RequestTooLargeError returns a 413 error if err came from reading past the limit set by LimitRequestBody,
+// or nil if it didn't
+func RequestTooLargeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return types.NewAPIError(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body can't be larger than %d MB", maxBytesErr.Limit>>20))
+	}
+
+	return nil
+}
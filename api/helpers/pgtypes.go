@@ -5,8 +5,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// pgDateLayout is the layout used to read and write postgres dates
+const pgDateLayout = "2006-01-02"
+
 func MakePgString(s string) pgtype.Text {
 	if len(s) == 0 {
 		return pgtype.Text{Valid: false}
@@ -37,3 +42,85 @@ func AssignPgtypeText(dest *pgtype.Text, src *string) {
 		dest.Valid = false
 	}
 }
+
+// MakePgInt4 converts an optional integer to a postgres integer, treating nil as NULL
+func MakePgInt4(i *int32) pgtype.Int4 {
+	if i == nil {
+		return pgtype.Int4{Valid: false}
+	}
+	return pgtype.Int4{Int32: *i, Valid: true}
+}
+
+// PgInt4Pointer converts a postgres integer to an optional integer, treating NULL as nil
+func PgInt4Pointer(i pgtype.Int4) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
+
+// MakePgDate parses a YYYY-MM-DD date, treating empty or malformed dates as NULL
+func MakePgDate(s string) pgtype.Date {
+	date, err := time.Parse(pgDateLayout, s)
+	if err != nil {
+		return pgtype.Date{Valid: false}
+	}
+	return pgtype.Date{Time: date, Valid: true}
+}
+
+// PgDatePointer formats a postgres date as YYYY-MM-DD, treating NULL as nil
+func PgDatePointer(d pgtype.Date) *string {
+	if !d.Valid {
+		return nil
+	}
+	date := d.Time.Format(pgDateLayout)
+	return &date
+}
+
+// PgTextPointer converts postgres text to an optional string, treating NULL as nil
+func PgTextPointer(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+// PgInt8Pointer converts a postgres bigint to an optional integer, treating NULL as nil
+func PgInt8Pointer(i pgtype.Int8) *int64 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int64
+}
+
+// PgTimestamptzPointer converts a postgres timestamp to an optional time, treating NULL as nil
+func PgTimestamptzPointer(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// MakePgNumeric converts an optional float to a postgres numeric, treating nil as NULL
+func MakePgNumeric(f *float64) pgtype.Numeric {
+	var n pgtype.Numeric
+	if f == nil {
+		return n
+	}
+	if err := n.Scan(strconv.FormatFloat(*f, 'f', -1, 64)); err != nil {
+		return pgtype.Numeric{Valid: false}
+	}
+	return n
+}
+
+// PgNumericPointer converts a postgres numeric to an optional float, treating NULL as nil
+func PgNumericPointer(n pgtype.Numeric) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	f, err := n.Float64Value()
+	if err != nil || !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
@@ -0,0 +1,182 @@
+package helpers
+
+import (
+	"errors"
+	"strings"
+)
+
+// Rank keys are base-36 fractions written without the leading "0.", so that
+// ordering keys as plain strings orders the list items they belong to.
+// Keys never end in the zero digit, which guarantees that there is always
+// room for another key in front of any existing key.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const (
+	// rankWidth is the number of digits used when appending, prepending and spreading keys
+	rankWidth = 6
+	// rankStep is the gap left between keys when appending, prepending and spreading keys
+	rankStep = 36 * 36
+	// rankMaxWidth is the widest key that can be decoded without overflowing an int64
+	rankMaxWidth = 12
+	// MaxRankLength is the key length after which a column should be rebalanced
+	MaxRankLength = 24
+)
+
+var errInvalidRank = errors.New("invalid rank")
+
+// RankBetween returns a key that sorts between prev and next.
+// Pass an empty string for prev to get a key before next, and an empty
+// string for next to get a key after prev.
+func RankBetween(prev, next string) (string, error) {
+	if (prev != "" && !ValidRank(prev)) || (next != "" && !ValidRank(next)) {
+		return "", errInvalidRank
+	}
+
+	if prev != "" && next != "" && prev >= next {
+		return "", errInvalidRank
+	}
+
+	switch {
+	case prev == "" && next == "":
+		return encodeRank(rankStep, rankWidth), nil
+	case next == "":
+		return rankAfter(prev), nil
+	case prev == "":
+		return rankBefore(next), nil
+	default:
+		return rankMidpoint(prev, next), nil
+	}
+}
+
+// SpreadRanks returns count evenly spaced keys in ascending order
+func SpreadRanks(count int) []string {
+	width := rankWidth
+	for int64(count+1)*rankStep >= rankCapacity(width) {
+		width++
+	}
+
+	ranks := make([]string, count)
+	for i := range ranks {
+		ranks[i] = encodeRank(int64(i+1)*rankStep, width)
+	}
+
+	return ranks
+}
+
+// ValidRank reports whether a key only uses rank digits and doesn't end in a zero
+func ValidRank(rank string) bool {
+	if rank == "" || rank[len(rank)-1] == rankDigits[0] {
+		return false
+	}
+
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rankAfter steps forward from prev, falling back to a midpoint when the fixed width is exhausted
+func rankAfter(prev string) string {
+	value := decodeRank(prev, rankWidth) + rankStep
+	if value < rankCapacity(rankWidth) {
+		return encodeRank(value, rankWidth)
+	}
+
+	return rankMidpoint(prev, "")
+}
+
+// rankBefore steps back from next, widening the key when there is no room left at the current width
+func rankBefore(next string) string {
+	width := rankWidth
+	for decodeRank(next, width) <= rankStep {
+		if width+2 > rankMaxWidth {
+			return rankMidpoint("", next)
+		}
+		width += 2
+	}
+
+	return encodeRank(decodeRank(next, width)-rankStep, width)
+}
+
+// rankMidpoint returns a key between prev and next, where an empty next means no upper bound
+func rankMidpoint(prev, next string) string {
+	if next != "" {
+		// Keep any prefix the two keys share
+		n := 0
+		for n < len(next) && rankDigitAt(prev, n) == next[n] {
+			n++
+		}
+		if n > 0 {
+			suffix := ""
+			if n < len(prev) {
+				suffix = prev[n:]
+			}
+			return next[:n] + rankMidpoint(suffix, next[n:])
+		}
+	}
+
+	digitPrev := 0
+	if prev != "" {
+		digitPrev = strings.IndexByte(rankDigits, prev[0])
+	}
+
+	digitNext := len(rankDigits)
+	if next != "" {
+		digitNext = strings.IndexByte(rankDigits, next[0])
+	}
+
+	if digitNext-digitPrev > 1 {
+		return string(rankDigits[(digitPrev+digitNext+1)/2])
+	}
+
+	// The first digits are consecutive, so look further along the keys
+	if len(next) > 1 {
+		return next[:1]
+	}
+
+	suffix := ""
+	if len(prev) > 1 {
+		suffix = prev[1:]
+	}
+
+	return string(rankDigits[digitPrev]) + rankMidpoint(suffix, "")
+}
+
+// rankDigitAt returns the digit at position i of a key, treating missing digits as zero
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+// rankCapacity returns the number of values that fit in a key of the given width
+func rankCapacity(width int) int64 {
+	capacity := int64(1)
+	for i := 0; i < width; i++ {
+		capacity *= int64(len(rankDigits))
+	}
+	return capacity
+}
+
+// decodeRank reads the first width digits of a key as a number
+func decodeRank(rank string, width int) int64 {
+	var value int64
+	for i := 0; i < width; i++ {
+		value = value*int64(len(rankDigits)) + int64(strings.IndexByte(rankDigits, rankDigitAt(rank, i)))
+	}
+	return value
+}
+
+// encodeRank writes a number as a key of the given width with trailing zeros removed
+func encodeRank(value int64, width int) string {
+	digits := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = rankDigits[value%int64(len(rankDigits))]
+		value /= int64(len(rankDigits))
+	}
+	return strings.TrimRight(string(digits), rankDigits[:1])
+}
@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+		want string
+	}{
+		{"empty column", "", "", "0001"},
+		{"after last", "1", "", "1001"},
+		{"after widest key", "zzzzzz", "", "zzzzzzi"},
+		{"after last digit", "z", "", "z001"},
+		{"before first", "", "1", "0zzz"},
+		{"before small key", "", "01", "00zz"},
+		{"before smallest six digit key", "", "000001", "000000zz"},
+		{"before key too small to step back from", "", "00000000000001", "00000000000000i"},
+		{"adjacent keys", "1", "2", "1i"},
+		{"adjacent last digits", "y", "z", "yi"},
+		{"adjacent keys with shared prefix", "a1", "a2", "a1i"},
+		{"keys that differ only in length", "a", "a1", "a0i"},
+		{"keys that differ only in length by two", "a", "a01", "a00i"},
+		{"longer keys that differ only in length", "zz", "zz1", "zz0i"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.prev, tt.next)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			checkRankBetween(t, tt.prev, got, tt.next)
+		})
+	}
+}
+
+func TestRankBetweenInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{"equal keys", "a", "a"},
+		{"keys out of order", "b", "a"},
+		{"longer key first", "a1", "a"},
+		{"trailing zero", "a0", "b"},
+		{"upper case", "A", "b"},
+		{"invalid character", "", "a-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := RankBetween(tt.prev, tt.next); err == nil {
+				t.Errorf("got %q, want an error", got)
+			}
+		})
+	}
+}
+
+func TestRankBetweenRepeated(t *testing.T) {
+	// Each test keeps inserting at the same place, which is where keys grow the quickest
+	tests := []struct {
+		name     string
+		position func(count int) int
+	}{
+		{"at the start", func(int) int { return 0 }},
+		{"at the end", func(count int) int { return count }},
+		{"after the first", func(count int) int { return min(1, count) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ranks []string
+
+			for i := 0; i < 500; i++ {
+				at := tt.position(len(ranks))
+
+				prev, next := "", ""
+				if at > 0 {
+					prev = ranks[at-1]
+				}
+				if at < len(ranks) {
+					next = ranks[at]
+				}
+
+				rank, err := RankBetween(prev, next)
+				if err != nil {
+					t.Fatalf("insert %d between %q and %q: %v", i, prev, next, err)
+				}
+				checkRankBetween(t, prev, rank, next)
+
+				ranks = slices.Insert(ranks, at, rank)
+			}
+		})
+	}
+}
+
+func TestSpreadRanks(t *testing.T) {
+	for _, count := range []int{0, 1, 10, 2000} {
+		ranks := SpreadRanks(count)
+		if len(ranks) != count {
+			t.Fatalf("got %d ranks, want %d", len(ranks), count)
+		}
+
+		for i, rank := range ranks {
+			if !ValidRank(rank) {
+				t.Fatalf("rank %q is invalid", rank)
+			}
+			if i > 0 && ranks[i-1] >= rank {
+				t.Fatalf("ranks %q and %q are out of order", ranks[i-1], rank)
+			}
+		}
+	}
+}
+
+// checkRankBetween checks that a key is valid and sorts between prev and next
+func checkRankBetween(t *testing.T, prev, rank, next string) {
+	t.Helper()
+
+	if !ValidRank(rank) {
+		t.Errorf("rank %q is invalid", rank)
+	}
+
+	if prev != "" && rank <= prev {
+		t.Errorf("rank %q doesn't sort after %q", rank, prev)
+	}
+
+	if next != "" && rank >= next {
+		t.Errorf("rank %q doesn't sort before %q", rank, next)
+	}
+}
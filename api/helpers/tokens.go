@@ -0,0 +1,152 @@
+package helpers
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/types"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// SigningKey is a key that access tokens are signed or verified with. Keys loaded from a public key
+// can only verify tokens, so that tokens signed elsewhere or by a retired key are still accepted
+type SigningKey struct {
+	// ID is sent in the kid header of each token so that the right key can be found to verify it
+	ID     string
+	Method jwt.SigningMethod
+	// SignKey is the secret or private key used to sign tokens, or nil for verify-only keys
+	SignKey any
+	// VerifyKey is the secret or public key used to verify tokens
+	VerifyKey any
+}
+
+// TokenKeys signs access tokens with the active key and verifies tokens signed with any of its keys.
+// Rotating a key means adding a new key, making it active, and removing the old key once the tokens
+// it signed have expired
+type TokenKeys struct {
+	active   SigningKey
+	keys     map[string]SigningKey
+	methods  []string
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+func NewTokenKeys(keys []SigningKey, activeKeyId, issuer, audience string, ttl time.Duration) (*TokenKeys, error) {
+	tokenKeys := TokenKeys{
+		keys:     make(map[string]SigningKey, len(keys)),
+		issuer:   issuer,
+		audience: audience,
+		ttl:      ttl,
+	}
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("signing keys need an id")
+		}
+		if _, ok := tokenKeys.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id %q", key.ID)
+		}
+		tokenKeys.keys[key.ID] = key
+		tokenKeys.methods = append(tokenKeys.methods, key.Method.Alg())
+	}
+
+	active, ok := tokenKeys.keys[activeKeyId]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q not found", activeKeyId)
+	}
+	if active.SignKey == nil {
+		return nil, fmt.Errorf("active signing key %q can't sign tokens", activeKeyId)
+	}
+	tokenKeys.active = active
+
+	return &tokenKeys, nil
+}
+
+// GenerateAccessToken generates an access token for the user's session, returning the token and its expiry date
+func (k *TokenKeys) GenerateAccessToken(user interface{}, sessionId string) (string, string, error) {
+	var uuid pgtype.UUID
+
+	// Use type assertions to handle both types
+	switch u := user.(type) {
+	case queries.GetExistingUserRow:
+		uuid = u.Uuid
+	case queries.GetUserByUuidRow:
+		uuid = u.Uuid
+	default:
+		return "", "", errors.New("unsupported user type")
+	}
+
+	now := time.Now().UTC()
+	expiryDate := now.Add(k.ttl)
+
+	claims := types.TokenClaims{
+		SessionID: sessionId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   uuid.String(),
+			Issuer:    k.issuer,
+			Audience:  jwt.ClaimStrings{k.audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiryDate),
+		},
+	}
+
+	accessToken := jwt.NewWithClaims(k.active.Method, claims)
+	accessToken.Header["kid"] = k.active.ID
+
+	accessTokenString, err := accessToken.SignedString(k.active.SignKey)
+	if err != nil {
+		return "", "", errors.New("error generating access token")
+	}
+
+	return accessTokenString, expiryDate.String(), nil
+}
+
+// ParseAccessToken verifies an access token against the key named in its kid header and checks its
+// registered claims. Expired tokens return an error matching jwt.ErrTokenExpired
+func (k *TokenKeys) ParseAccessToken(tokenString string) (*types.TokenClaims, error) {
+	var claims types.TokenClaims
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, k.verifyKey,
+		jwt.WithValidMethods(k.methods),
+		jwt.WithIssuer(k.issuer),
+		jwt.WithAudience(k.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	if claims.SessionID == "" {
+		return nil, errors.New("token has no session")
+	}
+
+	return &claims, nil
+}
+
+// verifyKey finds the key a token was signed with. The token's algorithm must match the key's, so a
+// public key can never be used as an HMAC secret
+func (k *TokenKeys) verifyKey(token *jwt.Token) (interface{}, error) {
+	keyId, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, errors.New("token has no key id")
+	}
+
+	key, ok := k.keys[keyId]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", keyId)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+
+	return key.VerifyKey, nil
+}
@@ -0,0 +1,172 @@
+package helpers
+
+import (
+	"encoding/base32"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key used by the test vectors in RFC 6238, appendix B
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTotpRfc6238Vectors(t *testing.T) {
+	// The RFC lists eight digit codes. Six digit codes are their last six digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		step, ok := ValidateTotp(rfc6238Secret, tt.code, time.Unix(tt.unix, 0))
+		if !ok {
+			t.Errorf("code %s at %d was rejected", tt.code, tt.unix)
+			continue
+		}
+
+		if want := tt.unix / totpPeriod; step != want {
+			t.Errorf("code %s at %d: got step %d, want %d", tt.code, tt.unix, step, want)
+		}
+	}
+}
+
+func TestTotpWindow(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"two steps behind", -2, false},
+		{"one step behind", -1, true},
+		{"current step", 0, true},
+		{"one step ahead", 1, true},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTotp(rfc6238Secret, totpCode(key, current+tt.offset), now)
+			if ok != tt.ok {
+				t.Fatalf("got %v, want %v", ok, tt.ok)
+			}
+
+			if ok && step != current+tt.offset {
+				t.Errorf("got step %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateTotpInput(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		ok     bool
+	}{
+		{"spaces", rfc6238Secret, "287 082", true},
+		{"lower case secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", true},
+		{"wrong code", rfc6238Secret, "287083", false},
+		{"too short", rfc6238Secret, "28708", false},
+		{"eight digits", rfc6238Secret, "94287082", false},
+		{"empty", rfc6238Secret, "", false},
+		{"invalid secret", "not base32!", "287082", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTotp(tt.secret, tt.code, now); ok != tt.ok {
+				t.Errorf("got %v, want %v", ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGenerateTotpSecret(t *testing.T) {
+	secret, err := GenerateTotpSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q isn't base32: %v", secret, err)
+	}
+
+	if len(key) != 20 {
+		t.Errorf("got a %d byte key, want 20", len(key))
+	}
+}
+
+func TestTotpURI(t *testing.T) {
+	uri, err := url.Parse(TotpURI("eigakanban", "film fan", rfc6238Secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" {
+		t.Errorf("got %s://%s, want otpauth://totp", uri.Scheme, uri.Host)
+	}
+
+	if uri.Path != "/eigakanban:film fan" {
+		t.Errorf("got label %q", uri.Path)
+	}
+
+	query := uri.Query()
+	if query.Get("secret") != rfc6238Secret || query.Get("issuer") != "eigakanban" {
+		t.Errorf("got query %q", uri.RawQuery)
+	}
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	format := regexp.MustCompile(`^[` + recoveryCodeAlphabet + `]{5}-[` + recoveryCodeAlphabet + `]{5}$`)
+	seen := make(map[string]bool)
+
+	for i := 0; i < 100; i++ {
+		code, err := GenerateRecoveryCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !format.MatchString(code) {
+			t.Fatalf("code %q isn't in the form xxxxx-xxxxx", code)
+		}
+
+		if seen[code] {
+			t.Fatalf("code %q was generated twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("abcde-fghjk")
+
+	for _, code := range []string{"abcdefghjk", "ABCDE-FGHJK", "abcde fghjk", " abcde-fghjk "} {
+		if got := HashRecoveryCode(code); got != want {
+			t.Errorf("%q hashed differently to abcde-fghjk", code)
+		}
+	}
+
+	if HashRecoveryCode("abcde-fghjm") == want {
+		t.Error("different codes have the same hash")
+	}
+}
@@ -2,8 +2,11 @@ package main
 
 import (
 	"codeberg.org/sporiff/eigakanban/config"
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
 	_ "codeberg.org/sporiff/eigakanban/docs"
 	"codeberg.org/sporiff/eigakanban/routes"
+	"codeberg.org/sporiff/eigakanban/services"
+	"context"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -44,14 +47,46 @@ func main() {
 	}
 	defer db.Close()
 
-	tmdbClient, err := config.LoadTmdbConfig()
+	tokenKeys, err := config.LoadTokenKeys()
 	if err != nil {
-		log.Fatalf("Couldn't set up TMDB client: %v", err)
+		log.Fatalf("Couldn't load access token keys: %v", err)
 	}
 
+	mailer, err := config.LoadMailer()
+	if err != nil {
+		log.Fatalf("Couldn't set up mailer: %v", err)
+	}
+
+	metadataProvider, err := config.LoadMetadataProvider()
+	if err != nil {
+		log.Fatalf("Couldn't set up metadata provider: %v", err)
+	}
+
+	// Respace board columns in the background when their rank keys grow too long
+	rankRebalancer := services.NewRankRebalancer(queries.New(db), db)
+	go rankRebalancer.Run(context.Background())
+
+	// Cache metadata responses and purge entries that are too old to serve
+	cacheConfig := config.LoadTmdbCacheConfig()
+	metadataCache := services.NewMetadataCache(queries.New(db), metadataProvider, cacheConfig.TTL, cacheConfig.MaxStale)
+	go metadataCache.Run(context.Background())
+
+	// Keep TMDB metadata up to date in the background
+	refreshConfig := config.LoadTmdbRefreshConfig()
+	metadataRefresher := services.NewMetadataRefresher(queries.New(db), metadataProvider, refreshConfig.MaxAge, refreshConfig.RequestInterval)
+	go metadataRefresher.Run(context.Background())
+
+	// Run imports in the background, resuming any that were interrupted
+	importJobRunner := services.NewImportJobRunner(queries.New(db), db, metadataCache, rankRebalancer)
+	go importJobRunner.Run(context.Background())
+
+	// Delete refresh and password reset tokens once they have expired
+	tokenPurger := services.NewTokenPurger(queries.New(db))
+	go tokenPurger.Run(context.Background())
+
 	router := gin.Default()
 	router.Use(cors.Default())
-	routes.SetupRoutes(router, db, tmdbClient)
+	routes.SetupRoutes(router, db, metadataCache, rankRebalancer, metadataRefresher, importJobRunner, tokenKeys, mailer, config.LoadAccountEmailConfig())
 
 	router.GET("/docs", func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
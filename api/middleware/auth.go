@@ -2,33 +2,56 @@ package middleware
 
 import (
 	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"net/http"
+	"slices"
 	"strings"
-	"time"
 )
 
 type AuthMiddlewareHandler struct {
-	db *pgxpool.Pool
-	q  *queries.Queries
+	db               *pgxpool.Pool
+	q                *queries.Queries
+	tokenKeys        *helpers.TokenKeys
+	unverifiedAccess types.UnverifiedAccess
 }
 
-func NewAuthMiddlewareHandler(db *pgxpool.Pool) *AuthMiddlewareHandler {
+func NewAuthMiddlewareHandler(db *pgxpool.Pool, tokenKeys *helpers.TokenKeys, unverifiedAccess types.UnverifiedAccess) *AuthMiddlewareHandler {
 	return &AuthMiddlewareHandler{
-		db: db,
-		q:  queries.New(db),
+		db:               db,
+		q:                queries.New(db),
+		tokenKeys:        tokenKeys,
+		unverifiedAccess: unverifiedAccess,
 	}
 }
 
+// AuthRequired only lets through requests with a valid access token. Users whose email isn't verified may
+// be limited in what they can do
 func (h *AuthMiddlewareHandler) AuthRequired() gin.HandlerFunc {
+	return h.authRequired(true)
+}
+
+// AccountAuthRequired is AuthRequired for the routes users manage their own account with, such as changing
+// their email or password and deleting their account. Users whose email isn't verified can always use these,
+// so that they can correct a wrong address or leave without verifying it first
+func (h *AuthMiddlewareHandler) AccountAuthRequired() gin.HandlerFunc {
+	return h.authRequired(false)
+}
+
+func (h *AuthMiddlewareHandler) authRequired(checkVerified bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract the access token from the Authorization header
-		token, err := h.extractAuthToken(c)
+		claims, err := h.extractAuthToken(c)
 		if err != nil {
-			if errors.Is(err, jwt.ErrSignatureInvalid) {
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+				return
+			}
+			if errors.Is(err, jwt.ErrTokenSignatureInvalid) {
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token signature"})
 				return
 			}
@@ -37,57 +60,93 @@ func (h *AuthMiddlewareHandler) AuthRequired() gin.HandlerFunc {
 			return
 		}
 
-		if !token.Valid {
-			// If the token is invalid reject the request
+		// Access tokens stop working as soon as their session is logged out
+		sessionId, err := helpers.ValidateAndConvertUUID(claims.SessionID)
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			return
 		}
 
-		// Check if the token is expired
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			exp := claims["expiry_date"].(float64)
-			if time.Now().Unix() > int64(exp) {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
-				return
-			}
+		active, err := h.q.IsSessionActive(c.Request.Context(), *sessionId)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error checking session"})
+			return
 		}
 
-		var userUuid string
-		var superUser bool
+		if !active {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session revoked"})
+			return
+		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			claimsUuid, ok := claims["user_uuid"]
-			if !ok {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid user UUID in token"})
-				return
-			}
-			if claimsUuid.(string) == "" {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid user UUID in token"})
+		userUuid, err := helpers.ValidateAndConvertUUID(claims.Subject)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+
+		// Users whose email isn't verified may be limited to requests that don't change anything. The email
+		// can be unverified after logging in if it is changed, so this is checked on every request too
+		if checkVerified && h.limitsUnverified(c.Request.Method) {
+			verified, err := h.q.IsEmailVerified(c.Request.Context(), *userUuid)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error checking email verification"})
 				return
 			}
 
-			claimsSuperUser, ok := claims["superuser"]
-			if !ok {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			if !verified {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Email address not verified"})
 				return
 			}
-
-			superUser = claimsSuperUser.(bool)
-			userUuid = claimsUuid.(string)
 		}
 
-		// Store the user UUID in the context
-		if token.Valid {
-			c.Set("user_uuid", userUuid)
-			c.Set("superuser", superUser)
+		// Permissions are read on every request so that removing a role takes effect straight away
+		permissions, err := h.q.GetPermissionsForUser(c.Request.Context(), *userUuid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "error checking permissions"})
+			return
 		}
 
+		// Store the user UUID, session and permissions in the context
+		c.Set("user_uuid", claims.Subject)
+		c.Set("session_id", claims.SessionID)
+		c.Set("permissions", permissions)
+
 		// The token is valid
 		c.Next()
 	}
 }
 
-func (h *AuthMiddlewareHandler) extractAuthToken(c *gin.Context) (*jwt.Token, error) {
+// limitsUnverified reports whether requests with a method are refused for users whose email isn't verified
+func (h *AuthMiddlewareHandler) limitsUnverified(method string) bool {
+	switch h.unverifiedAccess {
+	case types.UnverifiedAccessNone:
+		return true
+	case types.UnverifiedAccessReadOnly:
+		return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+	default:
+		return false
+	}
+}
+
+// RequirePermission only lets through users whose roles grant a permission. It must run after AuthRequired
+func (h *AuthMiddlewareHandler) RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("user_uuid"); !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "you are not authorized to access this resource"})
+			return
+		}
+
+		if !slices.Contains(c.GetStringSlice("permissions"), permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you do not have the " + permission + " permission"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// extractAuthToken reads the access token from the Authorization header and verifies it
+func (h *AuthMiddlewareHandler) extractAuthToken(c *gin.Context) (*types.TokenClaims, error) {
 	// Extract the access token from the Authorization header
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
@@ -100,13 +159,6 @@ func (h *AuthMiddlewareHandler) extractAuthToken(c *gin.Context) (*jwt.Token, er
 		return nil, errors.New("invalid token format")
 	}
 
-	// Parse and validate the access token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte("your-secret-key"), nil // TODO: Look into using the .env file for this
-	})
-	if err != nil {
-		return nil, errors.New("invalid token")
-	}
-
-	return token, nil
+	// Parse and validate the access token against the configured keys
+	return h.tokenKeys.ParseAccessToken(tokenString)
 }
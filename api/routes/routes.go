@@ -1,35 +1,62 @@
 package routes
 
 import (
+	"codeberg.org/sporiff/eigakanban/config"
 	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
 	"codeberg.org/sporiff/eigakanban/handlers"
+	"codeberg.org/sporiff/eigakanban/helpers"
 	"codeberg.org/sporiff/eigakanban/middleware"
 	"codeberg.org/sporiff/eigakanban/services"
-	tmdb "github.com/cyruzin/golang-tmdb"
+	"codeberg.org/sporiff/eigakanban/types"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // SetupRoutes initializes all the routes for the application.
-func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, tmdbClient *tmdb.Client) {
+func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, metadataCache *services.MetadataCache, rankRebalancer *services.RankRebalancer, metadataRefresher *services.MetadataRefresher, importJobRunner *services.ImportJobRunner, tokenKeys *helpers.TokenKeys, mailer services.Mailer, emailConfig config.AccountEmailConfig) {
 	q := queries.New(db)
 
-	authService := services.NewAuthService(q)
-	usersService := services.NewUsersService(q)
-	statusesService := services.NewStatusesService(q)
-	itemsService := services.NewItemsService(q)
-	listItemsService := services.NewListItemsService(q)
-	searchService := services.NewSearchService(q, tmdbClient)
+	emailVerificationService := services.NewEmailVerificationService(q, db, mailer, emailConfig.AppURL, emailConfig.EmailVerificationTTL)
+	authService := services.NewAuthService(q, db, tokenKeys, emailVerificationService, emailConfig.UnverifiedAccess)
+	usersService := services.NewUsersService(q, db, emailVerificationService)
+	listsService := services.NewListsService(q)
+	statusesService := services.NewStatusesService(q, db)
+	listStatusesService := services.NewListStatusesService(q, db)
+	itemsService := services.NewItemsService(q, metadataCache)
+	listItemsService := services.NewListItemsService(q, db, rankRebalancer)
+	searchService := services.NewSearchService(q, metadataCache)
+	reviewsService := services.NewReviewsService(q)
+	watchesService := services.NewWatchesService(q, db, rankRebalancer)
+	letterboxdImportService := services.NewLetterboxdImportService(q, db, searchService, itemsService, rankRebalancer)
+	letterboxdExportService := services.NewLetterboxdExportService(q)
+	accountDataService := services.NewAccountDataService(q, db, itemsService)
+	imdbImportService := services.NewImdbImportService(q, db, importJobRunner)
+	importJobsService := services.NewImportJobsService(q, importJobRunner)
+	sessionsService := services.NewSessionsService(q)
+	rolesService := services.NewRolesService(q, db)
+	mfaService := services.NewMfaService(q, db)
+	passwordsService := services.NewPasswordsService(q, db, tokenKeys, mailer, emailConfig.AppURL, emailConfig.PasswordResetTTL)
 
 	authHandler := handlers.NewAuthHandler(authService)
 	usersHandler := handlers.NewUsersHandler(usersService)
+	listsHandler := handlers.NewListsHandler(listsService)
 	statusesHandler := handlers.NewStatusesHandler(statusesService)
-	itemsHandler := handlers.NewItemsHandler(itemsService)
+	listStatusesHandler := handlers.NewListStatusesHandler(listStatusesService)
+	itemsHandler := handlers.NewItemsHandler(itemsService, metadataRefresher)
 	listItemsHandler := handlers.NewListItemsHandler(listItemsService)
 	searchHandler := handlers.NewSearchHandler(searchService)
-
-	authMiddlewareHandler := middleware.NewAuthMiddlewareHandler(db)
-	superUserMiddlewareHandler := middleware.NewSuperUserMiddlewareHandler(db)
+	reviewsHandler := handlers.NewReviewsHandler(reviewsService)
+	watchesHandler := handlers.NewWatchesHandler(watchesService)
+	importsHandler := handlers.NewImportsHandler(letterboxdImportService, imdbImportService, importJobsService)
+	exportsHandler := handlers.NewExportsHandler(letterboxdExportService)
+	accountHandler := handlers.NewAccountHandler(accountDataService)
+	sessionsHandler := handlers.NewSessionsHandler(sessionsService)
+	rolesHandler := handlers.NewRolesHandler(rolesService)
+	passwordsHandler := handlers.NewPasswordsHandler(passwordsService)
+	emailVerificationHandler := handlers.NewEmailVerificationHandler(emailVerificationService)
+	mfaHandler := handlers.NewMfaHandler(mfaService)
+
+	authMiddlewareHandler := middleware.NewAuthMiddlewareHandler(db, tokenKeys, emailConfig.UnverifiedAccess)
 
 	v1 := router.Group("/api/v1")
 	{
@@ -38,80 +65,171 @@ func SetupRoutes(router *gin.Engine, db *pgxpool.Pool, tmdbClient *tmdb.Client)
 		{
 			auth.POST("/register", authHandler.RegisterUser)
 			auth.POST("/login", authHandler.LoginUser)
+			auth.POST("/login/mfa", authHandler.LoginMfa)
 			auth.POST("/logout", authHandler.LogoutUser)
+			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/password/forgot", passwordsHandler.ForgotPassword)
+			auth.POST("/password/reset", passwordsHandler.ResetPassword)
+			auth.POST("/verify-email", emailVerificationHandler.VerifyEmail)
+			auth.POST("/verify-email/resend", emailVerificationHandler.ResendVerification)
 		}
 
 		items := v1.Group("/items")
 		{
 			items.GET("/", itemsHandler.GetAllItems)
 			items.GET("/:uuid", itemsHandler.GetItemByUuid)
+			items.GET("/:uuid/reviews", reviewsHandler.GetReviewsForItem)
+		}
+
+		reviews := v1.Group("/reviews")
+		{
+			reviews.GET("/:uuid", reviewsHandler.GetReview)
+		}
+
+		watches := v1.Group("/watches")
+		{
+			watches.GET("/:uuid", watchesHandler.GetWatch)
 		}
 
 		lists := v1.Group("/lists")
 		{
+			// GET /lists/:uuid and /lists/:uuid/ returned list items before lists had details, so they keep
+			// doing so for existing clients
 			lists.GET("/:uuid", listItemsHandler.GetListItemsForList)
+			lists.GET("/:uuid/", listItemsHandler.GetAllListItems)
+			lists.GET("/:uuid/details", listsHandler.GetListByUuid)
+			lists.GET("/:uuid/items", listItemsHandler.GetListItemsForList)
+			lists.GET("/:uuid/board", listsHandler.GetBoard)
+			lists.GET("/:uuid/statuses", listStatusesHandler.GetStatusesForList)
 		}
 
-		listItems := v1.Group("/lists/:uuid")
+		listItems := v1.Group("/list_items")
 		{
 			listItems.GET("/", listItemsHandler.GetAllListItems)
 		}
 
 		// Authenticated routes
-		loggedInAuth := v1.Group("/auth")
-		loggedInAuth.Use(authMiddlewareHandler.AuthRequired())
+		authAccount := v1.Group("/auth")
+		authAccount.Use(authMiddlewareHandler.AccountAuthRequired())
 		{
-			loggedInAuth.POST("/refresh", authHandler.RefreshToken)
+			authAccount.POST("/password", passwordsHandler.ChangePassword)
+			authAccount.POST("/mfa/totp", mfaHandler.StartTotpEnrollment)
+			authAccount.POST("/mfa/totp/confirm", mfaHandler.ConfirmTotpEnrollment)
+			authAccount.DELETE("/mfa/totp", mfaHandler.DisableTotp)
+			authAccount.POST("/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
 		}
 
 		users := v1.Group("/users/:uuid")
 		users.Use(authMiddlewareHandler.AuthRequired())
 		{
 			users.GET("/", usersHandler.GetUserByUuid)
-			users.PATCH("/", usersHandler.UpdateUser)
-			users.DELETE("/", usersHandler.DeleteUser)
+			users.GET("/reviews", reviewsHandler.GetReviewsForUser)
+			users.GET("/watches", watchesHandler.GetWatchesForUser)
+			users.GET("/export", accountHandler.ExportAccount)
+			users.POST("/import", accountHandler.ImportAccount)
+			users.GET("/export/letterboxd", exportsHandler.ExportLetterboxd)
+			users.GET("/sessions", sessionsHandler.GetSessionsForUser)
+		}
+
+		// Users can manage their account before verifying their email, so that they can fix a wrong address
+		userAccount := v1.Group("/users/:uuid")
+		userAccount.Use(authMiddlewareHandler.AccountAuthRequired())
+		{
+			userAccount.PATCH("/", usersHandler.UpdateUser)
+			userAccount.DELETE("/", usersHandler.DeleteUser)
+			userAccount.DELETE("/sessions/others", sessionsHandler.RevokeOtherSessions)
+		}
+
+		sessions := v1.Group("/sessions")
+		sessions.Use(authMiddlewareHandler.AccountAuthRequired())
+		{
+			sessions.DELETE("/:id", sessionsHandler.RevokeSession)
 		}
 
 		authItems := v1.Group("/items")
 		authItems.Use(authMiddlewareHandler.AuthRequired())
 		{
 			authItems.POST("/", itemsHandler.AddItem)
-			authItems.PATCH("/:uuid", itemsHandler.UpdateItem)
+			authItems.POST("/import/tmdb/:id", itemsHandler.ImportTmdbMovie)
+			authItems.PATCH("/:uuid", authMiddlewareHandler.RequirePermission(types.PermissionItemsEdit), itemsHandler.UpdateItem)
+			authItems.POST("/:uuid/reviews", reviewsHandler.AddReview)
+		}
+
+		authReviews := v1.Group("/reviews")
+		authReviews.Use(authMiddlewareHandler.AuthRequired())
+		{
+			authReviews.PATCH("/:uuid", reviewsHandler.UpdateReview)
+			authReviews.DELETE("/:uuid", reviewsHandler.DeleteReview)
+		}
+
+		authWatches := v1.Group("/watches")
+		authWatches.Use(authMiddlewareHandler.AuthRequired())
+		{
+			authWatches.POST("/", watchesHandler.LogWatch)
+			authWatches.PATCH("/:uuid", watchesHandler.UpdateWatch)
+			authWatches.DELETE("/:uuid", watchesHandler.DeleteWatch)
+		}
+
+		imports := v1.Group("/imports")
+		imports.Use(authMiddlewareHandler.AuthRequired())
+		{
+			imports.POST("/letterboxd", importsHandler.ImportLetterboxd)
+			imports.POST("/imdb", importsHandler.ImportImdb)
+			imports.GET("/jobs", importsHandler.GetImportJobs)
+			imports.GET("/jobs/:uuid", importsHandler.GetImportJob)
+			imports.POST("/jobs/:uuid/resume", importsHandler.ResumeImportJob)
 		}
 
 		search := v1.Group("/search")
 		search.Use(authMiddlewareHandler.AuthRequired())
 		{
 			search.GET("/", searchHandler.SearchMovie)
+			search.GET("/movie/:id", searchHandler.GetMovieDetails)
+			search.GET("/movie/:id/credits", searchHandler.GetMovieCredits)
+			search.GET("/movie/:id/images", searchHandler.GetMovieImages)
 		}
 
-		//authLists := v1.Group("/lists")
-		//authLists.Use(authMiddlewareHandler.AuthRequired())
-		//{
-		//	authLists.POST("/", listHandler.AddList)
-		//}
+		authLists := v1.Group("/lists")
+		authLists.Use(authMiddlewareHandler.AuthRequired())
+		{
+			authLists.GET("/", listsHandler.GetListsForUser)
+			authLists.POST("/", listsHandler.AddList)
+			authLists.PATCH("/:uuid", listsHandler.UpdateList)
+			authLists.DELETE("/:uuid", listsHandler.DeleteList)
+			authLists.POST("/:uuid/items", listItemsHandler.AddItemToList)
+			authLists.POST("/:uuid/statuses", listStatusesHandler.AddStatusToList)
+			authLists.PUT("/:uuid/statuses", listStatusesHandler.ReorderStatusesForList)
+			authLists.PATCH("/:uuid/statuses/:status_uuid", listStatusesHandler.UpdateStatusOnList)
+			authLists.DELETE("/:uuid/statuses/:status_uuid", listStatusesHandler.RemoveStatusFromList)
+		}
 
-		//authListItems := v1.Group("/lists/:uuid")
-		//authListItems.Use(authMiddlewareHandler.AuthRequired())
-		//{
-		//	authListItems.POST("/", listItemsHandler.AddItemToList)
-		//}
+		authListItems := v1.Group("/list_items")
+		authListItems.Use(authMiddlewareHandler.AuthRequired())
+		{
+			authListItems.PATCH("/:uuid", listItemsHandler.MoveListItem)
+			authListItems.DELETE("/:uuid", listItemsHandler.DeleteListItem)
+		}
 
 		statuses := v1.Group("/statuses")
 		statuses.Use(authMiddlewareHandler.AuthRequired())
 		{
 			statuses.POST("/", statusesHandler.AddStatus)
 			statuses.GET("/", statusesHandler.GetStatusesForUser)
+			statuses.PATCH("/:uuid", statusesHandler.UpdateStatus)
+			statuses.DELETE("/:uuid", statusesHandler.DeleteStatus)
 		}
 
 		// Admin routes
 		admin := v1.Group("/admin")
 		admin.Use(authMiddlewareHandler.AuthRequired())
-		admin.Use(superUserMiddlewareHandler.SuperUserStatusRequired())
-		// TODO add middleware to check superuser status
 		{
-			admin.GET("/users", usersHandler.GetAllUsers)
-			admin.DELETE("/users/:uuid", usersHandler.DeleteUser)
+			admin.GET("/users", authMiddlewareHandler.RequirePermission(types.PermissionUsersList), usersHandler.GetAllUsers)
+			admin.DELETE("/users/:uuid", authMiddlewareHandler.RequirePermission(types.PermissionUsersDelete), usersHandler.DeleteUser)
+			admin.POST("/items/:uuid/refresh", authMiddlewareHandler.RequirePermission(types.PermissionItemsRefresh), itemsHandler.RefreshItemMetadata)
+			admin.GET("/roles", authMiddlewareHandler.RequirePermission(types.PermissionRolesAssign), rolesHandler.GetRoles)
+			admin.GET("/users/:uuid/roles", authMiddlewareHandler.RequirePermission(types.PermissionRolesAssign), rolesHandler.GetRolesForUser)
+			admin.POST("/users/:uuid/roles", authMiddlewareHandler.RequirePermission(types.PermissionRolesAssign), rolesHandler.AssignRole)
+			admin.DELETE("/users/:uuid/roles/:role", authMiddlewareHandler.RequirePermission(types.PermissionRolesAssign), rolesHandler.RemoveRole)
 		}
 	}
 }
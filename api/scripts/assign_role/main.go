@@ -0,0 +1,53 @@
+package main
+
+import (
+	"codeberg.org/sporiff/eigakanban/config"
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+)
+
+// assign_role gives the user with an email address a role. Roles are normally assigned by an admin through
+// the API, so this is how the first admin of a new server is created.
+//
+//	go run ./scripts/assign_role -email <email> [-role admin]
+func main() {
+	email := flag.String("email", "", "email address of the user")
+	role := flag.String("role", types.RoleAdmin, "name of the role to assign")
+	flag.Parse()
+
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dbConfig := config.LoadDBConfig()
+
+	db, err := config.ConnectDB(dbConfig)
+	if err != nil {
+		log.Fatalf("Couldn't connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	q := queries.New(db)
+	ctx := context.Background()
+
+	user, err := q.GetUserByEmail(ctx, strings.TrimSpace(*email))
+	if err != nil {
+		log.Fatalf("Couldn't find a user with the email %s: %v", *email, err)
+	}
+
+	rolesService := services.NewRolesService(q, db)
+
+	roles, err := rolesService.AssignRole(ctx, user.Uuid.String(), *role)
+	if err != nil {
+		log.Fatalf("Couldn't assign role: %v", err)
+	}
+
+	log.Printf("%s now has the roles %s", user.Username, strings.Join(roles.Roles, ", "))
+}
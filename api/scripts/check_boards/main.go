@@ -0,0 +1,76 @@
+package main
+
+import (
+	"codeberg.org/sporiff/eigakanban/config"
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/services"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"flag"
+	"log"
+	"os"
+)
+
+// check_boards scans every board for ordering problems and optionally repairs them.
+//
+//	go run ./scripts/check_boards [-list <uuid>] [-repair]
+func main() {
+	listUuid := flag.String("list", "", "only check the list with this UUID")
+	repair := flag.Bool("repair", false, "rewrite broken columns into a canonical order")
+	flag.Parse()
+
+	dbConfig := config.LoadDBConfig()
+
+	db, err := config.ConnectDB(dbConfig)
+	if err != nil {
+		log.Fatalf("Couldn't connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	q := queries.New(db)
+	ctx := context.Background()
+
+	boardIntegrityService := services.NewBoardIntegrityService(q, services.NewRankRebalancer(q, db))
+
+	var issues []types.BoardIssue
+	if *listUuid != "" {
+		pgUuid, uuidErr := helpers.ValidateAndConvertUUID(*listUuid)
+		if uuidErr != nil {
+			log.Fatalf("Invalid list UUID: %v", uuidErr)
+		}
+		issues, err = boardIntegrityService.CheckList(ctx, *pgUuid)
+	} else {
+		issues, err = boardIntegrityService.CheckAllLists(ctx)
+	}
+	if err != nil {
+		log.Fatalf("Couldn't check boards: %v", err)
+	}
+
+	for _, issue := range issues {
+		log.Printf("[%s] list %s status %s item %s: %s", issue.Kind, issue.ListUUID, issue.StatusUUID, issue.ListItemUUID, issue.Detail)
+	}
+
+	if len(issues) == 0 {
+		log.Println("No board issues found")
+		return
+	}
+
+	log.Printf("Found %d board issues", len(issues))
+
+	if !*repair {
+		os.Exit(1)
+	}
+
+	remaining, err := boardIntegrityService.RepairIssues(ctx, issues)
+	if err != nil {
+		log.Fatalf("Couldn't repair boards: %v", err)
+	}
+
+	log.Printf("Repaired %d board issues", len(issues)-len(remaining))
+
+	if len(remaining) > 0 {
+		log.Printf("%d board issues need to be fixed manually", len(remaining))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"codeberg.org/sporiff/eigakanban/config"
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/services"
+	"context"
+	"flag"
+	"log"
+	"os"
+)
+
+// import_letterboxd imports a Letterboxd export archive for a user and prints a report of the
+// rows that couldn't be matched.
+//
+//	go run ./scripts/import_letterboxd -user <uuid> -file <export.zip> [-dry-run]
+func main() {
+	userUuid := flag.String("user", "", "UUID of the user to import for")
+	path := flag.String("file", "", "path to the Letterboxd export zip")
+	dryRun := flag.Bool("dry-run", false, "match films and report without importing anything")
+	flag.Parse()
+
+	if *userUuid == "" || *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Couldn't open export: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		log.Fatalf("Couldn't read export: %v", err)
+	}
+
+	dbConfig := config.LoadDBConfig()
+
+	db, err := config.ConnectDB(dbConfig)
+	if err != nil {
+		log.Fatalf("Couldn't connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	metadataProvider, err := config.LoadMetadataProvider()
+	if err != nil {
+		log.Fatalf("Couldn't set up metadata provider: %v", err)
+	}
+
+	q := queries.New(db)
+
+	cacheConfig := config.LoadTmdbCacheConfig()
+	metadataCache := services.NewMetadataCache(q, metadataProvider, cacheConfig.TTL, cacheConfig.MaxStale)
+
+	// Long rank keys are left for the server's rebalancer to pick up
+	importService := services.NewLetterboxdImportService(
+		q,
+		db,
+		services.NewSearchService(q, metadataCache),
+		services.NewItemsService(q, metadataCache),
+		services.NewRankRebalancer(q, db),
+	)
+
+	report, err := importService.Import(context.Background(), *userUuid, file, info.Size(), *dryRun)
+	if err != nil {
+		log.Fatalf("Couldn't import export: %v", err)
+	}
+
+	for _, row := range report.Unmatched {
+		log.Printf("[%s:%d] %s (%s): %s", row.File, row.Line, row.Name, row.Year, row.Reason)
+	}
+
+	if report.DryRun {
+		log.Println("Dry run, nothing was imported")
+	}
+
+	log.Printf("Rows: %d, matched: %d, unmatched: %d, skipped: %d", report.Rows, report.Matched, len(report.Unmatched), report.Skipped)
+	log.Printf("Watches: %d, reviews: %d, ratings: %d, watchlist: %d", report.WatchesCreated, report.ReviewsCreated, report.RatingsCreated, report.WatchlistAdded)
+}
@@ -7,6 +7,7 @@ import (
 	"codeberg.org/sporiff/eigakanban/types"
 	"context"
 	"github.com/brianvoe/gofakeit/v7"
+	"io"
 	"log"
 )
 
@@ -21,9 +22,14 @@ func main() {
 
 	q := queries.New(db)
 
-	authService := services.NewAuthService(q)
-	usersService := services.NewUsersService(q)
-	itemService := services.NewItemsService(q)
+	// The dummy users' addresses are made up, so their verification emails are thrown away
+	emailConfig := config.LoadAccountEmailConfig()
+	verificationService := services.NewEmailVerificationService(q, db, services.NewLogMailer(io.Discard, ""), emailConfig.AppURL, emailConfig.EmailVerificationTTL)
+
+	// Registering users doesn't issue tokens, so no signing keys are needed
+	authService := services.NewAuthService(q, db, nil, verificationService, emailConfig.UnverifiedAccess)
+	usersService := services.NewUsersService(q, db, verificationService)
+	itemService := services.NewItemsService(q, nil)
 
 	createDummyUsers(context.Background(), authService, usersService)
 	createDummyItems(context.Background(), itemService)
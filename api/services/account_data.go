@@ -0,0 +1,709 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AccountDataService struct {
+	q            *queries.Queries
+	db           *pgxpool.Pool
+	itemsService *ItemsService
+}
+
+func NewAccountDataService(q *queries.Queries, db *pgxpool.Pool, itemsService *ItemsService) *AccountDataService {
+	return &AccountDataService{q: q, db: db, itemsService: itemsService}
+}
+
+// Export builds a full backup of a user's data. Users can only export their own data
+func (s *AccountDataService) Export(ctx context.Context, uuid, requesterUuid string) (*types.AccountExport, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(userActor(requesterUuid), ownerOnly, resourceUser, ownership{owner: *pgUuid}); err != nil {
+		return nil, err
+	}
+
+	user, err := s.q.GetUserForExport(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	export := types.AccountExport{
+		Version:    types.AccountExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Profile: types.AccountExportProfile{
+			UUID:        user.Uuid.String(),
+			Username:    user.Username,
+			Email:       user.Email,
+			FullName:    helpers.PgTextPointer(user.FullName),
+			Bio:         helpers.PgTextPointer(user.Bio),
+			CreatedDate: user.CreatedDate.Time,
+		},
+		Items:    []types.AccountExportItem{},
+		Statuses: []types.AccountExportStatus{},
+		Lists:    []types.AccountExportList{},
+		Reviews:  []types.AccountExportReview{},
+		Watches:  []types.AccountExportWatch{},
+	}
+
+	items, err := s.q.GetItemsForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting items")
+	}
+
+	for _, item := range items {
+		export.Items = append(export.Items, types.AccountExportItem{
+			UUID:             item.Uuid.String(),
+			Title:            item.Title,
+			TmdbID:           helpers.PgInt8Pointer(item.TmdbID),
+			ImdbID:           helpers.PgTextPointer(item.ImdbID),
+			ReleaseDate:      helpers.PgDatePointer(item.ReleaseDate),
+			Runtime:          helpers.PgInt4Pointer(item.Runtime),
+			Overview:         helpers.PgTextPointer(item.Overview),
+			OriginalLanguage: helpers.PgTextPointer(item.OriginalLanguage),
+			PosterPath:       helpers.PgTextPointer(item.PosterPath),
+			BackdropPath:     helpers.PgTextPointer(item.BackdropPath),
+			Genres:           item.Genres,
+			CreatedDate:      item.CreatedDate.Time,
+		})
+	}
+
+	statuses, err := s.q.GetStatusesForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting statuses")
+	}
+
+	for _, status := range statuses {
+		export.Statuses = append(export.Statuses, types.AccountExportStatus{
+			UUID:        status.Uuid.String(),
+			Label:       status.Label.String,
+			CreatedDate: status.CreatedDate.Time,
+		})
+	}
+
+	lists, err := s.q.GetListsForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting lists")
+	}
+
+	listIndexes := make(map[string]int, len(lists))
+	for i, list := range lists {
+		listIndexes[list.Uuid.String()] = i
+		export.Lists = append(export.Lists, types.AccountExportList{
+			UUID:        list.Uuid.String(),
+			Name:        list.Name,
+			CreatedDate: list.CreatedDate.Time,
+			Statuses:    []types.AccountExportListStatus{},
+			Cards:       []types.AccountExportCard{},
+		})
+	}
+
+	listStatuses, err := s.q.GetListStatusesForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting list statuses")
+	}
+
+	for _, listStatus := range listStatuses {
+		list := &export.Lists[listIndexes[listStatus.ListUuid.String()]]
+		list.Statuses = append(list.Statuses, types.AccountExportListStatus{
+			StatusUUID: listStatus.StatusUuid.String(),
+			Rank:       listStatus.Rank,
+			WipLimit:   helpers.PgInt4Pointer(listStatus.WipLimit),
+		})
+	}
+
+	cards, err := s.q.GetListItemsForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting list items")
+	}
+
+	for _, card := range cards {
+		list := &export.Lists[listIndexes[card.ListUuid.String()]]
+		list.Cards = append(list.Cards, types.AccountExportCard{
+			UUID:        card.Uuid.String(),
+			ItemUUID:    card.ItemUuid.String(),
+			StatusUUID:  pgUuidPointer(card.StatusUuid),
+			Rank:        card.Rank,
+			CreatedDate: card.CreatedDate.Time,
+		})
+	}
+
+	reviews, err := s.q.GetReviewsForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting reviews")
+	}
+
+	for _, review := range reviews {
+		export.Reviews = append(export.Reviews, types.AccountExportReview{
+			UUID:        review.Uuid.String(),
+			ItemUUID:    review.ItemUuid.String(),
+			Content:     review.Content,
+			Rating:      helpers.PgNumericPointer(review.Rating),
+			CreatedDate: review.CreatedDate.Time,
+			UpdatedDate: helpers.PgTimestamptzPointer(review.UpdatedDate),
+		})
+	}
+
+	watches, err := s.q.GetWatchesForUserExport(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting watches")
+	}
+
+	for _, watch := range watches {
+		export.Watches = append(export.Watches, types.AccountExportWatch{
+			UUID:        watch.Uuid.String(),
+			ItemUUID:    watch.ItemUuid.String(),
+			ReviewUUID:  pgUuidPointer(watch.ReviewUuid),
+			WatchedDate: formatPgDateOrEmpty(watch.WatchedDate),
+			Rewatch:     watch.Rewatch,
+			Rating:      helpers.PgNumericPointer(watch.Rating),
+			Location:    helpers.PgTextPointer(watch.Location),
+			Format:      helpers.PgTextPointer(watch.Format),
+			CreatedDate: watch.CreatedDate.Time,
+			UpdatedDate: helpers.PgTimestamptzPointer(watch.UpdatedDate),
+		})
+	}
+
+	return &export, nil
+}
+
+// Import recreates an account export in a user's account in a single transaction. Users can only import
+// into their own account. Records keep their exported UUIDs unless another user already has them, in which
+// case they are created with a new UUID. Lists and watches the user already has are skipped, as are reviews
+// of items the user has already reviewed, so importing the same export twice changes nothing
+func (s *AccountDataService) Import(ctx context.Context, uuid, requesterUuid string, export types.AccountExport) (*types.AccountImportReport, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(userActor(requesterUuid), ownerOnly, resourceUser, ownership{owner: *pgUuid}); err != nil {
+		return nil, err
+	}
+
+	if export.Version < 1 || export.Version > types.AccountExportVersion {
+		return nil, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("unsupported export version: %d", export.Version))
+	}
+
+	run := accountImport{
+		userUuid:  *pgUuid,
+		items:     make(map[string]pgtype.UUID),
+		unmatched: make(map[string]bool),
+		statuses:  make(map[string]pgtype.UUID),
+		reviews:   make(map[string]pgtype.UUID),
+		report:    types.AccountImportReport{Remapped: []types.RemappedUUID{}, UnmatchedItems: []types.UnmatchedItem{}},
+	}
+
+	if err := s.resolveItems(ctx, &run, export); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	run.q = qtx
+
+	user, err := qtx.GetUserByUuid(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	steps := []func(context.Context, types.AccountExport) error{
+		run.importStatuses,
+		run.importLists,
+		run.importReviews,
+		run.importWatches,
+	}
+
+	for _, step := range steps {
+		if err := step(ctx, export); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = qtx.UpdateUserDetails(ctx, queries.UpdateUserDetailsParams{
+		NewUsername: user.Username,
+		NewName:     makeOptionalPgText(export.Profile.FullName),
+		NewBio:      makeOptionalPgText(export.Profile.Bio),
+		UserUuid:    *pgUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating user")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return &run.report, nil
+}
+
+// accountImport holds the state of a single account import. The maps translate exported UUIDs to the
+// UUIDs the records have after the import, and unmatched holds the exported items that couldn't be resolved
+type accountImport struct {
+	q         *queries.Queries
+	userUuid  pgtype.UUID
+	items     map[string]pgtype.UUID
+	unmatched map[string]bool
+	statuses  map[string]pgtype.UUID
+	reviews   map[string]pgtype.UUID
+	report    types.AccountImportReport
+}
+
+// resolveItems finds the item each exported item refers to. Items are shared between users, so they are
+// never created from the export itself: items with a TMDB ID are imported from TMDB like any other movie, and
+// items without one are only matched to an item that already has their UUID. Items that can't be resolved are
+// reported, and the cards, reviews and watches that use them are skipped. This runs before the import's
+// transaction so that TMDB isn't queried while rows are locked
+func (s *AccountDataService) resolveItems(ctx context.Context, run *accountImport, export types.AccountExport) error {
+	for _, item := range export.Items {
+		itemUuid, err := parseExportUuid("item", item.UUID)
+		if err != nil {
+			return err
+		}
+
+		if item.TmdbID == nil {
+			_, err := s.q.GetItemByUuid(ctx, itemUuid)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+			}
+
+			if errors.Is(err, sql.ErrNoRows) {
+				run.unmatchedItem(item, "item has no TMDB ID")
+				continue
+			}
+
+			run.items[item.UUID] = itemUuid
+			continue
+		}
+
+		imported, created, err := s.itemsService.ImportTmdbMovie(ctx, *item.TmdbID)
+		if err != nil {
+			var apiErr *types.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				run.unmatchedItem(item, "movie not found on TMDB")
+				continue
+			}
+			return err
+		}
+
+		resolved, err := helpers.ValidateAndConvertUUID(imported.UUID)
+		if err != nil {
+			return err
+		}
+
+		if created {
+			run.report.ItemsCreated++
+		}
+
+		run.items[item.UUID] = *resolved
+	}
+
+	return nil
+}
+
+// unmatchedItem records an exported item that couldn't be resolved to an item
+func (a *accountImport) unmatchedItem(item types.AccountExportItem, reason string) {
+	a.unmatched[item.UUID] = true
+	a.report.UnmatchedItems = append(a.report.UnmatchedItems, types.UnmatchedItem{
+		UUID:   item.UUID,
+		Title:  item.Title,
+		Reason: reason,
+	})
+}
+
+// itemFor returns the item an exported item was resolved to. It returns false if the item couldn't be
+// resolved, in which case the record using it is skipped
+func (a *accountImport) itemFor(kind, recordUuid, itemUuid string) (pgtype.UUID, bool, error) {
+	if resolved, ok := a.items[itemUuid]; ok {
+		return resolved, true, nil
+	}
+
+	if a.unmatched[itemUuid] {
+		a.report.Skipped++
+		return pgtype.UUID{}, false, nil
+	}
+
+	return pgtype.UUID{}, false, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("%s %s references unknown item %s", kind, recordUuid, itemUuid))
+}
+
+// importStatuses matches statuses to the user's existing statuses by label, and creates the rest
+func (a *accountImport) importStatuses(ctx context.Context, export types.AccountExport) error {
+	for _, status := range export.Statuses {
+		statusUuid, err := parseExportUuid("status", status.UUID)
+		if err != nil {
+			return err
+		}
+
+		label := helpers.MakePgString(strings.TrimSpace(status.Label))
+		if !label.Valid {
+			return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("status %s has no label", status.UUID))
+		}
+
+		existing, err := a.q.GetStatusUuidByLabelForUser(ctx, queries.GetStatusUuidByLabelForUserParams{
+			UserUuid: a.userUuid,
+			Label:    label,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return types.NewAPIError(http.StatusInternalServerError, "error getting status by label")
+		}
+
+		if err == nil {
+			a.statuses[status.UUID] = existing
+			continue
+		}
+
+		created, err := a.insertWithRemap("status", statusUuid, func(id pgtype.UUID) (pgtype.UUID, error) {
+			return a.q.ImportStatus(ctx, queries.ImportStatusParams{
+				Uuid:        id,
+				Label:       label,
+				UserUuid:    a.userUuid,
+				CreatedDate: makeImportTimestamp(status.CreatedDate),
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		a.statuses[status.UUID] = created
+		a.report.StatusesCreated++
+	}
+
+	return nil
+}
+
+// importLists recreates each list with its status columns and cards in their exported positions
+func (a *accountImport) importLists(ctx context.Context, export types.AccountExport) error {
+	for _, list := range export.Lists {
+		listUuid, err := parseExportUuid("list", list.UUID)
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(list.Name) == "" {
+			return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("list %s has no name", list.UUID))
+		}
+
+		existing, err := a.q.GetListByUuid(ctx, listUuid)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return types.NewAPIError(http.StatusInternalServerError, "error getting list by uuid")
+		}
+
+		// The list was already imported, or never left this account
+		if err == nil && existing.UserUuid == a.userUuid {
+			a.report.Skipped++
+			continue
+		}
+
+		created, err := a.insertWithRemap("list", listUuid, func(id pgtype.UUID) (pgtype.UUID, error) {
+			return a.q.ImportList(ctx, queries.ImportListParams{
+				Uuid:        id,
+				Name:        list.Name,
+				UserUuid:    a.userUuid,
+				CreatedDate: makeImportTimestamp(list.CreatedDate),
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		a.report.ListsCreated++
+
+		columns := make(map[string]pgtype.UUID, len(list.Statuses))
+		for _, listStatus := range list.Statuses {
+			statusUuid, ok := a.statuses[listStatus.StatusUUID]
+			if !ok {
+				return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("list %s references unknown status %s", list.UUID, listStatus.StatusUUID))
+			}
+
+			if !helpers.ValidRank(listStatus.Rank) {
+				return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("list %s has an invalid rank for status %s", list.UUID, listStatus.StatusUUID))
+			}
+
+			if listStatus.WipLimit != nil && *listStatus.WipLimit < 1 {
+				return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("list %s has an invalid wip limit for status %s", list.UUID, listStatus.StatusUUID))
+			}
+
+			err = a.q.ImportListStatus(ctx, queries.ImportListStatusParams{
+				ListUuid:   created,
+				StatusUuid: statusUuid,
+				Rank:       listStatus.Rank,
+				WipLimit:   helpers.MakePgInt4(listStatus.WipLimit),
+			})
+			if err != nil {
+				return types.NewAPIError(http.StatusInternalServerError, "error importing list status")
+			}
+
+			columns[listStatus.StatusUUID] = statusUuid
+		}
+
+		for _, card := range list.Cards {
+			cardUuid, err := parseExportUuid("card", card.UUID)
+			if err != nil {
+				return err
+			}
+
+			itemUuid, ok, err := a.itemFor("card", card.UUID, card.ItemUUID)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+
+			statusUuid := pgtype.UUID{Valid: false}
+			if card.StatusUUID != nil {
+				statusUuid, ok = columns[*card.StatusUUID]
+				if !ok {
+					return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("card %s references status %s, which is not on its list", card.UUID, *card.StatusUUID))
+				}
+			}
+
+			if !helpers.ValidRank(card.Rank) {
+				return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("card %s has an invalid rank", card.UUID))
+			}
+
+			_, err = a.insertWithRemap("card", cardUuid, func(id pgtype.UUID) (pgtype.UUID, error) {
+				return a.q.ImportListItem(ctx, queries.ImportListItemParams{
+					Uuid:        id,
+					ListUuid:    created,
+					ItemUuid:    itemUuid,
+					StatusUuid:  statusUuid,
+					Rank:        card.Rank,
+					CreatedDate: makeImportTimestamp(card.CreatedDate),
+				})
+			})
+			if err != nil {
+				return err
+			}
+
+			a.report.CardsCreated++
+		}
+	}
+
+	return nil
+}
+
+// importReviews creates the user's reviews, keeping any review the user already has for the same item
+func (a *accountImport) importReviews(ctx context.Context, export types.AccountExport) error {
+	for _, review := range export.Reviews {
+		reviewUuid, err := parseExportUuid("review", review.UUID)
+		if err != nil {
+			return err
+		}
+
+		itemUuid, ok, err := a.itemFor("review", review.UUID, review.ItemUUID)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := validateRating(review.Rating); err != nil {
+			return err
+		}
+
+		existing, err := a.q.GetReviewForUserItem(ctx, queries.GetReviewForUserItemParams{
+			ItemUuid: itemUuid,
+			UserUuid: a.userUuid,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return types.NewAPIError(http.StatusInternalServerError, "error getting review")
+		}
+
+		if err == nil {
+			a.reviews[review.UUID] = existing.Uuid
+			a.report.Skipped++
+			continue
+		}
+
+		updatedDate := pgtype.Timestamptz{Valid: false}
+		if review.UpdatedDate != nil {
+			updatedDate = makeImportTimestamp(*review.UpdatedDate)
+		}
+
+		created, err := a.insertWithRemap("review", reviewUuid, func(id pgtype.UUID) (pgtype.UUID, error) {
+			return a.q.ImportReview(ctx, queries.ImportReviewParams{
+				Uuid:        id,
+				ItemUuid:    itemUuid,
+				UserUuid:    a.userUuid,
+				Content:     review.Content,
+				Rating:      helpers.MakePgNumeric(review.Rating),
+				CreatedDate: makeImportTimestamp(review.CreatedDate),
+				UpdatedDate: updatedDate,
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		a.reviews[review.UUID] = created
+		a.report.ReviewsCreated++
+	}
+
+	return nil
+}
+
+// importWatches creates the user's diary entries, skipping entries the user already has
+func (a *accountImport) importWatches(ctx context.Context, export types.AccountExport) error {
+	for _, watch := range export.Watches {
+		watchUuid, err := parseExportUuid("watch", watch.UUID)
+		if err != nil {
+			return err
+		}
+
+		itemUuid, ok, err := a.itemFor("watch", watch.UUID, watch.ItemUUID)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		reviewUuid := pgtype.UUID{Valid: false}
+		if watch.ReviewUUID != nil {
+			reviewUuid, ok = a.reviews[*watch.ReviewUUID]
+			if !ok {
+				return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("watch %s references unknown review %s", watch.UUID, *watch.ReviewUUID))
+			}
+		}
+
+		watchedDate, err := time.Parse(time.DateOnly, watch.WatchedDate)
+		if err != nil {
+			return types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("watch %s has an invalid watched date", watch.UUID))
+		}
+
+		if err := validateRating(watch.Rating); err != nil {
+			return err
+		}
+
+		existing, err := a.q.GetWatch(ctx, watchUuid)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return types.NewAPIError(http.StatusInternalServerError, "error getting watch")
+		}
+
+		if err == nil && existing.UserUuid == a.userUuid {
+			a.report.Skipped++
+			continue
+		}
+
+		updatedDate := pgtype.Timestamptz{Valid: false}
+		if watch.UpdatedDate != nil {
+			updatedDate = makeImportTimestamp(*watch.UpdatedDate)
+		}
+
+		_, err = a.insertWithRemap("watch", watchUuid, func(id pgtype.UUID) (pgtype.UUID, error) {
+			return a.q.ImportWatch(ctx, queries.ImportWatchParams{
+				Uuid:        id,
+				UserUuid:    a.userUuid,
+				ItemUuid:    itemUuid,
+				ReviewUuid:  reviewUuid,
+				WatchedDate: pgtype.Date{Time: watchedDate, Valid: true},
+				Rewatch:     watch.Rewatch,
+				Rating:      helpers.MakePgNumeric(watch.Rating),
+				Location:    makeOptionalPgText(watch.Location),
+				Format:      makeOptionalPgText(watch.Format),
+				CreatedDate: makeImportTimestamp(watch.CreatedDate),
+				UpdatedDate: updatedDate,
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		a.report.WatchesCreated++
+	}
+
+	return nil
+}
+
+// insertWithRemap inserts a record under its exported UUID. If the UUID is already taken the record is
+// inserted again under a new UUID, and the change is added to the report
+func (a *accountImport) insertWithRemap(kind string, id pgtype.UUID, insert func(pgtype.UUID) (pgtype.UUID, error)) (pgtype.UUID, error) {
+	created, err := insert(id)
+	if err == nil {
+		return created, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, types.NewAPIError(http.StatusInternalServerError, fmt.Sprintf("error importing %s", kind))
+	}
+
+	created, err = insert(pgtype.UUID{Bytes: uuid.New(), Valid: true})
+	if err != nil {
+		return pgtype.UUID{}, types.NewAPIError(http.StatusInternalServerError, fmt.Sprintf("error importing %s", kind))
+	}
+
+	a.report.Remapped = append(a.report.Remapped, types.RemappedUUID{
+		Type:    kind,
+		OldUUID: id.String(),
+		NewUUID: created.String(),
+	})
+
+	return created, nil
+}
+
+// parseExportUuid parses a UUID from an account export
+func parseExportUuid(kind, s string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(s)
+	if err != nil {
+		return pgtype.UUID{}, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("invalid %s uuid: %q", kind, s))
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+// pgUuidPointer formats a postgres UUID as a string, treating NULL as nil
+func pgUuidPointer(u pgtype.UUID) *string {
+	if !u.Valid {
+		return nil
+	}
+	s := u.String()
+	return &s
+}
+
+// makeOptionalPgText converts an optional string to postgres text, treating nil and empty strings as NULL
+func makeOptionalPgText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{Valid: false}
+	}
+	return helpers.MakePgString(*s)
+}
+
+// makeImportTimestamp converts an exported timestamp to a postgres timestamp, using the current time if
+// the export left it out
+func makeImportTimestamp(t time.Time) pgtype.Timestamptz {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
@@ -7,18 +7,35 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
 	"net/http"
 	"time"
 )
 
+// refreshTokenTTL is how long a refresh token can be used for. Each refresh issues a new token, so a
+// session lasts until it goes unused for this long
+const refreshTokenTTL = time.Hour * 24 * 7
+
+const (
+	// mfaChallengeTTL is how long a user has to enter their second factor after entering their password
+	mfaChallengeTTL = 5 * time.Minute
+	// maxMfaAttempts is how many wrong codes can be entered before the user has to enter their password again
+	maxMfaAttempts = 5
+)
+
 type AuthService struct {
-	q *queries.Queries
+	q                *queries.Queries
+	db               *pgxpool.Pool
+	tokenKeys        *helpers.TokenKeys
+	verification     *EmailVerificationService
+	unverifiedAccess types.UnverifiedAccess
 }
 
-func NewAuthService(q *queries.Queries) *AuthService {
-	return &AuthService{q: q}
+func NewAuthService(q *queries.Queries, db *pgxpool.Pool, tokenKeys *helpers.TokenKeys, verification *EmailVerificationService, unverifiedAccess types.UnverifiedAccess) *AuthService {
+	return &AuthService{q: q, db: db, tokenKeys: tokenKeys, verification: verification, unverifiedAccess: unverifiedAccess}
 }
 
 // RegisterUser creates a new user and populates default information
@@ -57,14 +74,21 @@ func (s *AuthService) RegisterUser(ctx context.Context, user types.RegisterUserR
 		return nil, err
 	}
 
+	// The address isn't trusted until the user follows the link sent to it
+	err = s.verification.sendVerification(ctx, registeredUser.Uuid, registeredUser.Username, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
 	return &registeredUser, nil
 }
 
-// LoginUser logs in the user and sets up authentication
-func (s *AuthService) LoginUser(ctx context.Context, email, username, password string) (*types.AuthenticatedUserResponse, error) {
+// LoginUser logs in the user and sets up authentication. Users with two-factor authentication get an MFA
+// challenge instead, which CompleteMfaLogin exchanges for tokens
+func (s *AuthService) LoginUser(ctx context.Context, email, username, password string, client types.SessionClient) (*types.AuthenticatedUserResponse, *types.MfaChallengeResponse, error) {
 	err := s.validateDetails(email, username)
 	if err != nil {
-		return nil, types.NewAPIError(http.StatusBadRequest, "no credentials were passed")
+		return nil, nil, types.NewAPIError(http.StatusBadRequest, "no credentials were passed")
 	}
 
 	existingUserCount, err := s.q.CheckForUser(ctx, queries.CheckForUserParams{
@@ -72,11 +96,11 @@ func (s *AuthService) LoginUser(ctx context.Context, email, username, password s
 		Username: username,
 	})
 	if err != nil {
-		return nil, types.NewAPIError(http.StatusInternalServerError, err.Error())
+		return nil, nil, types.NewAPIError(http.StatusInternalServerError, err.Error())
 	}
 
 	if existingUserCount == 0 {
-		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+		return nil, nil, types.NewAPIError(http.StatusNotFound, "user not found")
 	}
 
 	existingUser, err := s.q.GetExistingUser(ctx, queries.GetExistingUserParams{
@@ -84,40 +108,178 @@ func (s *AuthService) LoginUser(ctx context.Context, email, username, password s
 		Username: username,
 	})
 	if err != nil {
-		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching user: "+err.Error())
+		return nil, nil, types.NewAPIError(http.StatusInternalServerError, "error fetching user: "+err.Error())
 	}
 
 	authenticated := helpers.CheckPasswordHash(password, existingUser.HashedPassword)
 
 	if !authenticated {
-		return nil, types.NewAPIError(http.StatusBadRequest, "incorrect password")
+		return nil, nil, types.NewAPIError(http.StatusBadRequest, "incorrect password")
+	}
+
+	if !existingUser.EmailVerifiedAt.Valid && s.unverifiedAccess == types.UnverifiedAccessNone {
+		return nil, nil, types.NewAPIError(http.StatusForbidden, "email address not verified")
+	}
+
+	if existingUser.TotpEnabledAt.Valid {
+		challenge, err := s.startMfaChallenge(ctx, existingUser.UserID.Int64, client.DeviceName)
+		return nil, challenge, err
+	}
+
+	// Start a new session, which is a new refresh token family
+	sessionId := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	refreshToken, err := generateAndStoreRefreshToken(ctx, s.q, existingUser.UserID.Int64, sessionId, client)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Generate an access token
-	accessToken, expiryDate, err := helpers.GenerateAccessToken(existingUser)
+	accessToken, expiryDate, err := s.tokenKeys.GenerateAccessToken(existingUser, sessionId.String())
 	if err != nil {
-		return nil, types.NewAPIError(http.StatusInternalServerError, err.Error())
+		return nil, nil, types.NewAPIError(http.StatusInternalServerError, err.Error())
 	}
 
-	// Generate a refresh token
-	refreshToken, err := s.generateAndStoreRefreshToken(existingUser, ctx)
+	userResponse := types.NewAuthenticateUserResponse(existingUser.Uuid.String(), accessToken, *refreshToken, expiryDate)
+
+	return userResponse, nil, nil
+}
+
+// CompleteMfaLogin finishes logging in a user with two-factor authentication. The MFA token from LoginUser
+// is exchanged for tokens once a code from the user's authenticator app or a recovery code is given. Each
+// MFA token can only be used once, and only for a few wrong codes
+func (s *AuthService) CompleteMfaLogin(ctx context.Context, mfaToken, code string, client types.SessionClient) (*types.TokenResponse, error) {
+	challenge, err := s.q.GetMfaChallengeByHash(ctx, helpers.HashToken(mfaToken))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting mfa challenge")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) || time.Now().After(challenge.ExpiresAt.Time) {
+		return nil, types.NewAPIError(http.StatusUnauthorized, "invalid or expired mfa token")
+	}
+
+	// The attempt is counted before the code is checked, and outside the transaction so that a wrong code
+	// still counts when the transaction is rolled back
+	_, err = s.q.AddMfaChallengeAttempt(ctx, queries.AddMfaChallengeAttemptParams{
+		MfaChallengeID: challenge.MfaChallengeID,
+		MaxAttempts:    maxMfaAttempts,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error recording mfa attempt")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.q.DeleteMfaChallenge(ctx, challenge.MfaChallengeID); err != nil {
+			return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting mfa challenge")
+		}
+		return nil, types.NewAPIError(http.StatusUnauthorized, "too many attempts, please log in again")
+	}
+
+	user, err := getUserTotp(ctx, s.q, challenge.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	existingUser, err := s.q.GetUserByUuid(ctx, challenge.UserUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	ok, err := checkSecondFactor(ctx, qtx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, types.NewAPIError(http.StatusUnauthorized, "invalid code")
+	}
+
+	// Another request may have used the challenge since it was read
+	deleted, err := qtx.DeleteMfaChallenge(ctx, challenge.MfaChallengeID)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting mfa challenge")
+	}
+
+	if deleted == 0 {
+		return nil, types.NewAPIError(http.StatusUnauthorized, "invalid or expired mfa token")
+	}
+
+	// Start a new session, named from the login request
+	sessionId := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	client.DeviceName = challenge.DeviceName.String
+
+	refreshToken, err := generateAndStoreRefreshToken(ctx, qtx, challenge.UserID, sessionId, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	accessToken, expiryDate, err := s.tokenKeys.GenerateAccessToken(existingUser, sessionId.String())
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating access token")
+	}
+
+	tokenResponse := types.TokenResponse{
+		AccessToken:  accessToken,
+		ExpiryDate:   expiryDate,
+		RefreshToken: *refreshToken,
+	}
+
+	return &tokenResponse, nil
+}
+
+// startMfaChallenge issues a short-lived token that a user who has entered their password can exchange for
+// tokens along with their second factor
+func (s *AuthService) startMfaChallenge(ctx context.Context, userId int64, deviceName string) (*types.MfaChallengeResponse, error) {
+	mfaToken, err := helpers.GenerateToken(32)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating mfa token")
+	}
+
+	expiryDate := time.Now().Add(mfaChallengeTTL)
+
+	err = s.q.AddMfaChallenge(ctx, queries.AddMfaChallengeParams{
+		UserID:     userId,
+		TokenHash:  helpers.HashToken(*mfaToken),
+		DeviceName: helpers.MakePgString(deviceName),
+		ExpiresAt:  pgtype.Timestamptz{Time: expiryDate, Valid: true},
+	})
 	if err != nil {
-		return nil, types.NewAPIError(http.StatusInternalServerError, err.Error())
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error storing mfa challenge")
 	}
 
-	userResponse := types.NewAuthenticateUserResponse(existingUser.Uuid.String(), accessToken, *refreshToken, expiryDate, existingUser.Superuser)
+	challenge := types.MfaChallengeResponse{
+		MfaRequired: true,
+		MfaToken:    *mfaToken,
+		ExpiryDate:  expiryDate.String(),
+	}
 
-	return userResponse, nil
+	return &challenge, nil
 }
 
-// LogoutUser logs out the user
+// LogoutUser logs out the user by deleting every token in the refresh token's family
 func (s *AuthService) LogoutUser(ctx context.Context, refreshToken string) error {
-	_, err := s.q.GetRefreshTokenByToken(ctx, refreshToken)
-	if err != nil && errors.Is(err, sql.ErrNoRows) {
+	existingToken, err := s.q.GetRefreshTokenByHash(ctx, helpers.HashToken(refreshToken))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "failed to log out: "+err.Error())
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
 		return types.NewAPIError(http.StatusUnauthorized, "already logged out")
 	}
 
-	err = s.q.DeleteRefreshToken(ctx, refreshToken)
+	err = s.q.DeleteRefreshTokenFamily(ctx, existingToken.FamilyID)
 	if err != nil {
 		return types.NewAPIError(http.StatusInternalServerError, "failed to log out: "+err.Error())
 	}
@@ -125,52 +287,103 @@ func (s *AuthService) LogoutUser(ctx context.Context, refreshToken string) error
 	return nil
 }
 
-// CreateNewAccessToken creates a new auth token for the logged-in user
-func (s *AuthService) CreateNewAccessToken(c *gin.Context, refreshToken *string) (*types.AccessTokenResponse, error) {
-	userUuid, err := helpers.ValidateUserUuidFromClaims(c)
-	if err != nil {
-		return nil, err
+// RefreshTokens exchanges a refresh token for a new access token and a new refresh token in the same
+// family. Each refresh token can only be used once. If a used token is presented again it has most likely
+// been stolen, so the whole family is revoked and the user has to log in again
+func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string, client types.SessionClient) (*types.TokenResponse, error) {
+	existingToken, err := s.q.GetRefreshTokenByHash(ctx, helpers.HashToken(refreshToken))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting refresh token")
 	}
 
-	if userUuid == nil {
-		return nil, types.NewAPIError(http.StatusBadRequest, "missing user uuid")
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusUnauthorized, "invalid refresh token")
 	}
 
-	pgUuid, err := helpers.ValidateAndConvertUUID(*userUuid)
-	if err != nil {
-		return nil, err
+	if existingToken.RevokedAt.Valid {
+		return nil, types.NewAPIError(http.StatusUnauthorized, "refresh token revoked")
+	}
+
+	if existingToken.UsedAt.Valid {
+		return nil, s.revokeFamily(ctx, existingToken)
+	}
+
+	if time.Now().After(existingToken.ExpiresAt.Time) {
+		return nil, types.NewAPIError(http.StatusUnauthorized, "refresh token expired")
 	}
 
-	existingUser, err := s.q.GetUserByUuid(c.Request.Context(), *pgUuid)
+	existingUser, err := s.q.GetUserByUuid(ctx, existingToken.UserUuid)
 	if err != nil {
 		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
 	}
 
-	existingToken, err := s.q.GetRefreshTokenByToken(c.Request.Context(), *refreshToken)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return nil, types.NewAPIError(http.StatusBadRequest, "missing user uuid")
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
 	}
+	defer tx.Rollback(ctx)
 
-	if time.Now().After(existingToken.ExpiresAt.Time) {
-		return nil, types.NewAPIError(http.StatusBadRequest, "refresh token expired")
+	qtx := s.q.WithTx(tx)
+
+	// Another request may have used the token since it was read
+	used, err := qtx.MarkRefreshTokenUsed(ctx, existingToken.TokenID)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error rotating refresh token")
+	}
+
+	if used == 0 {
+		return nil, s.revokeFamily(ctx, existingToken)
 	}
 
-	accessToken, expiryDate, err := helpers.GenerateAccessToken(existingUser)
+	// The session keeps its name, but records the client that last used it
+	client.DeviceName = existingToken.DeviceName.String
+
+	newRefreshToken, err := generateAndStoreRefreshToken(ctx, qtx, existingToken.UserID, existingToken.FamilyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	accessToken, expiryDate, err := s.tokenKeys.GenerateAccessToken(existingUser, existingToken.FamilyID.String())
 	if err != nil {
 		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating access token")
 	}
 
-	c.Set("user_uuid", userUuid)
-	c.Set("access_token", accessToken)
+	tokenResponse := types.TokenResponse{
+		AccessToken:  accessToken,
+		ExpiryDate:   expiryDate,
+		RefreshToken: *newRefreshToken,
+	}
+
+	return &tokenResponse, nil
+}
+
+// revokeFamily revokes every token in the family of a refresh token that was used more than once
+func (s *AuthService) revokeFamily(ctx context.Context, refreshToken queries.GetRefreshTokenByHashRow) error {
+	log.Printf("Refresh token reused for user %s, revoking token family %s", refreshToken.UserUuid.String(), refreshToken.FamilyID.String())
 
-	tokenResponse := types.NewAccessTokenResponse(accessToken, expiryDate)
+	if err := s.q.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error revoking refresh tokens")
+	}
 
-	return tokenResponse, nil
+	return types.NewAPIError(http.StatusUnauthorized, "refresh token already used")
 }
 
 // createDefaultData adds default data for a user
 func (s *AuthService) createDefaultData(ctx context.Context, user queries.AddUserRow) error {
 
+	// Every user starts as a member
+	err := s.q.AddUserRoleByName(ctx, queries.AddUserRoleByNameParams{
+		RoleName: types.RoleMember,
+		UserUuid: user.Uuid,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, err.Error())
+	}
+
 	// Create a default list
 	list, err := s.q.AddList(ctx, queries.AddListParams{
 		Name:     "Watchlist",
@@ -190,9 +403,15 @@ func (s *AuthService) createDefaultData(ctx context.Context, user queries.AddUse
 	}
 
 	// Assign the default status to the default list
+	rank, err := helpers.RankBetween("", "")
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, err.Error())
+	}
+
 	_, err = s.q.AddListStatus(ctx, queries.AddListStatusParams{
 		ListUuid:   list.Uuid,
 		StatusUuid: status.Uuid,
+		Rank:       rank,
 	})
 	if err != nil {
 		return types.NewAPIError(http.StatusInternalServerError, err.Error())
@@ -209,28 +428,89 @@ func (s *AuthService) validateDetails(email, username string) error {
 	return nil
 }
 
-// generateAndStoreRefreshToken creates and stores a refresh token for the user
-func (s *AuthService) generateAndStoreRefreshToken(user queries.GetExistingUserRow, ctx context.Context) (*string, error) {
+// generateAndStoreRefreshToken creates a refresh token in a token family and stores its hash along with the
+// client it was issued to
+func generateAndStoreRefreshToken(ctx context.Context, q *queries.Queries, userId int64, familyId pgtype.UUID, client types.SessionClient) (*string, error) {
 	// Generate a refresh token
-	refreshToken, err := helpers.GenerateRefreshToken(64)
+	refreshToken, err := helpers.GenerateToken(64)
 	if err != nil {
 		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating refresh token: "+err.Error())
 	}
 
-	// Set the refresh token to expire in 7 days
-	refreshTokenExpiry := time.Now().Add(time.Hour * 24 * 7)
-
 	RefreshTokenParams := queries.AddRefreshTokenParams{
-		UserID:    user.UserID.Int64,
-		Token:     *refreshToken,
-		ExpiresAt: pgtype.Timestamptz{Time: refreshTokenExpiry, Valid: true},
+		UserID:     userId,
+		TokenHash:  helpers.HashToken(*refreshToken),
+		FamilyID:   familyId,
+		ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(refreshTokenTTL), Valid: true},
+		DeviceName: helpers.MakePgString(client.DeviceName),
+		UserAgent:  helpers.MakePgString(client.UserAgent),
+		IpAddress:  helpers.MakePgString(client.IPAddress),
 	}
 
-	// Store the refresh token in the database
-	refreshTokenRow, err := s.q.AddRefreshToken(ctx, RefreshTokenParams)
+	// Store the refresh token's hash in the database. The token itself is only ever sent to the user
+	_, err = q.AddRefreshToken(ctx, RefreshTokenParams)
 	if err != nil {
 		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating refresh token: "+err.Error())
 	}
 
-	return &refreshTokenRow.Token, nil
+	return refreshToken, nil
+}
+
+// TokenPurger periodically deletes expired refresh, password reset and email verification tokens, and
+// expired MFA challenges. Used refresh tokens are kept until they expire so that reusing one can still be
+// detected
+type TokenPurger struct {
+	q        *queries.Queries
+	interval time.Duration
+}
+
+func NewTokenPurger(q *queries.Queries) *TokenPurger {
+	return &TokenPurger{q: q, interval: time.Hour}
+}
+
+// Run purges expired tokens every interval until the context is cancelled
+func (p *TokenPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+func (p *TokenPurger) purge(ctx context.Context) {
+	deleted, err := p.q.DeleteExpiredRefreshTokens(ctx)
+	if err != nil {
+		log.Printf("Couldn't purge expired refresh tokens: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Purged %d expired refresh tokens", deleted)
+	}
+
+	deleted, err = p.q.DeleteExpiredPasswordResetTokens(ctx)
+	if err != nil {
+		log.Printf("Couldn't purge expired password reset tokens: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Purged %d expired password reset tokens", deleted)
+	}
+
+	deleted, err = p.q.DeleteExpiredEmailVerificationTokens(ctx)
+	if err != nil {
+		log.Printf("Couldn't purge expired email verification tokens: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Purged %d expired email verification tokens", deleted)
+	}
+
+	deleted, err = p.q.DeleteExpiredMfaChallenges(ctx)
+	if err != nil {
+		log.Printf("Couldn't purge expired mfa challenges: %v", err)
+	} else if deleted > 0 {
+		log.Printf("Purged %d expired mfa challenges", deleted)
+	}
 }
@@ -0,0 +1,134 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type BoardIntegrityService struct {
+	q          *queries.Queries
+	rebalancer *RankRebalancer
+}
+
+func NewBoardIntegrityService(q *queries.Queries, rebalancer *RankRebalancer) *BoardIntegrityService {
+	return &BoardIntegrityService{
+		q:          q,
+		rebalancer: rebalancer,
+	}
+}
+
+// CheckAllLists scans every list and returns the ordering problems found on each board
+func (s *BoardIntegrityService) CheckAllLists(ctx context.Context) ([]types.BoardIssue, error) {
+	listUuids, err := s.q.GetAllListUuids(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch lists: %w", err)
+	}
+
+	issues := []types.BoardIssue{}
+
+	for _, listUuid := range listUuids {
+		listIssues, err := s.CheckList(ctx, listUuid)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, listIssues...)
+	}
+
+	return issues, nil
+}
+
+// CheckList returns the ordering problems found on a single board
+func (s *BoardIntegrityService) CheckList(ctx context.Context, listUuid pgtype.UUID) ([]types.BoardIssue, error) {
+	items, err := s.q.GetListItemsForIntegrityCheck(ctx, listUuid)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch items for list %s: %w", listUuid.String(), err)
+	}
+
+	issues := []types.BoardIssue{}
+
+	for i, item := range items {
+		issue := types.BoardIssue{
+			ListUUID:     listUuid.String(),
+			StatusUUID:   item.StatusUuid.String(),
+			ListItemUUID: item.ListItemUuid.String(),
+		}
+
+		if !item.StatusUuid.Valid {
+			issue.StatusUUID = ""
+			issue.Kind = types.BoardIssueMissingStatus
+			issue.Detail = "list item has no status"
+			issues = append(issues, issue)
+			continue
+		}
+
+		if !item.StatusOnList {
+			issue.Kind = types.BoardIssueDetached
+			issue.Detail = "list item status is not attached to the list"
+			issues = append(issues, issue)
+		}
+
+		if !helpers.ValidRank(item.Rank) {
+			issue.Kind = types.BoardIssueInvalidRank
+			issue.Detail = fmt.Sprintf("rank %q is not a valid key", item.Rank)
+			issues = append(issues, issue)
+		} else if len(item.Rank) > helpers.MaxRankLength {
+			issue.Kind = types.BoardIssueLongRank
+			issue.Detail = fmt.Sprintf("rank is %d characters long", len(item.Rank))
+			issues = append(issues, issue)
+		}
+
+		// Items arrive sorted by status and rank, so duplicates are always adjacent
+		if i > 0 && items[i-1].StatusUuid == item.StatusUuid && items[i-1].Rank == item.Rank {
+			issue.Kind = types.BoardIssueDuplicateRank
+			issue.Detail = fmt.Sprintf("rank %q is shared with list item %s", item.Rank, items[i-1].ListItemUuid.String())
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// RepairIssues rewrites the ranks of every column with a rank problem into a canonical order.
+// Each column is rewritten in its own transaction. Status problems can't be repaired automatically
+// and are returned for the caller to report.
+func (s *BoardIntegrityService) RepairIssues(ctx context.Context, issues []types.BoardIssue) ([]types.BoardIssue, error) {
+	repaired := map[string]bool{}
+	remaining := []types.BoardIssue{}
+
+	for _, issue := range issues {
+		switch issue.Kind {
+		case types.BoardIssueDuplicateRank, types.BoardIssueInvalidRank, types.BoardIssueLongRank:
+		default:
+			remaining = append(remaining, issue)
+			continue
+		}
+
+		column := issue.ListUUID + "/" + issue.StatusUUID
+		if repaired[column] {
+			continue
+		}
+
+		listUuid, err := helpers.ValidateAndConvertUUID(issue.ListUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		statusUuid, err := helpers.ValidateAndConvertUUID(issue.StatusUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.rebalancer.Rebalance(ctx, *listUuid, *statusUuid)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't repair list %s status %s: %w", issue.ListUUID, issue.StatusUUID, err)
+		}
+
+		repaired[column] = true
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"net/http"
+	"time"
+)
+
+// verificationResendInterval is how long a user has to wait before another verification link is sent
+const verificationResendInterval = time.Minute
+
+type EmailVerificationService struct {
+	q      *queries.Queries
+	db     *pgxpool.Pool
+	mailer Mailer
+	appUrl string
+	ttl    time.Duration
+}
+
+func NewEmailVerificationService(q *queries.Queries, db *pgxpool.Pool, mailer Mailer, appUrl string, ttl time.Duration) *EmailVerificationService {
+	return &EmailVerificationService{q: q, db: db, mailer: mailer, appUrl: appUrl, ttl: ttl}
+}
+
+// VerifyEmail marks a user's email as verified using the token from a verification link. The token can't be
+// used again, and only verifies the address it was sent to
+func (s *EmailVerificationService) VerifyEmail(ctx context.Context, req types.VerifyEmailRequest) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	// Deleting the token as it is read means that it can only be used once
+	token, err := qtx.UseEmailVerificationToken(ctx, helpers.HashToken(req.Token))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting verification token")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) || time.Now().After(token.ExpiresAt.Time) {
+		return types.NewAPIError(http.StatusBadRequest, "invalid or expired verification token")
+	}
+
+	verified, err := qtx.MarkEmailVerified(ctx, queries.MarkEmailVerifiedParams{
+		UserID: pgtype.Int8{Int64: token.UserID, Valid: true},
+		Email:  token.Email,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error verifying email")
+	}
+
+	if verified == 0 {
+		return types.NewAPIError(http.StatusBadRequest, "the email address has changed since the link was sent")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return nil
+}
+
+// ResendVerification emails a new verification link to an unverified user. Nothing is sent if the email
+// doesn't belong to an unverified user, or if a link was sent to them very recently, but the caller isn't
+// told so that the endpoint can't be used to find out who has an account
+func (s *EmailVerificationService) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.q.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	if user.EmailVerifiedAt.Valid {
+		return nil
+	}
+
+	lastSent, err := s.q.GetLatestEmailVerificationTokenDate(ctx, user.Uuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting verification token")
+	}
+
+	if err == nil && time.Since(lastSent.Time) < verificationResendInterval {
+		return nil
+	}
+
+	return s.sendVerification(ctx, user.Uuid, user.Username, user.Email)
+}
+
+// sendVerification emails a verification link for a user's current address
+func (s *EmailVerificationService) sendVerification(ctx context.Context, userUuid pgtype.UUID, username, email string) error {
+	token, err := s.addVerificationToken(ctx, s.q, userUuid, email)
+	if err != nil {
+		return err
+	}
+
+	s.mailVerification(username, email, *token)
+
+	return nil
+}
+
+// addVerificationToken stores a new verification token for a user's address. Earlier links stop working,
+// so only the latest link sent can verify the address. Callers in a transaction mail the token once it
+// commits
+func (s *EmailVerificationService) addVerificationToken(ctx context.Context, q *queries.Queries, userUuid pgtype.UUID, email string) (*string, error) {
+	token, err := helpers.GenerateToken(32)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating verification token")
+	}
+
+	if err := q.DeleteEmailVerificationTokensForUser(ctx, userUuid); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting verification tokens")
+	}
+
+	err = q.AddEmailVerificationToken(ctx, queries.AddEmailVerificationTokenParams{
+		UserUuid:  userUuid,
+		Email:     email,
+		TokenHash: helpers.HashToken(*token),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.ttl), Valid: true},
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error storing verification token")
+	}
+
+	return token, nil
+}
+
+// mailVerification emails a verification link in the background
+func (s *EmailVerificationService) mailVerification(username, email, token string) {
+	go sendInBackground(s.mailer, Email{
+		To:      email,
+		Subject: "Verify your eigakanban email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"To confirm that this is your email address, open this link within %s:\n\n%s\n\n"+
+			"If you didn't sign up for eigakanban or change your email address, you can ignore this email.\n",
+			username, s.ttl, appLink(s.appUrl, "verify-email", token)),
+	})
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"regexp"
+)
+
+// queryNamePattern finds the sqlc name at the start of a generated query
+var queryNamePattern = regexp.MustCompile(`-- name: (\w+)`)
+
+// fakeDB stands in for the database in tests. Queries are passed to the test's handlers by their sqlc name,
+// and any query without a handler fails, so tests only fake the queries the code under test should run
+type fakeDB struct {
+	queryRow func(name string, args []interface{}) pgx.Row
+	exec     func(name string, args []interface{}) (int64, error)
+}
+
+func (db *fakeDB) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	name := queryName(sql)
+	if db.exec == nil {
+		return pgconn.CommandTag{}, fmt.Errorf("unexpected query %s", name)
+	}
+
+	rows, err := db.exec(name, args)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", rows)), nil
+}
+
+func (db *fakeDB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	return nil, fmt.Errorf("unexpected query %s", queryName(sql))
+}
+
+func (db *fakeDB) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	name := queryName(sql)
+	if db.queryRow == nil {
+		return fakeRow{err: fmt.Errorf("unexpected query %s", name)}
+	}
+
+	return db.queryRow(name, args)
+}
+
+// fakeRow is a row returned by fakeDB. Scan passes each destination to set, or returns err if it is set
+type fakeRow struct {
+	set func(dest any)
+	err error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	for _, d := range dest {
+		if r.set != nil {
+			r.set(d)
+		}
+	}
+
+	return nil
+}
+
+// noRows is a row for a query that found nothing
+var noRows = fakeRow{err: pgx.ErrNoRows}
+
+// queryName returns the sqlc name of a generated query
+func queryName(sql string) string {
+	match := queryNamePattern.FindStringSubmatch(sql)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
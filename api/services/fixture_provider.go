@@ -0,0 +1,118 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FixtureProvider serves recorded TMDB responses from a directory so that the server can run
+// without network access. The directory is laid out like the TMDB API:
+//
+//	search/movie/<query>.json           first page of results for a lower-cased query
+//	search/movie/<query>.page<n>.json   later pages of results
+//	movie/<id>.json                     movie details
+//	movie/<id>/credits.json             movie credits
+//	movie/<id>/images.json              movie images
+//	find/<imdb id>.json                 results of a lookup by IMDb ID
+//
+// Queries are path escaped, so "fight club" is stored as "fight%20club.json". Fixtures are recorded
+// in a single language, so the language of a request is ignored
+type FixtureProvider struct {
+	dir string
+}
+
+func NewFixtureProvider(dir string) *FixtureProvider {
+	return &FixtureProvider{dir: dir}
+}
+
+// Name identifies the provider in cache keys and logs
+func (p *FixtureProvider) Name() string {
+	return "fixtures"
+}
+
+// SearchMovies returns the recorded results for a query, or no results if nothing was recorded
+func (p *FixtureProvider) SearchMovies(query string, page int, _ string) (*tmdb.SearchMovies, error) {
+	name := url.PathEscape(strings.ToLower(strings.TrimSpace(query)))
+	if page > 1 {
+		name = fmt.Sprintf("%s.page%d", name, page)
+	}
+
+	var results tmdb.SearchMovies
+	err := p.load(filepath.Join("search", "movie", name+".json"), &results)
+	if errors.Is(err, ErrMetadataNotFound) {
+		return &tmdb.SearchMovies{
+			Page:                int64(page),
+			SearchMoviesResults: &tmdb.SearchMoviesResults{},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// GetMovieDetails returns the recorded details of a movie
+func (p *FixtureProvider) GetMovieDetails(tmdbId int64, _ string) (*tmdb.MovieDetails, error) {
+	var details tmdb.MovieDetails
+	if err := p.load(filepath.Join("movie", fmt.Sprintf("%d.json", tmdbId)), &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// GetMovieCredits returns the recorded credits of a movie
+func (p *FixtureProvider) GetMovieCredits(tmdbId int64, _ string) (*tmdb.MovieCredits, error) {
+	var credits tmdb.MovieCredits
+	if err := p.load(filepath.Join("movie", fmt.Sprint(tmdbId), "credits.json"), &credits); err != nil {
+		return nil, err
+	}
+	return &credits, nil
+}
+
+// GetMovieImages returns the recorded images of a movie
+func (p *FixtureProvider) GetMovieImages(tmdbId int64, _ string) (*tmdb.MovieImages, error) {
+	var images tmdb.MovieImages
+	if err := p.load(filepath.Join("movie", fmt.Sprint(tmdbId), "images.json"), &images); err != nil {
+		return nil, err
+	}
+	return &images, nil
+}
+
+// FindByImdbID returns the recorded results of a lookup by IMDb ID, or no results if nothing was recorded
+func (p *FixtureProvider) FindByImdbID(imdbId string) (*tmdb.FindByID, error) {
+	var results tmdb.FindByID
+	err := p.load(filepath.Join("find", url.PathEscape(imdbId)+".json"), &results)
+	if errors.Is(err, ErrMetadataNotFound) {
+		return &tmdb.FindByID{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// load decodes a fixture file, returning ErrMetadataNotFound if it doesn't exist
+func (p *FixtureProvider) load(name string, dest any) error {
+	data, err := os.ReadFile(filepath.Join(p.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: no fixture at %s", ErrMetadataNotFound, name)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't read fixture %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("couldn't decode fixture %s: %w", name, err)
+	}
+
+	return nil
+}
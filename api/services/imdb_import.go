@@ -0,0 +1,265 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// importSourceImdb identifies import jobs created from IMDb exports
+const importSourceImdb = "imdb"
+
+// Files of an IMDb export. Both are keyed by the IMDb ID in the Const column
+const (
+	imdbRatings   = "ratings.csv"
+	imdbWatchlist = "WATCHLIST.csv"
+)
+
+// imdbIdPattern matches IMDb title IDs, such as tt0137523
+var imdbIdPattern = regexp.MustCompile(`^tt\d+$`)
+
+type ImdbImportService struct {
+	q      *queries.Queries
+	db     *pgxpool.Pool
+	runner *ImportJobRunner
+}
+
+func NewImdbImportService(q *queries.Queries, db *pgxpool.Pool, runner *ImportJobRunner) *ImdbImportService {
+	return &ImdbImportService{q: q, db: db, runner: runner}
+}
+
+// CreateImportJob stores the rows of an IMDb ratings and watchlist export and queues them to be imported
+// in the background. Either file may be nil. Watchlist entries are added to the given list and status, or
+// to the first column of the user's default list if neither is given
+func (s *ImdbImportService) CreateImportJob(ctx context.Context, userUuid string, ratings, watchlist io.Reader, listUuid, statusUuid string) (*types.ImportJobResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if ratings == nil && watchlist == nil {
+		return nil, types.NewAPIError(http.StatusBadRequest, "a ratings or watchlist file is required")
+	}
+
+	var ratingRows, watchlistRows []csvRow
+	remaining := int64(maxImportSize)
+	if ratings != nil {
+		ratingRows, err = readImdbCsv(ratings, imdbRatings, &remaining)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pgListUuid := pgtype.UUID{Valid: false}
+	pgStatusUuid := pgtype.UUID{Valid: false}
+	if watchlist != nil {
+		watchlistRows, err = readImdbCsv(watchlist, imdbWatchlist, &remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		pgListUuid, pgStatusUuid, err = watchlistColumn(ctx, s.q, *pgUserUuid, listUuid, statusUuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	jobUuid, err := qtx.CreateImportJob(ctx, queries.CreateImportJobParams{
+		UserUuid:   *pgUserUuid,
+		Source:     importSourceImdb,
+		ListUuid:   pgListUuid,
+		StatusUuid: pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error creating import job")
+	}
+
+	files := []struct {
+		kind string
+		rows []csvRow
+	}{
+		{importRowRating, ratingRows},
+		{importRowWatchlist, watchlistRows},
+	}
+
+	for _, file := range files {
+		for _, row := range file.rows {
+			if err := qtx.AddImportJobRow(ctx, imdbJobRow(jobUuid, file.kind, row)); err != nil {
+				return nil, types.NewAPIError(http.StatusInternalServerError, "error adding import rows")
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	s.runner.Schedule(jobUuid)
+
+	return getImportJob(ctx, s.q, jobUuid)
+}
+
+// readImdbCsv reads an IMDb export file, checking that it has the IMDb ID column
+func readImdbCsv(r io.Reader, name string, remaining *int64) ([]csvRow, error) {
+	rows, err := readLimitedCsvRows(r, name, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > 0 {
+		if _, ok := rows[0].values["Const"]; !ok {
+			return nil, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("%s is not an imdb export", name))
+		}
+	}
+
+	return rows, nil
+}
+
+// imdbJobRow converts a row of an IMDb export to an import job row. Rows with an invalid IMDb ID or
+// rating are stored as unmatched so that they appear in the report
+func imdbJobRow(jobUuid pgtype.UUID, kind string, row csvRow) queries.AddImportJobRowParams {
+	params := queries.AddImportJobRowParams{
+		JobUuid:    jobUuid,
+		File:       row.file,
+		Line:       int32(row.line),
+		Kind:       kind,
+		ExternalID: row.get("Const"),
+		Title:      row.get("Title"),
+		Year:       row.get("Year"),
+	}
+
+	unmatched := func(reason string) queries.AddImportJobRowParams {
+		params.Outcome = helpers.MakePgString(importRowUnmatched)
+		params.Reason = helpers.MakePgString(reason)
+		return params
+	}
+
+	if !imdbIdPattern.MatchString(params.ExternalID) {
+		return unmatched("invalid imdb id")
+	}
+
+	if kind == importRowRating {
+		rating, err := parseImdbRating(row.get("Your Rating"))
+		if err != nil {
+			return unmatched("invalid rating")
+		}
+		params.Rating = helpers.MakePgNumeric(&rating)
+	}
+
+	return params
+}
+
+// parseImdbRating converts an IMDb rating out of ten to a rating out of five stars in half-star steps
+func parseImdbRating(s string) (float64, error) {
+	rating, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if rating < 1 || rating > 10 {
+		return 0, fmt.Errorf("rating %d is out of range", rating)
+	}
+
+	return float64(rating) / 2, nil
+}
+
+// processImdbRow imports a single row of an IMDb import job
+func (r *ImportJobRunner) processImdbRow(ctx context.Context, job queries.GetImportJobRow, row queries.GetPendingImportJobRowsRow) (importRowResult, error) {
+	itemUuid, reason, err := r.resolveImdbId(ctx, row.ExternalID)
+	if err != nil {
+		return importRowResult{}, err
+	}
+
+	if reason != "" {
+		return importRowResult{outcome: importRowUnmatched, reason: reason}, nil
+	}
+
+	var created bool
+	switch row.Kind {
+	case importRowRating:
+		created, err = rateItem(ctx, r.q, itemUuid, job.UserUuid, helpers.PgNumericPointer(row.Rating))
+	case importRowWatchlist:
+		// The list or status may have been deleted since the job was created
+		if !job.ListUuid.Valid || !job.StatusUuid.Valid {
+			return importRowResult{outcome: importRowUnmatched, reason: "list or status no longer exists"}, nil
+		}
+
+		created, err = addToListColumn(ctx, r.q, r.db, r.rebalancer, job.ListUuid, job.StatusUuid, itemUuid, job.UserUuid)
+
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+			return importRowResult{outcome: importRowUnmatched, reason: apiErr.Message}, nil
+		}
+	default:
+		return importRowResult{outcome: importRowUnmatched, reason: "unknown row kind"}, nil
+	}
+	if err != nil {
+		return importRowResult{}, err
+	}
+
+	if !created {
+		return importRowResult{outcome: importRowSkipped}, nil
+	}
+
+	return importRowResult{outcome: importRowCreated}, nil
+}
+
+// resolveImdbId finds the item for an IMDb ID, importing the movie from TMDB if there is no item yet.
+// A reason is returned instead if the ID doesn't belong to a movie on TMDB
+func (r *ImportJobRunner) resolveImdbId(ctx context.Context, imdbId string) (pgtype.UUID, string, error) {
+	itemUuid, err := r.q.GetItemUuidByImdbId(ctx, helpers.MakePgString(imdbId))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, "", types.NewAPIError(http.StatusInternalServerError, "error getting item by imdb id")
+	}
+
+	if err == nil {
+		return itemUuid, "", nil
+	}
+
+	results, _, err := r.metadataCache.FindByImdbID(ctx, imdbId)
+	if err != nil {
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return pgtype.UUID{}, "no match found", nil
+		}
+		return pgtype.UUID{}, "", err
+	}
+
+	if len(results.MovieResults) == 0 {
+		return pgtype.UUID{}, "no match found", nil
+	}
+
+	item, _, err := r.itemsService.ImportTmdbMovie(ctx, results.MovieResults[0].ID)
+	if err != nil {
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return pgtype.UUID{}, "movie not found", nil
+		}
+		return pgtype.UUID{}, "", err
+	}
+
+	pgItemUuid, err := helpers.ValidateAndConvertUUID(item.UUID)
+	if err != nil {
+		return pgtype.UUID{}, "", err
+	}
+
+	return *pgItemUuid, "", nil
+}
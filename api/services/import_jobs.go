@@ -0,0 +1,344 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"net/http"
+	"time"
+)
+
+// States of an import job
+const (
+	importJobPending   = "pending"
+	importJobRunning   = "running"
+	importJobCompleted = "completed"
+	importJobFailed    = "failed"
+)
+
+// Kinds of import job rows
+const (
+	importRowRating    = "rating"
+	importRowWatchlist = "watchlist"
+)
+
+// Outcomes of a processed import job row
+const (
+	importRowCreated   = "created"
+	importRowSkipped   = "skipped"
+	importRowUnmatched = "unmatched"
+)
+
+// importRowResult is the outcome of a processed row, with the reason for rows that couldn't be imported
+type importRowResult struct {
+	outcome string
+	reason  string
+}
+
+// ImportJobRunner processes import jobs in the background, one at a time. Jobs are queued when they are
+// created or resumed, and unfinished jobs are swept periodically so that jobs interrupted by a restart
+// carry on from the first row that wasn't processed
+type ImportJobRunner struct {
+	q             *queries.Queries
+	db            *pgxpool.Pool
+	metadataCache *MetadataCache
+	itemsService  *ItemsService
+	rebalancer    *RankRebalancer
+	queue         chan pgtype.UUID
+	interval      time.Duration
+	batchSize     int32
+}
+
+func NewImportJobRunner(q *queries.Queries, db *pgxpool.Pool, metadataCache *MetadataCache, rebalancer *RankRebalancer) *ImportJobRunner {
+	return &ImportJobRunner{
+		q:             q,
+		db:            db,
+		metadataCache: metadataCache,
+		itemsService:  NewItemsService(q, metadataCache),
+		rebalancer:    rebalancer,
+		queue:         make(chan pgtype.UUID, 64),
+		interval:      time.Minute,
+		batchSize:     100,
+	}
+}
+
+// Schedule queues a job without blocking the caller
+func (r *ImportJobRunner) Schedule(jobUuid pgtype.UUID) {
+	select {
+	case r.queue <- jobUuid:
+	default:
+		// The queue is full. The periodic sweep will pick the job up
+	}
+}
+
+// Run processes queued jobs and sweeps for unfinished jobs until the context is cancelled
+func (r *ImportJobRunner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobUuid := <-r.queue:
+			r.runJob(ctx, jobUuid)
+		case <-ticker.C:
+			r.sweep(ctx)
+		}
+	}
+}
+
+// sweep runs every job that is waiting or was interrupted, oldest first
+func (r *ImportJobRunner) sweep(ctx context.Context) {
+	jobs, err := r.q.GetUnfinishedImportJobs(ctx)
+	if err != nil {
+		log.Printf("Couldn't fetch unfinished import jobs: %v", err)
+		return
+	}
+
+	for _, jobUuid := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		r.runJob(ctx, jobUuid)
+	}
+}
+
+// runJob processes the remaining rows of a job. Each row's outcome is stored as soon as it is processed,
+// so a job that fails or is interrupted can be resumed without repeating work
+func (r *ImportJobRunner) runJob(ctx context.Context, jobUuid pgtype.UUID) {
+	job, err := r.q.GetImportJob(ctx, jobUuid)
+	if err != nil {
+		log.Printf("Couldn't get import job %s: %v", jobUuid.String(), err)
+		return
+	}
+
+	// The job may have been queued more than once
+	if job.State != importJobPending && job.State != importJobRunning {
+		return
+	}
+
+	var process func(context.Context, queries.GetImportJobRow, queries.GetPendingImportJobRowsRow) (importRowResult, error)
+	switch job.Source {
+	case importSourceImdb:
+		process = r.processImdbRow
+	default:
+		r.finishJob(ctx, jobUuid, importJobFailed, "unknown import source")
+		return
+	}
+
+	if err := r.q.StartImportJob(ctx, jobUuid); err != nil {
+		log.Printf("Couldn't start import job %s: %v", jobUuid.String(), err)
+		return
+	}
+
+	for {
+		rows, err := r.q.GetPendingImportJobRows(ctx, queries.GetPendingImportJobRowsParams{
+			JobUuid:   jobUuid,
+			BatchSize: r.batchSize,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				r.finishJob(ctx, jobUuid, importJobFailed, "error fetching import rows")
+			}
+			return
+		}
+
+		if len(rows) == 0 {
+			r.finishJob(ctx, jobUuid, importJobCompleted, "")
+			return
+		}
+
+		for _, row := range rows {
+			result, err := process(ctx, job, row)
+			if err != nil {
+				// Interrupted jobs stay running and are picked up by the next sweep
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("Import job %s failed: %v", jobUuid.String(), err)
+				r.finishJob(ctx, jobUuid, importJobFailed, err.Error())
+				return
+			}
+
+			err = r.q.SetImportJobRowOutcome(ctx, queries.SetImportJobRowOutcomeParams{
+				Outcome:        helpers.MakePgString(result.outcome),
+				Reason:         helpers.MakePgString(result.reason),
+				ImportJobRowID: row.ImportJobRowID,
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					r.finishJob(ctx, jobUuid, importJobFailed, "error saving import progress")
+				}
+				return
+			}
+		}
+	}
+}
+
+func (r *ImportJobRunner) finishJob(ctx context.Context, jobUuid pgtype.UUID, state, message string) {
+	err := r.q.FinishImportJob(ctx, queries.FinishImportJobParams{
+		State:   state,
+		Error:   helpers.MakePgString(message),
+		JobUuid: jobUuid,
+	})
+	if err != nil {
+		log.Printf("Couldn't finish import job %s: %v", jobUuid.String(), err)
+	}
+}
+
+type ImportJobsService struct {
+	q      *queries.Queries
+	runner *ImportJobRunner
+}
+
+func NewImportJobsService(q *queries.Queries, runner *ImportJobRunner) *ImportJobsService {
+	return &ImportJobsService{q: q, runner: runner}
+}
+
+// GetImportJob returns the progress of one of the authenticated user's import jobs
+func (s *ImportJobsService) GetImportJob(ctx context.Context, jobUuid, userUuid string) (*types.ImportJobResponse, error) {
+	pgJobUuid, err := helpers.ValidateAndConvertUUID(jobUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeResource(ctx, s.q, userActor(userUuid), ownerOnly, resourceImportJob, *pgJobUuid); err != nil {
+		return nil, err
+	}
+
+	return getImportJob(ctx, s.q, *pgJobUuid)
+}
+
+// GetImportJobsForUser returns the authenticated user's import jobs, most recent first
+func (s *ImportJobsService) GetImportJobsForUser(ctx context.Context, userUuid string, pagination *types.Pagination) (*types.PaginatedImportJobsResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.q.GetImportJobsCountForUser(ctx, *pgUserUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching import job count")
+	}
+
+	pagination.Total = total
+
+	if total == 0 {
+		response := &types.PaginatedImportJobsResponse{
+			Pagination: *pagination,
+			Jobs:       []types.ImportJobResponse{},
+		}
+		return response, nil
+	}
+
+	rows, err := s.q.GetImportJobsForUser(ctx, queries.GetImportJobsForUserParams{
+		UserUuid: *pgUserUuid,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching import jobs")
+	}
+
+	jobs := make([]types.ImportJobResponse, len(rows))
+	for i, row := range rows {
+		jobs[i] = importJobResponse(queries.GetImportJobRow(row), []types.UnmatchedRow{})
+	}
+
+	response := types.PaginatedImportJobsResponse{
+		Pagination: *pagination,
+		Jobs:       jobs,
+	}
+
+	return &response, nil
+}
+
+// ResumeImportJob queues a failed import job to carry on from the first row that wasn't processed
+func (s *ImportJobsService) ResumeImportJob(ctx context.Context, jobUuid, userUuid string) (*types.ImportJobResponse, error) {
+	pgJobUuid, err := helpers.ValidateAndConvertUUID(jobUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeResource(ctx, s.q, userActor(userUuid), ownerOnly, resourceImportJob, *pgJobUuid); err != nil {
+		return nil, err
+	}
+
+	_, err = s.q.ResumeImportJob(ctx, *pgJobUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error resuming import job")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusConflict, "only failed import jobs can be resumed")
+	}
+
+	s.runner.Schedule(*pgJobUuid)
+
+	return getImportJob(ctx, s.q, *pgJobUuid)
+}
+
+// getImportJob fetches an import job with the rows that couldn't be imported
+func getImportJob(ctx context.Context, q *queries.Queries, jobUuid pgtype.UUID) (*types.ImportJobResponse, error) {
+	job, err := q.GetImportJob(ctx, jobUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting import job")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "import job not found")
+	}
+
+	rows, err := q.GetUnmatchedImportJobRows(ctx, jobUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching unmatched rows")
+	}
+
+	unmatched := make([]types.UnmatchedRow, len(rows))
+	for i, row := range rows {
+		unmatched[i] = types.UnmatchedRow{
+			File:   row.File,
+			Line:   int(row.Line),
+			Name:   row.Title,
+			Year:   row.Year,
+			Reason: row.Reason.String,
+		}
+	}
+
+	response := importJobResponse(job, unmatched)
+
+	return &response, nil
+}
+
+func importJobResponse(job queries.GetImportJobRow, unmatched []types.UnmatchedRow) types.ImportJobResponse {
+	return types.ImportJobResponse{
+		UUID:         job.Uuid.String(),
+		Source:       job.Source,
+		State:        job.State,
+		ListUUID:     pgUuidPointer(job.ListUuid),
+		StatusUUID:   pgUuidPointer(job.StatusUuid),
+		Total:        job.Total,
+		Processed:    job.Processed,
+		Error:        helpers.PgTextPointer(job.Error),
+		CreatedDate:  job.CreatedDate.Time,
+		StartedDate:  helpers.PgTimestamptzPointer(job.StartedDate),
+		FinishedDate: helpers.PgTimestamptzPointer(job.FinishedDate),
+		Report: types.ImportReport{
+			Rows:           int(job.Total),
+			Matched:        int(job.Matched),
+			RatingsCreated: int(job.RatingsCreated),
+			WatchlistAdded: int(job.WatchlistAdded),
+			Skipped:        int(job.Skipped),
+			Unmatched:      unmatched,
+		},
+	}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxImportSize is the most CSV data, in bytes, that a single import reads. Rows are held in memory until
+// they are imported, and a small archive can unzip to far more than was uploaded
+const maxImportSize = 64 << 20
+
+// csvRow is a single row of an imported CSV file, keyed by column name
+type csvRow struct {
+	file   string
+	line   int
+	values map[string]string
+}
+
+func (r csvRow) get(column string) string {
+	return strings.TrimSpace(r.values[column])
+}
+
+// readCsvRows reads the rows of a CSV file, keyed by the header row
+func readCsvRows(r io.Reader, name string) ([]csvRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("couldn't read %s", name))
+	}
+
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var rows []csvRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("couldn't read %s: %v", name, err))
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		values := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				values[column] = record[i]
+			}
+		}
+
+		rows = append(rows, csvRow{file: name, line: line, values: values})
+	}
+
+	return rows, nil
+}
+
+// readLimitedCsvRows reads the rows of a CSV file, counting its size against the bytes an import has left
+// to read. Files of the same import share what is left, so the limit applies to the import as a whole
+func readLimitedCsvRows(r io.Reader, name string, remaining *int64) ([]csvRow, error) {
+	limited := &io.LimitedReader{R: r, N: *remaining + 1}
+
+	rows, err := readCsvRows(limited, name)
+
+	// A file cut off at the limit may not parse, so the limit is checked before the error
+	if limited.N == 0 {
+		return nil, types.NewAPIError(http.StatusRequestEntityTooLarge, fmt.Sprintf("imports can't be larger than %d MB", maxImportSize>>20))
+	}
+	*remaining = limited.N - 1
+
+	return rows, err
+}
+
+// rateItem records an imported rating, adding it to the user's review of the item if they have one.
+// It returns false if the user had already rated the item
+func rateItem(ctx context.Context, q *queries.Queries, itemUuid, userUuid pgtype.UUID, rating *float64) (bool, error) {
+	review, err := q.GetReviewForUserItem(ctx, queries.GetReviewForUserItemParams{
+		ItemUuid: itemUuid,
+		UserUuid: userUuid,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, types.NewAPIError(http.StatusInternalServerError, "error checking reviews")
+	}
+
+	switch {
+	case err == nil && review.Rating.Valid:
+		return false, nil
+	case err == nil:
+		err = q.UpdateReview(ctx, queries.UpdateReviewParams{
+			Content:    review.Content,
+			Rating:     helpers.MakePgNumeric(rating),
+			ReviewUuid: review.Uuid,
+		})
+	default:
+		_, err = q.AddReview(ctx, queries.AddReviewParams{
+			ItemUuid: itemUuid,
+			UserUuid: userUuid,
+			Rating:   helpers.MakePgNumeric(rating),
+		})
+	}
+	if err != nil {
+		return false, types.NewAPIError(http.StatusInternalServerError, "error adding rating")
+	}
+
+	return true, nil
+}
+
+// addToListColumn adds an imported item to the end of a status column in its own transaction, so that the
+// list stays locked until the card is in place. Imports ignore WIP limits so that a full column doesn't stop
+// the rest of the import. It returns false if the item was already on the list
+func addToListColumn(ctx context.Context, q *queries.Queries, db *pgxpool.Pool, rebalancer *RankRebalancer, listUuid, statusUuid, itemUuid, userUuid pgtype.UUID) (bool, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return false, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	_, err = qtx.GetListItemForItem(ctx, queries.GetListItemForItemParams{
+		ListUuid: listUuid,
+		ItemUuid: itemUuid,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, types.NewAPIError(http.StatusInternalServerError, "error checking list items")
+	}
+
+	if err == nil {
+		return false, nil
+	}
+
+	_, rank, err := moveCardToStatus(ctx, qtx, listUuid, itemUuid, statusUuid, userUuid.String(), true)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	if len(rank) > helpers.MaxRankLength {
+		rebalancer.Schedule(listUuid, statusUuid)
+	}
+
+	return true, nil
+}
+
+// watchlistColumn checks the list and status chosen for the watchlist, falling back to the user's default
+// list if neither is given
+func watchlistColumn(ctx context.Context, q *queries.Queries, userUuid pgtype.UUID, listUuid, statusUuid string) (pgtype.UUID, pgtype.UUID, error) {
+	if listUuid == "" && statusUuid == "" {
+		return defaultListColumn(ctx, q, userUuid)
+	}
+
+	if listUuid == "" || statusUuid == "" {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusBadRequest, "list_uuid and status_uuid must be given together")
+	}
+
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	pgStatusUuid, err := helpers.ValidateAndConvertUUID(statusUuid)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	list, err := q.GetListByUuid(ctx, *pgListUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusInternalServerError, "error getting list by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusNotFound, "list not found")
+	}
+
+	if err := authorize(userActor(userUuid.String()), ownerOnly, resourceList, ownership{owner: list.UserUuid}); err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	if err := checkStatusOnList(ctx, q, *pgListUuid, *pgStatusUuid); err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	return *pgListUuid, *pgStatusUuid, nil
+}
+
+// defaultListColumn returns a user's default list, which is their oldest list, and its first status column.
+// Imported watchlists are added to this column when no other column is chosen
+func defaultListColumn(ctx context.Context, q *queries.Queries, userUuid pgtype.UUID) (pgtype.UUID, pgtype.UUID, error) {
+	list, err := q.GetDefaultListForUser(ctx, userUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusInternalServerError, "error getting default list")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusNotFound, "user has no list for the watchlist")
+	}
+
+	statuses, err := q.GetStatusesForList(ctx, list.Uuid)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusInternalServerError, "error fetching list statuses")
+	}
+
+	if len(statuses) == 0 {
+		return pgtype.UUID{}, pgtype.UUID{}, types.NewAPIError(http.StatusNotFound, "default list has no statuses")
+	}
+
+	return list.Uuid, statuses[0].StatusUuid, nil
+}
@@ -7,15 +7,21 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/jackc/pgx/v5/pgtype"
 	"net/http"
 )
 
 type ItemsService struct {
-	q *queries.Queries
+	q             *queries.Queries
+	metadataCache *MetadataCache
 }
 
-func NewItemsService(q *queries.Queries) *ItemsService {
-	return &ItemsService{q: q}
+func NewItemsService(q *queries.Queries, metadataCache *MetadataCache) *ItemsService {
+	return &ItemsService{
+		q:             q,
+		metadataCache: metadataCache,
+	}
 }
 
 // GetAllItems retrieves all items from the database as a paginated list
@@ -50,8 +56,11 @@ func (s *ItemsService) GetAllItems(ctx context.Context, pagination *types.Pagina
 
 	for i, item := range items {
 		itemsResponse[i] = types.ItemsResponse{
-			UUID:  item.Uuid.String(),
-			Title: item.Title,
+			UUID:        item.Uuid.String(),
+			Title:       item.Title,
+			TmdbID:      helpers.PgInt8Pointer(item.TmdbID),
+			ReleaseDate: helpers.PgDatePointer(item.ReleaseDate),
+			PosterPath:  helpers.PgTextPointer(item.PosterPath),
 		}
 	}
 
@@ -64,22 +73,64 @@ func (s *ItemsService) GetAllItems(ctx context.Context, pagination *types.Pagina
 }
 
 // GetItemByUuid returns a single item from the database by UUID
-func (s *ItemsService) GetItemByUuid(ctx context.Context, uuid string) (*queries.GetItemByUuidRow, error) {
+func (s *ItemsService) GetItemByUuid(ctx context.Context, uuid string) (*types.ItemDetailsResponse, error) {
 	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	item, err := s.q.GetItemByUuid(ctx, *pgUuid)
+	return getItemDetails(ctx, s.q, *pgUuid)
+}
+
+// ImportTmdbMovie adds a movie to the database using its TMDB details. If the movie has
+// already been imported the existing item is returned and created is false
+func (s *ItemsService) ImportTmdbMovie(ctx context.Context, tmdbId int64) (*types.ItemDetailsResponse, bool, error) {
+	pgTmdbId := pgtype.Int8{Int64: tmdbId, Valid: true}
+
+	existingUuid, err := s.q.GetItemUuidByTmdbId(ctx, pgTmdbId)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+		return nil, false, types.NewAPIError(http.StatusInternalServerError, "error getting item by tmdb id")
 	}
 
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	if err == nil {
+		item, err := getItemDetails(ctx, s.q, existingUuid)
+		return item, false, err
 	}
 
-	return &item, nil
+	details, _, err := s.metadataCache.GetMovieDetails(ctx, tmdbId, "")
+	if err != nil {
+		return nil, false, err
+	}
+
+	metadata := movieMetadataFromDetails(details)
+
+	itemUuid, err := s.q.AddTmdbItem(ctx, queries.AddTmdbItemParams{
+		Title:            metadata.Title,
+		TmdbID:           pgTmdbId,
+		ImdbID:           metadata.ImdbID,
+		ReleaseDate:      metadata.ReleaseDate,
+		Runtime:          metadata.Runtime,
+		Overview:         metadata.Overview,
+		OriginalLanguage: metadata.OriginalLanguage,
+		PosterPath:       metadata.PosterPath,
+		BackdropPath:     metadata.BackdropPath,
+		Genres:           metadata.Genres,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, types.NewAPIError(http.StatusInternalServerError, "error adding item")
+	}
+
+	// Another request imported the same movie while TMDB was being queried
+	created := !errors.Is(err, sql.ErrNoRows)
+	if !created {
+		itemUuid, err = s.q.GetItemUuidByTmdbId(ctx, pgTmdbId)
+		if err != nil {
+			return nil, false, types.NewAPIError(http.StatusInternalServerError, "error getting item by tmdb id")
+		}
+	}
+
+	item, err := getItemDetails(ctx, s.q, itemUuid)
+	return item, created, err
 }
 
 // AddItem adds an item to the database
@@ -141,3 +192,79 @@ func (s *ItemsService) DeleteItem(ctx context.Context, uuid string) error {
 func (s *ItemsService) GetItemsCount(ctx context.Context) (int64, error) {
 	return s.q.GetItemsCount(ctx)
 }
+
+// movieMetadata holds the TMDB details stored on an item
+type movieMetadata struct {
+	Title            string
+	ImdbID           pgtype.Text
+	ReleaseDate      pgtype.Date
+	Runtime          pgtype.Int4
+	Overview         pgtype.Text
+	OriginalLanguage pgtype.Text
+	PosterPath       pgtype.Text
+	BackdropPath     pgtype.Text
+	Genres           []string
+}
+
+// movieMetadataFromDetails picks the fields stored on an item from the TMDB details of a movie
+func movieMetadataFromDetails(details *tmdb.MovieDetails) *movieMetadata {
+	genres := make([]string, len(details.Genres))
+	for i, genre := range details.Genres {
+		genres[i] = genre.Name
+	}
+
+	metadata := movieMetadata{
+		Title:            details.Title,
+		ImdbID:           helpers.MakePgString(details.IMDbID),
+		ReleaseDate:      helpers.MakePgDate(details.ReleaseDate),
+		Runtime:          pgtype.Int4{Int32: int32(details.Runtime), Valid: details.Runtime > 0},
+		Overview:         helpers.MakePgString(details.Overview),
+		OriginalLanguage: helpers.MakePgString(details.OriginalLanguage),
+		PosterPath:       helpers.MakePgString(details.PosterPath),
+		BackdropPath:     helpers.MakePgString(details.BackdropPath),
+		Genres:           genres,
+	}
+
+	return &metadata
+}
+
+// getItemDetails fetches an item with its full metadata
+func getItemDetails(ctx context.Context, q *queries.Queries, uuid pgtype.UUID) (*types.ItemDetailsResponse, error) {
+	item, err := q.GetItemByUuid(ctx, uuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	}
+
+	genres := item.Genres
+	if genres == nil {
+		genres = []string{}
+	}
+
+	response := types.ItemDetailsResponse{
+		UUID:                item.Uuid.String(),
+		Title:               item.Title,
+		TmdbID:              helpers.PgInt8Pointer(item.TmdbID),
+		ImdbID:              helpers.PgTextPointer(item.ImdbID),
+		ReleaseDate:         helpers.PgDatePointer(item.ReleaseDate),
+		Runtime:             helpers.PgInt4Pointer(item.Runtime),
+		Overview:            helpers.PgTextPointer(item.Overview),
+		OriginalLanguage:    helpers.PgTextPointer(item.OriginalLanguage),
+		PosterPath:          helpers.PgTextPointer(item.PosterPath),
+		BackdropPath:        helpers.PgTextPointer(item.BackdropPath),
+		Genres:              genres,
+		RatingCount:         item.RatingCount,
+		ReviewCount:         item.ReviewCount,
+		MetadataRefreshedAt: helpers.PgTimestamptzPointer(item.MetadataRefreshedAt),
+		CreatedDate:         item.CreatedDate.Time,
+	}
+
+	if item.RatingCount > 0 {
+		response.AverageRating = &item.AverageRating
+	}
+
+	return &response, nil
+}
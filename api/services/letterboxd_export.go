@@ -0,0 +1,271 @@
+package services
+
+import (
+	"archive/zip"
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// exportBatchSize is the number of rows fetched at a time while an export is streamed
+const exportBatchSize = 500
+
+// Column layouts of the Letterboxd export files
+var (
+	letterboxdWatchlistHeader = []string{"Date", "Name", "Year", "Letterboxd URI"}
+	letterboxdDiaryHeader     = []string{"Date", "Name", "Year", "Letterboxd URI", "Rating", "Rewatch", "Tags", "Watched Date"}
+	letterboxdRatingsHeader   = []string{"Date", "Name", "Year", "Letterboxd URI", "Rating"}
+	letterboxdReviewsHeader   = []string{"Date", "Name", "Year", "Letterboxd URI", "Rating", "Rewatch", "Review", "Tags", "Watched Date"}
+)
+
+type LetterboxdExportService struct {
+	q *queries.Queries
+}
+
+func NewLetterboxdExportService(q *queries.Queries) *LetterboxdExportService {
+	return &LetterboxdExportService{q: q}
+}
+
+// LetterboxdExport is a user's data ready to be written as a Letterboxd export archive
+type LetterboxdExport struct {
+	q                   *queries.Queries
+	userUuid            pgtype.UUID
+	watchlistListUuid   pgtype.UUID
+	watchlistStatusUuid pgtype.UUID
+	Filename            string
+}
+
+// NewExport prepares an export of a user's data. Users can only export their own data. The watchlist is the
+// status column given by listUuid and statusUuid, or the column imported watchlists go to if neither is given
+func (s *LetterboxdExportService) NewExport(ctx context.Context, uuid, requesterUuid, listUuid, statusUuid string) (*LetterboxdExport, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(userActor(requesterUuid), ownerOnly, resourceUser, ownership{owner: *pgUuid}); err != nil {
+		return nil, err
+	}
+
+	user, err := s.q.GetUserByUuid(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	watchlistListUuid, watchlistStatusUuid, err := watchlistColumn(ctx, s.q, *pgUuid, listUuid, statusUuid)
+	if err != nil {
+		// Users without a default list have an empty watchlist
+		var apiErr *types.APIError
+		if listUuid != "" || statusUuid != "" || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+	}
+
+	export := LetterboxdExport{
+		q:                   s.q,
+		userUuid:            *pgUuid,
+		watchlistListUuid:   watchlistListUuid,
+		watchlistStatusUuid: watchlistStatusUuid,
+		Filename:            fmt.Sprintf("letterboxd-%s-%s.zip", user.Username, time.Now().UTC().Format(time.DateOnly)),
+	}
+
+	return &export, nil
+}
+
+// WriteTo streams the export as a zip archive with the same files and columns as a Letterboxd export.
+// Rows are fetched in batches so that large accounts are never held in memory
+func (e *LetterboxdExport) WriteTo(ctx context.Context, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name   string
+		header []string
+		write  func(*csv.Writer) error
+	}{
+		{letterboxdWatchlist, letterboxdWatchlistHeader, func(cw *csv.Writer) error { return e.writeWatchlist(ctx, cw) }},
+		{letterboxdDiary, letterboxdDiaryHeader, func(cw *csv.Writer) error { return e.writeDiary(ctx, cw) }},
+		{letterboxdRatings, letterboxdRatingsHeader, func(cw *csv.Writer) error { return e.writeRatings(ctx, cw) }},
+		{letterboxdReviews, letterboxdReviewsHeader, func(cw *csv.Writer) error { return e.writeReviews(ctx, cw) }},
+	}
+
+	for _, file := range files {
+		fw, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("couldn't add %s: %w", file.name, err)
+		}
+
+		cw := csv.NewWriter(fw)
+		if err := cw.Write(file.header); err != nil {
+			return fmt.Errorf("couldn't write %s: %w", file.name, err)
+		}
+
+		if err := file.write(cw); err != nil {
+			return fmt.Errorf("couldn't write %s: %w", file.name, err)
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("couldn't write %s: %w", file.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+func (e *LetterboxdExport) writeWatchlist(ctx context.Context, cw *csv.Writer) error {
+	if !e.watchlistStatusUuid.Valid {
+		return nil
+	}
+
+	return exportPages(func(offset int32) ([]queries.GetWatchlistForExportRow, error) {
+		return e.q.GetWatchlistForExport(ctx, queries.GetWatchlistForExportParams{
+			ListUuid:   e.watchlistListUuid,
+			StatusUuid: e.watchlistStatusUuid,
+			Offset:     offset,
+			PageSize:   exportBatchSize,
+		})
+	}, func(row queries.GetWatchlistForExportRow) error {
+		return cw.Write([]string{
+			formatExportTimestamp(row.AddedDate),
+			row.Title,
+			formatReleaseYear(row.ReleaseDate),
+			"",
+		})
+	})
+}
+
+func (e *LetterboxdExport) writeDiary(ctx context.Context, cw *csv.Writer) error {
+	return exportPages(func(offset int32) ([]queries.GetWatchesForExportRow, error) {
+		return e.q.GetWatchesForExport(ctx, queries.GetWatchesForExportParams{
+			UserUuid: e.userUuid,
+			Offset:   offset,
+			PageSize: exportBatchSize,
+		})
+	}, func(row queries.GetWatchesForExportRow) error {
+		return cw.Write([]string{
+			formatExportTimestamp(row.CreatedDate),
+			row.Title,
+			formatReleaseYear(row.ReleaseDate),
+			"",
+			formatExportRating(row.Rating),
+			formatExportRewatch(row.Rewatch),
+			"",
+			formatPgDateOrEmpty(row.WatchedDate),
+		})
+	})
+}
+
+func (e *LetterboxdExport) writeRatings(ctx context.Context, cw *csv.Writer) error {
+	return exportPages(func(offset int32) ([]queries.GetRatingsForExportRow, error) {
+		return e.q.GetRatingsForExport(ctx, queries.GetRatingsForExportParams{
+			UserUuid: e.userUuid,
+			Offset:   offset,
+			PageSize: exportBatchSize,
+		})
+	}, func(row queries.GetRatingsForExportRow) error {
+		return cw.Write([]string{
+			formatExportTimestamp(row.RatedDate),
+			row.Title,
+			formatReleaseYear(row.ReleaseDate),
+			"",
+			formatExportRating(row.Rating),
+		})
+	})
+}
+
+func (e *LetterboxdExport) writeReviews(ctx context.Context, cw *csv.Writer) error {
+	return exportPages(func(offset int32) ([]queries.GetReviewsForExportRow, error) {
+		return e.q.GetReviewsForExport(ctx, queries.GetReviewsForExportParams{
+			UserUuid: e.userUuid,
+			Offset:   offset,
+			PageSize: exportBatchSize,
+		})
+	}, func(row queries.GetReviewsForExportRow) error {
+		return cw.Write([]string{
+			formatExportTimestamp(row.CreatedDate),
+			row.Title,
+			formatReleaseYear(row.ReleaseDate),
+			"",
+			formatExportRating(row.Rating),
+			formatExportRewatch(row.Rewatch),
+			row.Content,
+			"",
+			formatPgDateOrEmpty(row.WatchedDate),
+		})
+	})
+}
+
+// exportPages fetches rows in batches of exportBatchSize and writes each row until a short batch is returned
+func exportPages[T any](fetch func(offset int32) ([]T, error), write func(T) error) error {
+	for offset := int32(0); ; offset += exportBatchSize {
+		rows, err := fetch(offset)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// formatExportTimestamp formats a timestamp as the date Letterboxd uses
+func formatExportTimestamp(t pgtype.Timestamptz) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.DateOnly)
+}
+
+// formatPgDateOrEmpty formats a postgres date as YYYY-MM-DD, treating NULL as an empty string
+func formatPgDateOrEmpty(d pgtype.Date) string {
+	if date := helpers.PgDatePointer(d); date != nil {
+		return *date
+	}
+	return ""
+}
+
+// formatReleaseYear returns the year of a release date, which Letterboxd uses to tell films apart
+func formatReleaseYear(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return strconv.Itoa(d.Time.Year())
+}
+
+// formatExportRating formats a rating without trailing zeros, such as 4 or 3.5
+func formatExportRating(n pgtype.Numeric) string {
+	rating := helpers.PgNumericPointer(n)
+	if rating == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*rating, 'f', -1, 64)
+}
+
+// formatExportRewatch formats a rewatch flag the way Letterboxd does, as Yes or an empty string
+func formatExportRewatch(rewatch bool) string {
+	if rewatch {
+		return "Yes"
+	}
+	return ""
+}
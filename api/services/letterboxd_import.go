@@ -0,0 +1,551 @@
+package services
+
+import (
+	"archive/zip"
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Files read from a Letterboxd export. Other files in the archive, such as likes and lists, are ignored
+const (
+	letterboxdWatched   = "watched.csv"
+	letterboxdDiary     = "diary.csv"
+	letterboxdRatings   = "ratings.csv"
+	letterboxdReviews   = "reviews.csv"
+	letterboxdWatchlist = "watchlist.csv"
+)
+
+// letterboxdSkippedDirs hold copies of rows that are no longer part of the user's history
+var letterboxdSkippedDirs = []string{"deleted", "orphaned", "likes", "lists"}
+
+type LetterboxdImportService struct {
+	q             *queries.Queries
+	db            *pgxpool.Pool
+	searchService *SearchService
+	itemsService  *ItemsService
+	rebalancer    *RankRebalancer
+}
+
+func NewLetterboxdImportService(q *queries.Queries, db *pgxpool.Pool, searchService *SearchService, itemsService *ItemsService, rebalancer *RankRebalancer) *LetterboxdImportService {
+	return &LetterboxdImportService{
+		q:             q,
+		db:            db,
+		searchService: searchService,
+		itemsService:  itemsService,
+		rebalancer:    rebalancer,
+	}
+}
+
+// Import reads a Letterboxd export archive and imports it for a user. Films are matched to TMDB by
+// title and year and imported as items. Reviews and ratings become reviews, diary entries and watched
+// films without a diary entry become watches, and the watchlist is added to the first column of the
+// user's default list. Rows that were already imported are skipped, so an import can be run again.
+// In a dry run films are matched but nothing is written
+func (s *LetterboxdImportService) Import(ctx context.Context, userUuid string, archive io.ReaderAt, size int64, dryRun bool) (*types.ImportReport, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := readLetterboxdArchive(archive, size)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &letterboxdImport{
+		s:           s,
+		userUuid:    *pgUserUuid,
+		dryRun:      dryRun,
+		report:      &types.ImportReport{DryRun: dryRun, Unmatched: []types.UnmatchedRow{}},
+		matches:     make(map[string]int64),
+		items:       make(map[int64]pgtype.UUID),
+		reviewDates: make(map[pgtype.UUID]string),
+		diaryFilms:  make(map[string]bool),
+	}
+
+	// Reviews come first so that diary entries can be linked to them, and diary entries come
+	// before watched films so that films with a diary entry aren't logged twice
+	steps := []struct {
+		file string
+		run  func(context.Context, csvRow) error
+	}{
+		{letterboxdReviews, imp.importReview},
+		{letterboxdRatings, imp.importRating},
+		{letterboxdDiary, imp.importDiaryEntry},
+		{letterboxdWatched, imp.importWatched},
+		{letterboxdWatchlist, imp.importWatchlistEntry},
+	}
+
+	for _, step := range steps {
+		for _, row := range files[step.file] {
+			imp.report.Rows++
+			if err := step.run(ctx, row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return imp.report, nil
+}
+
+// filmKey identifies the film of a row independently of whether it has been matched
+func (r csvRow) filmKey() string {
+	return strings.ToLower(r.get("Name")) + "|" + r.get("Year")
+}
+
+// letterboxdImport holds the state of a single import
+type letterboxdImport struct {
+	s        *LetterboxdImportService
+	userUuid pgtype.UUID
+	dryRun   bool
+	report   *types.ImportReport
+
+	// matches caches search results by film key, with 0 for films that couldn't be matched
+	matches map[string]int64
+	// items caches imported items by TMDB ID
+	items map[int64]pgtype.UUID
+	// reviewDates records the watched date of each review created by this import
+	reviewDates map[pgtype.UUID]string
+	// diaryFilms records the films that have a diary entry
+	diaryFilms map[string]bool
+
+	defaultList   *pgtype.UUID
+	defaultStatus *pgtype.UUID
+}
+
+// importReview creates a review from reviews.csv. Letterboxd allows several reviews of a film,
+// but only one review per item is kept
+func (imp *letterboxdImport) importReview(ctx context.Context, row csvRow) error {
+	rating, err := parseLetterboxdRating(row.get("Rating"))
+	if err != nil {
+		imp.unmatched(row, "invalid rating")
+		return nil
+	}
+
+	content := row.get("Review")
+	if content == "" && rating == nil {
+		imp.unmatched(row, "review has no content or rating")
+		return nil
+	}
+
+	itemUuid, ok, err := imp.resolve(ctx, row)
+	if err != nil || !ok {
+		return err
+	}
+
+	if imp.dryRun {
+		imp.report.ReviewsCreated++
+		return nil
+	}
+
+	_, err = imp.s.q.GetReviewForUserItem(ctx, queries.GetReviewForUserItemParams{
+		ItemUuid: itemUuid,
+		UserUuid: imp.userUuid,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error checking reviews")
+	}
+
+	if err == nil {
+		imp.report.Skipped++
+		return nil
+	}
+
+	_, err = imp.s.q.AddReview(ctx, queries.AddReviewParams{
+		ItemUuid: itemUuid,
+		UserUuid: imp.userUuid,
+		Content:  content,
+		Rating:   helpers.MakePgNumeric(rating),
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error adding review")
+	}
+
+	imp.reviewDates[itemUuid] = row.get("Watched Date")
+	imp.report.ReviewsCreated++
+
+	return nil
+}
+
+// importRating rates an item from ratings.csv, adding the rating to the user's review of the
+// item if they have one
+func (imp *letterboxdImport) importRating(ctx context.Context, row csvRow) error {
+	rating, err := parseLetterboxdRating(row.get("Rating"))
+	if err != nil || rating == nil {
+		imp.unmatched(row, "invalid rating")
+		return nil
+	}
+
+	itemUuid, ok, err := imp.resolve(ctx, row)
+	if err != nil || !ok {
+		return err
+	}
+
+	if imp.dryRun {
+		imp.report.RatingsCreated++
+		return nil
+	}
+
+	created, err := rateItem(ctx, imp.s.q, itemUuid, imp.userUuid, rating)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		imp.report.Skipped++
+		return nil
+	}
+
+	imp.report.RatingsCreated++
+
+	return nil
+}
+
+// importDiaryEntry logs a watch from diary.csv
+func (imp *letterboxdImport) importDiaryEntry(ctx context.Context, row csvRow) error {
+	watchedDate := row.get("Watched Date")
+	if watchedDate == "" {
+		watchedDate = row.get("Date")
+	}
+
+	rating, err := parseLetterboxdRating(row.get("Rating"))
+	if err != nil {
+		imp.unmatched(row, "invalid rating")
+		return nil
+	}
+
+	imp.diaryFilms[row.filmKey()] = true
+
+	return imp.logWatch(ctx, row, watchedDate, strings.EqualFold(row.get("Rewatch"), "yes"), rating)
+}
+
+// importWatched logs a watch from watched.csv for films without a diary entry. Letterboxd doesn't
+// record when these films were watched, so the date they were marked as watched is used
+func (imp *letterboxdImport) importWatched(ctx context.Context, row csvRow) error {
+	if imp.diaryFilms[row.filmKey()] {
+		imp.report.Skipped++
+		return nil
+	}
+
+	return imp.logWatch(ctx, row, row.get("Date"), false, nil)
+}
+
+// logWatch adds a watch for a row unless the user already logged the item on that date
+func (imp *letterboxdImport) logWatch(ctx context.Context, row csvRow, watchedDate string, rewatch bool, rating *float64) error {
+	pgWatchedDate := helpers.MakePgDate(watchedDate)
+	if !pgWatchedDate.Valid {
+		imp.unmatched(row, "invalid watched date")
+		return nil
+	}
+
+	itemUuid, ok, err := imp.resolve(ctx, row)
+	if err != nil || !ok {
+		return err
+	}
+
+	if imp.dryRun {
+		imp.report.WatchesCreated++
+		return nil
+	}
+
+	exists, err := imp.s.q.CheckWatchExists(ctx, queries.CheckWatchExistsParams{
+		UserUuid:    imp.userUuid,
+		ItemUuid:    itemUuid,
+		WatchedDate: pgWatchedDate,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error checking watches")
+	}
+
+	if exists != 0 {
+		imp.report.Skipped++
+		return nil
+	}
+
+	var reviewUuid pgtype.UUID
+	if date, ok := imp.reviewDates[itemUuid]; ok && date == watchedDate {
+		review, err := imp.s.q.GetReviewForUserItem(ctx, queries.GetReviewForUserItemParams{
+			ItemUuid: itemUuid,
+			UserUuid: imp.userUuid,
+		})
+		if err != nil {
+			return types.NewAPIError(http.StatusInternalServerError, "error getting review")
+		}
+		reviewUuid = review.Uuid
+	}
+
+	_, err = imp.s.q.AddWatch(ctx, queries.AddWatchParams{
+		UserUuid:    imp.userUuid,
+		ItemUuid:    itemUuid,
+		ReviewUuid:  reviewUuid,
+		WatchedDate: pgWatchedDate,
+		Rewatch:     rewatch,
+		Rating:      helpers.MakePgNumeric(rating),
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error logging watch")
+	}
+
+	imp.report.WatchesCreated++
+
+	return nil
+}
+
+// importWatchlistEntry adds a film from watchlist.csv to the first column of the user's default list.
+// Imports ignore WIP limits so that a full column doesn't stop the rest of the watchlist
+func (imp *letterboxdImport) importWatchlistEntry(ctx context.Context, row csvRow) error {
+	itemUuid, ok, err := imp.resolve(ctx, row)
+	if err != nil || !ok {
+		return err
+	}
+
+	if imp.dryRun {
+		imp.report.WatchlistAdded++
+		return nil
+	}
+
+	listUuid, statusUuid, err := imp.watchlistColumn(ctx)
+	if err != nil {
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			imp.unmatched(row, apiErr.Message)
+			return nil
+		}
+		return err
+	}
+
+	added, err := addToListColumn(ctx, imp.s.q, imp.s.db, imp.s.rebalancer, listUuid, statusUuid, itemUuid, imp.userUuid)
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		imp.report.Skipped++
+		return nil
+	}
+
+	imp.report.WatchlistAdded++
+
+	return nil
+}
+
+// watchlistColumn returns the user's default list and its first status column
+func (imp *letterboxdImport) watchlistColumn(ctx context.Context) (pgtype.UUID, pgtype.UUID, error) {
+	if imp.defaultList != nil {
+		return *imp.defaultList, *imp.defaultStatus, nil
+	}
+
+	listUuid, statusUuid, err := defaultListColumn(ctx, imp.s.q, imp.userUuid)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	imp.defaultList = &listUuid
+	imp.defaultStatus = &statusUuid
+
+	return *imp.defaultList, *imp.defaultStatus, nil
+}
+
+// resolve matches the film of a row and imports it as an item, returning false if the row was
+// reported as unmatched. In a dry run the film is matched but not imported
+func (imp *letterboxdImport) resolve(ctx context.Context, row csvRow) (pgtype.UUID, bool, error) {
+	tmdbId, err := imp.match(ctx, row)
+	if err != nil {
+		return pgtype.UUID{}, false, err
+	}
+
+	if tmdbId == 0 {
+		imp.unmatched(row, "no match found")
+		return pgtype.UUID{}, false, nil
+	}
+
+	imp.report.Matched++
+
+	if imp.dryRun {
+		return pgtype.UUID{}, true, nil
+	}
+
+	if itemUuid, ok := imp.items[tmdbId]; ok {
+		return itemUuid, true, nil
+	}
+
+	item, _, err := imp.s.itemsService.ImportTmdbMovie(ctx, tmdbId)
+	if err != nil {
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			imp.report.Matched--
+			imp.unmatched(row, "movie not found")
+			return pgtype.UUID{}, false, nil
+		}
+		return pgtype.UUID{}, false, err
+	}
+
+	itemUuid, err := helpers.ValidateAndConvertUUID(item.UUID)
+	if err != nil {
+		return pgtype.UUID{}, false, err
+	}
+
+	imp.items[tmdbId] = *itemUuid
+
+	return *itemUuid, true, nil
+}
+
+// match searches for the film of a row, returning 0 if there is no good match
+func (imp *letterboxdImport) match(ctx context.Context, row csvRow) (int64, error) {
+	key := row.filmKey()
+	if tmdbId, ok := imp.matches[key]; ok {
+		return tmdbId, nil
+	}
+
+	name := row.get("Name")
+	if name == "" {
+		return 0, nil
+	}
+
+	results, _, err := imp.s.searchService.SearchMovie(ctx, &types.Pagination{}, name, "")
+	if err != nil {
+		return 0, err
+	}
+
+	tmdbId := bestMovieMatch(results, name, row.get("Year"))
+	imp.matches[key] = tmdbId
+
+	return tmdbId, nil
+}
+
+func (imp *letterboxdImport) unmatched(row csvRow, reason string) {
+	imp.report.Unmatched = append(imp.report.Unmatched, types.UnmatchedRow{
+		File:   row.file,
+		Line:   row.line,
+		Name:   row.get("Name"),
+		Year:   row.get("Year"),
+		Reason: reason,
+	})
+}
+
+// bestMovieMatch picks a search result for a title and release year. A result from the same year
+// is preferred, with an exact title match first. Release years often differ by one between
+// festival and theatrical releases, so an exact title from an adjacent year is accepted as well
+func bestMovieMatch(results *tmdb.SearchMovies, name, year string) int64 {
+	if results == nil || results.SearchMoviesResults == nil {
+		return 0
+	}
+
+	wantYear, yearErr := strconv.Atoi(year)
+
+	var sameYear, adjacentYear int64
+	for _, result := range results.Results {
+		exactTitle := strings.EqualFold(result.Title, name) || strings.EqualFold(result.OriginalTitle, name)
+
+		if yearErr != nil {
+			if exactTitle {
+				return result.ID
+			}
+			continue
+		}
+
+		resultYear, err := strconv.Atoi(strings.SplitN(result.ReleaseDate, "-", 2)[0])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case resultYear == wantYear && exactTitle:
+			return result.ID
+		case resultYear == wantYear && sameYear == 0:
+			sameYear = result.ID
+		case exactTitle && (resultYear == wantYear-1 || resultYear == wantYear+1) && adjacentYear == 0:
+			adjacentYear = result.ID
+		}
+	}
+
+	if sameYear != 0 {
+		return sameYear
+	}
+
+	return adjacentYear
+}
+
+// parseLetterboxdRating parses a rating out of five stars, treating an empty rating as nil
+func parseLetterboxdRating(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	rating, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRating(&rating); err != nil {
+		return nil, err
+	}
+
+	return &rating, nil
+}
+
+// readLetterboxdArchive reads the CSV files of a Letterboxd export. The files may be at the root of
+// the archive or inside a single top-level folder
+func readLetterboxdArchive(archive io.ReaderAt, size int64) (map[string][]csvRow, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusBadRequest, "file is not a zip archive")
+	}
+
+	files := make(map[string][]csvRow)
+	remaining := int64(maxImportSize)
+
+	for _, f := range reader.File {
+		dir, name := path.Split(f.Name)
+		dir = strings.Trim(dir, "/")
+
+		if strings.Contains(dir, "/") || slices.Contains(letterboxdSkippedDirs, dir) {
+			continue
+		}
+
+		switch name {
+		case letterboxdWatched, letterboxdDiary, letterboxdRatings, letterboxdReviews, letterboxdWatchlist:
+		default:
+			continue
+		}
+
+		rows, err := readLetterboxdCsv(f, name, &remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		files[name] = rows
+	}
+
+	if len(files) == 0 {
+		return nil, types.NewAPIError(http.StatusBadRequest, "archive does not contain a letterboxd export")
+	}
+
+	return files, nil
+}
+
+// readLetterboxdCsv reads the rows of a CSV file in the archive, counting its unzipped size against the
+// bytes the import has left to read
+func readLetterboxdCsv(f *zip.File, name string, remaining *int64) ([]csvRow, error) {
+	file, err := f.Open()
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusBadRequest, fmt.Sprintf("couldn't read %s", name))
+	}
+	defer file.Close()
+
+	return readLimitedCsvRows(file, name, remaining)
+}
@@ -5,15 +5,22 @@ import (
 	"codeberg.org/sporiff/eigakanban/helpers"
 	"codeberg.org/sporiff/eigakanban/types"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"net/http"
 )
 
 type ListItemsService struct {
-	q *queries.Queries
+	q          *queries.Queries
+	db         *pgxpool.Pool
+	rebalancer *RankRebalancer
 }
 
-func NewListItemsService(q *queries.Queries) *ListItemsService {
-	return &ListItemsService{q: q}
+func NewListItemsService(q *queries.Queries, db *pgxpool.Pool, rebalancer *RankRebalancer) *ListItemsService {
+	return &ListItemsService{q: q, db: db, rebalancer: rebalancer}
 }
 
 // GetAllListItems fetches all list items from the database and returns them as a paginated list
@@ -49,6 +56,7 @@ func (s *ListItemsService) GetAllListItems(ctx context.Context, pagination *type
 			ListUUID: item.ListUuid.String(),
 			ItemUUID: item.ItemUuid.String(),
 			Status:   item.Label.String,
+			Rank:     item.Rank,
 			Position: item.Position,
 		}
 	}
@@ -68,6 +76,10 @@ func (s *ListItemsService) GetListItemsForList(ctx context.Context, pagination *
 		return nil, err
 	}
 
+	if err := authorizeResource(ctx, s.q, anonymousActor, publicRead, resourceList, *pgUuid); err != nil {
+		return nil, err
+	}
+
 	total, err := s.q.GetListItemsCountForList(ctx, *pgUuid)
 	if err != nil {
 		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list items count")
@@ -100,6 +112,7 @@ func (s *ListItemsService) GetListItemsForList(ctx context.Context, pagination *
 			ListUUID: item.ListUuid.String(),
 			ItemUUID: item.ItemUuid.String(),
 			Status:   item.Label.String,
+			Rank:     item.Rank,
 			Position: item.Position,
 		}
 	}
@@ -111,3 +124,431 @@ func (s *ListItemsService) GetListItemsForList(ctx context.Context, pagination *
 
 	return &response, nil
 }
+
+// AddItemToList adds an item to a status column of a list owned by the authenticated user
+func (s *ListItemsService) AddItemToList(ctx context.Context, listUuid, userUuid string, request types.AddListItemRequest) (*types.ListItemPlacementResponse, error) {
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgItemUuid, err := helpers.ValidateAndConvertUUID(request.ItemUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgStatusUuid, err := helpers.ValidateAndConvertUUID(request.StatusUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	err = lockOwnedList(ctx, qtx, *pgListUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStatusOnList(ctx, qtx, *pgListUuid, *pgStatusUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !request.IgnoreWipLimit {
+		err = checkWipLimit(ctx, qtx, *pgListUuid, *pgStatusUuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = qtx.GetItemByUuid(ctx, *pgItemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	}
+
+	existingCount, err := qtx.CheckItemInList(ctx, queries.CheckItemInListParams{
+		ListUuid: *pgListUuid,
+		ItemUuid: *pgItemUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking list items")
+	}
+
+	if existingCount != 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "item already in list")
+	}
+
+	rank, err := s.rankForPosition(ctx, qtx, *pgListUuid, *pgStatusUuid, pgtype.UUID{}, request.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := qtx.AddItemToList(ctx, queries.AddItemToListParams{
+		ListUuid:   *pgListUuid,
+		ItemUuid:   *pgItemUuid,
+		StatusUuid: *pgStatusUuid,
+		Rank:       rank,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error adding item to list")
+	}
+
+	response, err := s.getPlacement(ctx, qtx, added.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error adding item to list")
+	}
+
+	s.scheduleRebalance(*pgListUuid, *pgStatusUuid, rank)
+
+	return response, nil
+}
+
+// MoveListItem moves a list item to a new status and position. Only the moved item is updated
+func (s *ListItemsService) MoveListItem(ctx context.Context, listItemUuid, userUuid string, request types.MoveListItemRequest) (*types.ListItemPlacementResponse, error) {
+	pgListItemUuid, err := helpers.ValidateAndConvertUUID(listItemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgStatusUuid, err := helpers.ValidateAndConvertUUID(request.StatusUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	current, err := s.lockListItem(ctx, qtx, *pgListItemUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStatusOnList(ctx, qtx, current.ListUuid, *pgStatusUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reordering within a column never changes how full it is
+	if current.StatusUuid != *pgStatusUuid && !request.IgnoreWipLimit {
+		err = checkWipLimit(ctx, qtx, current.ListUuid, *pgStatusUuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rank, err := s.rankForPosition(ctx, qtx, current.ListUuid, *pgStatusUuid, *pgListItemUuid, request.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.MoveItemInList(ctx, queries.MoveItemInListParams{
+		StatusUuid:   *pgStatusUuid,
+		Rank:         rank,
+		ListItemUuid: *pgListItemUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error moving list item")
+	}
+
+	response, err := s.getPlacement(ctx, qtx, *pgListItemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error moving list item")
+	}
+
+	s.scheduleRebalance(current.ListUuid, *pgStatusUuid, rank)
+
+	return response, nil
+}
+
+// DeleteListItem removes an item from a list and returns the items that were either side of it
+func (s *ListItemsService) DeleteListItem(ctx context.Context, listItemUuid, userUuid string) (*types.ListItemPlacementResponse, error) {
+	pgListItemUuid, err := helpers.ValidateAndConvertUUID(listItemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	current, err := s.lockListItem(ctx, qtx, *pgListItemUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = qtx.DeleteItemFromList(ctx, *pgListItemUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting list item")
+	}
+
+	response := &types.ListItemPlacementResponse{}
+
+	if current.StatusUuid.Valid {
+		response.Previous, response.Next, err = s.getNeighbours(ctx, qtx, current.ListUuid, current.StatusUuid, current.Rank)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting list item")
+	}
+
+	return response, nil
+}
+
+// lockOwnedList locks a list for the rest of the transaction and checks that the given user owns it
+func lockOwnedList(ctx context.Context, qtx *queries.Queries, listUuid pgtype.UUID, userUuid string) error {
+	list, err := qtx.LockListByUuid(ctx, listUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting list by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusNotFound, "list not found")
+	}
+
+	return authorize(userActor(userUuid), ownerOnly, resourceList, ownership{owner: list.UserUuid})
+}
+
+// checkStatusOnList checks that a status is attached to a list through list_statuses
+func checkStatusOnList(ctx context.Context, qtx *queries.Queries, listUuid, statusUuid pgtype.UUID) error {
+	count, err := qtx.CheckStatusOnList(ctx, queries.CheckStatusOnListParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error checking list statuses")
+	}
+
+	if count == 0 {
+		return types.NewAPIError(http.StatusBadRequest, "status is not attached to this list")
+	}
+
+	return nil
+}
+
+// checkWipLimit checks that a status column on a list has room for another list item
+func checkWipLimit(ctx context.Context, qtx *queries.Queries, listUuid, statusUuid pgtype.UUID) error {
+	limit, err := qtx.GetListStatusWipLimit(ctx, queries.GetListStatusWipLimitParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error fetching wip limit")
+	}
+
+	if !limit.Valid {
+		return nil
+	}
+
+	count, err := qtx.GetListItemsCountForStatus(ctx, queries.GetListItemsCountForStatusParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error fetching list item count")
+	}
+
+	if count >= int64(limit.Int32) {
+		return types.NewAPIError(http.StatusConflict, fmt.Sprintf("status column has reached its wip limit of %d", limit.Int32))
+	}
+
+	return nil
+}
+
+// rankForPosition returns a rank that places an item at a zero-based position in a column.
+// The item being placed is passed as excludeUuid so that it doesn't count towards the position
+func (s *ListItemsService) rankForPosition(ctx context.Context, qtx *queries.Queries, listUuid, statusUuid, excludeUuid pgtype.UUID, requested *int32) (string, error) {
+	columnCount, err := qtx.GetListItemsCountForStatus(ctx, queries.GetListItemsCountForStatusParams{
+		ListUuid:    listUuid,
+		StatusUuid:  statusUuid,
+		ExcludeUuid: excludeUuid,
+	})
+	if err != nil {
+		return "", types.NewAPIError(http.StatusInternalServerError, "error fetching list items count")
+	}
+
+	position := clampPosition(requested, columnCount)
+
+	ranks, err := qtx.GetRanksAroundPosition(ctx, queries.GetRanksAroundPositionParams{
+		ListUuid:    listUuid,
+		StatusUuid:  statusUuid,
+		ExcludeUuid: excludeUuid,
+		Position:    position,
+	})
+	if err != nil {
+		return "", types.NewAPIError(http.StatusInternalServerError, "error fetching list item ranks")
+	}
+
+	var prev, next string
+	switch {
+	case position == 0 && len(ranks) > 0:
+		next = ranks[0]
+	case position > 0 && len(ranks) > 0:
+		prev = ranks[0]
+		if len(ranks) > 1 {
+			next = ranks[1]
+		}
+	}
+
+	rank, err := helpers.RankBetween(prev, next)
+	if err != nil {
+		return "", types.NewAPIError(http.StatusInternalServerError, "error calculating list item rank")
+	}
+
+	return rank, nil
+}
+
+// scheduleRebalance queues a column for rebalancing once its keys have grown too long
+func (s *ListItemsService) scheduleRebalance(listUuid, statusUuid pgtype.UUID, rank string) {
+	if len(rank) > helpers.MaxRankLength {
+		s.rebalancer.Schedule(listUuid, statusUuid)
+	}
+}
+
+// getListItem fetches a single list item by UUID
+func (s *ListItemsService) getListItem(ctx context.Context, qtx *queries.Queries, listItemUuid pgtype.UUID) (*queries.GetListItemByUuidRow, error) {
+	item, err := qtx.GetListItemByUuid(ctx, listItemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting list item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "list item not found")
+	}
+
+	return &item, nil
+}
+
+// lockListItem locks the list a list item is on and then reads the list item, so that its status and rank
+// can't change before the caller is done with them
+func (s *ListItemsService) lockListItem(ctx context.Context, qtx *queries.Queries, listItemUuid pgtype.UUID, userUuid string) (*queries.GetListItemByUuidRow, error) {
+	listUuid, err := qtx.GetListUuidForListItem(ctx, listItemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting list item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "list item not found")
+	}
+
+	err = lockOwnedList(ctx, qtx, listUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	// The list item may have been deleted while waiting for the lock
+	return s.getListItem(ctx, qtx, listItemUuid)
+}
+
+// getPlacement returns a list item together with its neighbours in its status column
+func (s *ListItemsService) getPlacement(ctx context.Context, qtx *queries.Queries, listItemUuid pgtype.UUID) (*types.ListItemPlacementResponse, error) {
+	item, err := s.getListItem(ctx, qtx, listItemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, next, err := s.getNeighbours(ctx, qtx, item.ListUuid, item.StatusUuid, item.Rank)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ListItemPlacementResponse{
+		ListItem: &types.ListItemsResponse{
+			UUID:     item.ListItemUuid.String(),
+			ListUUID: item.ListUuid.String(),
+			ItemUUID: item.ItemUuid.String(),
+			Status:   item.Label.String,
+			Rank:     item.Rank,
+			Position: item.Position,
+		},
+		Previous: previous,
+		Next:     next,
+	}
+
+	return &response, nil
+}
+
+// getNeighbours fetches the list items directly before and after a rank in a status column
+func (s *ListItemsService) getNeighbours(ctx context.Context, qtx *queries.Queries, listUuid, statusUuid pgtype.UUID, rank string) (*types.ListItemsResponse, *types.ListItemsResponse, error) {
+	var previous, next *types.ListItemsResponse
+
+	prevItem, err := qtx.GetPreviousListItem(ctx, queries.GetPreviousListItemParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+		Rank:       rank,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list items")
+	}
+
+	if err == nil {
+		previous = &types.ListItemsResponse{
+			UUID:     prevItem.ListItemUuid.String(),
+			ListUUID: prevItem.ListUuid.String(),
+			ItemUUID: prevItem.ItemUuid.String(),
+			Status:   prevItem.Label.String,
+			Rank:     prevItem.Rank,
+			Position: prevItem.Position,
+		}
+	}
+
+	nextItem, err := qtx.GetNextListItem(ctx, queries.GetNextListItemParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+		Rank:       rank,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list items")
+	}
+
+	if err == nil {
+		next = &types.ListItemsResponse{
+			UUID:     nextItem.ListItemUuid.String(),
+			ListUUID: nextItem.ListUuid.String(),
+			ItemUUID: nextItem.ItemUuid.String(),
+			Status:   nextItem.Label.String,
+			Rank:     nextItem.Rank,
+			Position: nextItem.Position,
+		}
+	}
+
+	return previous, next, nil
+}
+
+// clampPosition keeps a requested position inside a column, defaulting to the end of the column
+func clampPosition(position *int32, columnCount int64) int32 {
+	if position == nil || int64(*position) > columnCount {
+		return int32(columnCount)
+	}
+
+	return *position
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"net/http"
+)
+
+type ListStatusesService struct {
+	q  *queries.Queries
+	db *pgxpool.Pool
+}
+
+func NewListStatusesService(q *queries.Queries, db *pgxpool.Pool) *ListStatusesService {
+	return &ListStatusesService{q: q, db: db}
+}
+
+// GetStatusesForList returns the statuses attached to a list in column order
+func (s *ListStatusesService) GetStatusesForList(ctx context.Context, listUuid string) (*types.ListStatusesResponse, error) {
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeResource(ctx, s.q, anonymousActor, publicRead, resourceList, *pgListUuid); err != nil {
+		return nil, err
+	}
+
+	return getListStatuses(ctx, s.q, *pgListUuid)
+}
+
+// AddStatusToList attaches a status owned by the authenticated user to a list as its last column
+func (s *ListStatusesService) AddStatusToList(ctx context.Context, listUuid, userUuid string, request types.AddListStatusRequest) (*types.ListStatusesResponse, error) {
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgStatusUuid, err := helpers.ValidateAndConvertUUID(request.StatusUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	err = lockOwnedList(ctx, qtx, *pgListUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = getOwnedStatus(ctx, qtx, *pgStatusUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	attached, err := qtx.CheckStatusOnList(ctx, queries.CheckStatusOnListParams{
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking list statuses")
+	}
+
+	if attached != 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "status already attached to this list")
+	}
+
+	rank, err := nextListStatusRank(ctx, qtx, *pgListUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.AddListStatus(ctx, queries.AddListStatusParams{
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+		Rank:       rank,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error adding status to list")
+	}
+
+	response, err := getListStatuses(ctx, qtx, *pgListUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error adding status to list")
+	}
+
+	return response, nil
+}
+
+// ReorderStatusesForList sets the column order of a list. The request must name every
+// status attached to the list exactly once
+func (s *ListStatusesService) ReorderStatusesForList(ctx context.Context, listUuid, userUuid string, request types.ReorderListStatusesRequest) (*types.ListStatusesResponse, error) {
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	statusUuids := make([]pgtype.UUID, len(request.StatusUUIDs))
+	for i, statusUuid := range request.StatusUUIDs {
+		pgStatusUuid, err := helpers.ValidateAndConvertUUID(statusUuid)
+		if err != nil {
+			return nil, err
+		}
+		statusUuids[i] = *pgStatusUuid
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	err = lockOwnedList(ctx, qtx, *pgListUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := qtx.GetStatusesForList(ctx, *pgListUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list statuses")
+	}
+
+	remaining := make(map[pgtype.UUID]bool, len(current))
+	for _, status := range current {
+		remaining[status.StatusUuid] = true
+	}
+
+	for _, statusUuid := range statusUuids {
+		if !remaining[statusUuid] {
+			return nil, types.NewAPIError(http.StatusBadRequest, "status_uuids must contain every status on the list exactly once")
+		}
+		delete(remaining, statusUuid)
+	}
+
+	if len(remaining) != 0 {
+		return nil, types.NewAPIError(http.StatusBadRequest, "status_uuids must contain every status on the list exactly once")
+	}
+
+	err = qtx.UpdateListStatusRanks(ctx, queries.UpdateListStatusRanksParams{
+		ListUuid:    *pgListUuid,
+		StatusUuids: statusUuids,
+		Ranks:       helpers.SpreadRanks(len(statusUuids)),
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error reordering list statuses")
+	}
+
+	response, err := getListStatuses(ctx, qtx, *pgListUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error reordering list statuses")
+	}
+
+	return response, nil
+}
+
+// UpdateStatusOnList updates the settings of a status column on a list. Lowering the WIP limit
+// below the number of items already in the column is allowed, but blocks new items until it drains
+func (s *ListStatusesService) UpdateStatusOnList(ctx context.Context, listUuid, statusUuid, userUuid string, request types.UpdateListStatusRequest) (*types.ListStatusesResponse, error) {
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgStatusUuid, err := helpers.ValidateAndConvertUUID(statusUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	err = lockOwnedList(ctx, qtx, *pgListUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	attached, err := qtx.CheckStatusOnList(ctx, queries.CheckStatusOnListParams{
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking list statuses")
+	}
+
+	if attached == 0 {
+		return nil, types.NewAPIError(http.StatusNotFound, "status is not attached to this list")
+	}
+
+	err = qtx.UpdateListStatusWipLimit(ctx, queries.UpdateListStatusWipLimitParams{
+		WipLimit:   helpers.MakePgInt4(request.WipLimit),
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating list status")
+	}
+
+	response, err := getListStatuses(ctx, qtx, *pgListUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating list status")
+	}
+
+	return response, nil
+}
+
+// RemoveStatusFromList detaches a status from a list. The status column must be empty
+func (s *ListStatusesService) RemoveStatusFromList(ctx context.Context, listUuid, statusUuid, userUuid string) (*types.ListStatusesResponse, error) {
+	pgListUuid, err := helpers.ValidateAndConvertUUID(listUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgStatusUuid, err := helpers.ValidateAndConvertUUID(statusUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	err = lockOwnedList(ctx, qtx, *pgListUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	attached, err := qtx.CheckStatusOnList(ctx, queries.CheckStatusOnListParams{
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking list statuses")
+	}
+
+	if attached == 0 {
+		return nil, types.NewAPIError(http.StatusNotFound, "status is not attached to this list")
+	}
+
+	count, err := qtx.GetListItemsCountForStatus(ctx, queries.GetListItemsCountForStatusParams{
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list item count")
+	}
+
+	if count != 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "status still has items on this list")
+	}
+
+	err = qtx.DeleteListStatusForList(ctx, queries.DeleteListStatusForListParams{
+		ListUuid:   *pgListUuid,
+		StatusUuid: *pgStatusUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error removing status from list")
+	}
+
+	response, err := getListStatuses(ctx, qtx, *pgListUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error removing status from list")
+	}
+
+	return response, nil
+}
+
+// getListStatuses fetches the status columns of a list in order
+func getListStatuses(ctx context.Context, q *queries.Queries, listUuid pgtype.UUID) (*types.ListStatusesResponse, error) {
+	rows, err := q.GetStatusesForList(ctx, listUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list statuses")
+	}
+
+	statuses := make([]types.ListStatusResponse, len(rows))
+	for i, row := range rows {
+		statuses[i] = types.ListStatusResponse{
+			UUID:       row.ListStatusUuid.String(),
+			StatusUUID: row.StatusUuid.String(),
+			Label:      row.Label.String,
+			Position:   int32(i),
+			WipLimit:   helpers.PgInt4Pointer(row.WipLimit),
+		}
+	}
+
+	response := types.ListStatusesResponse{
+		ListUUID: listUuid.String(),
+		Statuses: statuses,
+	}
+
+	return &response, nil
+}
+
+// nextListStatusRank returns a rank that places a status after the last column of a list
+func nextListStatusRank(ctx context.Context, q *queries.Queries, listUuid pgtype.UUID) (string, error) {
+	last, err := q.GetLastListStatusRank(ctx, listUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", types.NewAPIError(http.StatusInternalServerError, "error fetching list statuses")
+	}
+
+	rank, err := helpers.RankBetween(last, "")
+	if err != nil {
+		return "", types.NewAPIError(http.StatusInternalServerError, "error calculating rank")
+	}
+
+	return rank, nil
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+)
+
+type ListsService struct {
+	q *queries.Queries
+}
+
+func NewListsService(q *queries.Queries) *ListsService {
+	return &ListsService{q: q}
+}
+
+// AddList creates a new list owned by the authenticated user
+func (s *ListsService) AddList(ctx context.Context, request types.AddListRequest, userUuid string) (*queries.AddListRow, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name == "" {
+		return nil, types.NewAPIError(http.StatusBadRequest, "name is required")
+	}
+
+	list, err := s.q.AddList(ctx, queries.AddListParams{
+		Name:     request.Name,
+		UserUuid: *pgUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error adding list")
+	}
+
+	return &list, nil
+}
+
+// GetListByUuid returns a single list by UUID
+func (s *ListsService) GetListByUuid(ctx context.Context, uuid string) (*queries.GetListByUuidRow, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.q.GetListByUuid(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting list by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "list not found")
+	}
+
+	if err := authorize(anonymousActor, publicRead, resourceList, ownership{owner: list.UserUuid}); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// GetListsForUser retrieves all lists belonging to a user as a paginated list
+func (s *ListsService) GetListsForUser(ctx context.Context, userUuid string, pagination *types.Pagination) (*types.PaginatedListsResponse, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.q.GetListsCountForUser(ctx, *pgUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching list count")
+	}
+
+	pagination.Total = total
+
+	if total == 0 {
+		response := &types.PaginatedListsResponse{
+			Pagination: *pagination,
+			Lists:      []types.ListsResponse{},
+		}
+		return response, nil
+	}
+
+	items, err := s.q.GetListsByUser(ctx, queries.GetListsByUserParams{
+		UserUuid: *pgUuid,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching lists")
+	}
+
+	lists := make([]types.ListsResponse, len(items))
+
+	for i, item := range items {
+		lists[i] = types.ListsResponse{
+			UUID: item.Uuid.String(),
+			Name: item.Name,
+		}
+	}
+
+	response := types.PaginatedListsResponse{
+		Pagination: *pagination,
+		Lists:      lists,
+	}
+
+	return &response, nil
+}
+
+// UpdateList renames a list owned by the authenticated user
+func (s *ListsService) UpdateList(ctx context.Context, uuid, userUuid string, request types.UpdateListRequest) (*queries.UpdateListRow, error) {
+	list, err := s.getOwnedList(ctx, uuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name == "" {
+		return nil, types.NewAPIError(http.StatusBadRequest, "name is required")
+	}
+
+	result, err := s.q.UpdateList(ctx, queries.UpdateListParams{
+		ListName: request.Name,
+		ListUuid: list.Uuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating list")
+	}
+
+	return &result, nil
+}
+
+// DeleteList deletes a list owned by the authenticated user
+func (s *ListsService) DeleteList(ctx context.Context, uuid, userUuid string) error {
+	list, err := s.getOwnedList(ctx, uuid, userUuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.q.DeleteList(ctx, list.Uuid)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting list")
+	}
+
+	return nil
+}
+
+// GetBoard returns a list as a kanban board with each list status as a column
+func (s *ListsService) GetBoard(ctx context.Context, uuid string) (*types.BoardResponse, error) {
+	list, err := s.GetListByUuid(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := s.q.GetBoardColumnsForList(ctx, list.Uuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching board columns")
+	}
+
+	cards, err := s.q.GetBoardCardsForList(ctx, list.Uuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching board cards")
+	}
+
+	boardColumns := make([]types.BoardColumnResponse, len(columns))
+	columnIndex := make(map[string]int, len(columns))
+
+	for i, column := range columns {
+		boardColumns[i] = types.BoardColumnResponse{
+			UUID:       column.ListStatusUuid.String(),
+			StatusUUID: column.StatusUuid.String(),
+			Label:      column.Label.String,
+			WipLimit:   helpers.PgInt4Pointer(column.WipLimit),
+			Cards:      []types.BoardCardResponse{},
+		}
+		columnIndex[column.StatusUuid.String()] = i
+	}
+
+	// Cards arrive ordered by rank, so appending keeps each column in order
+	for _, card := range cards {
+		i, ok := columnIndex[card.StatusUuid.String()]
+		if !ok {
+			continue
+		}
+
+		boardColumns[i].Cards = append(boardColumns[i].Cards, types.BoardCardResponse{
+			UUID:            card.ListItemUuid.String(),
+			ItemUUID:        card.ItemUuid.String(),
+			Title:           card.Title,
+			TmdbID:          helpers.PgInt8Pointer(card.TmdbID),
+			ReleaseDate:     helpers.PgDatePointer(card.ReleaseDate),
+			Runtime:         helpers.PgInt4Pointer(card.Runtime),
+			PosterPath:      helpers.PgTextPointer(card.PosterPath),
+			Rank:            card.Rank,
+			Position:        card.Position,
+			AddedDate:       card.CreatedDate.Time,
+			ItemCreatedDate: card.ItemCreatedDate.Time,
+		})
+	}
+
+	for i := range boardColumns {
+		column := &boardColumns[i]
+		column.CardCount = int32(len(column.Cards))
+		if column.WipLimit != nil {
+			column.AtLimit = column.CardCount >= *column.WipLimit
+			column.OverLimit = column.CardCount > *column.WipLimit
+		}
+	}
+
+	response := types.BoardResponse{
+		UUID:    list.Uuid.String(),
+		Name:    list.Name,
+		Columns: boardColumns,
+	}
+
+	return &response, nil
+}
+
+// getOwnedList fetches a list and checks that it belongs to the given user
+func (s *ListsService) getOwnedList(ctx context.Context, uuid, userUuid string) (*queries.GetListByUuidRow, error) {
+	list, err := s.GetListByUuid(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(userActor(userUuid), ownerOnly, resourceList, ownership{owner: list.UserUuid}); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// mailTimeout is how long sending an email in the background can take before it is given up on
+const mailTimeout = 30 * time.Second
+
+// Email is a plain text email to a single recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails to users
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SmtpMailer sends emails through an SMTP server. STARTTLS is used when the server offers it, and the
+// server is only authenticated with if a username is set, so that a local stand-in such as MailHog
+// can be used during development
+type SmtpMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewSmtpMailer(host string, port int, username, password, from string) *SmtpMailer {
+	return &SmtpMailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+// Send delivers an email through the SMTP server
+func (m *SmtpMailer) Send(ctx context.Context, email Email) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.host, fmt.Sprint(m.port)))
+	if err != nil {
+		return fmt.Errorf("couldn't connect to smtp server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("couldn't start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return fmt.Errorf("couldn't start tls: %w", err)
+		}
+	}
+
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return fmt.Errorf("couldn't authenticate with smtp server: %w", err)
+		}
+	}
+
+	// The sender may include a display name, which only belongs in the From header
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	if err := client.Mail(sender.Address); err != nil {
+		return fmt.Errorf("smtp server rejected sender: %w", err)
+	}
+
+	if err := client.Rcpt(email.To); err != nil {
+		return fmt.Errorf("smtp server rejected recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("couldn't send email: %w", err)
+	}
+
+	if _, err := w.Write(formatEmail(m.from, email)); err != nil {
+		return fmt.Errorf("couldn't send email: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("couldn't send email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// LogMailer writes emails to a log instead of sending them. It is used when no SMTP server is set up,
+// and writing to a file lets the emails be read back during development
+type LogMailer struct {
+	logger *log.Logger
+	from   string
+}
+
+func NewLogMailer(w io.Writer, from string) *LogMailer {
+	return &LogMailer{logger: log.New(w, "", log.LstdFlags), from: from}
+}
+
+// Send writes an email to the log
+func (m *LogMailer) Send(_ context.Context, email Email) error {
+	m.logger.Printf("Email to %s\n%s", email.To, formatEmail(m.from, email))
+	return nil
+}
+
+// formatEmail builds the message sent for an email, headers included. Line breaks are removed from
+// headers so that user input can't add headers of its own
+func formatEmail(from string, email Email) []byte {
+	header := strings.NewReplacer("\r", "", "\n", "")
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", header.Replace(from))
+	fmt.Fprintf(&msg, "To: %s\r\n", header.Replace(email.To))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", header.Replace(email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(email.Body, "\r\n", "\n"), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	return msg.Bytes()
+}
+
+// sendInBackground sends an email, logging rather than returning any error because the request that caused
+// it has already been answered
+func sendInBackground(mailer Mailer, email Email) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+	defer cancel()
+
+	if err := mailer.Send(ctx, email); err != nil {
+		log.Printf("Couldn't send email to %s: %v", email.To, err)
+	}
+}
+
+// appLink builds a link to a page of the app that takes a token
+func appLink(appUrl, page, token string) string {
+	return fmt.Sprintf("%s/%s?token=%s", appUrl, page, url.QueryEscape(token))
+}
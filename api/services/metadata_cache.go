@@ -0,0 +1,197 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Cache statuses reported alongside cached metadata responses
+const (
+	CacheHit   = "HIT"
+	CacheMiss  = "MISS"
+	CacheStale = "STALE"
+)
+
+// MetadataCache wraps a MetadataProvider with a cache stored in Postgres. Entries younger than ttl
+// are served without contacting the provider. Older entries are kept for maxStale so that they can
+// be served when the provider can't be reached
+type MetadataCache struct {
+	q        *queries.Queries
+	provider MetadataProvider
+	ttl      time.Duration
+	maxStale time.Duration
+}
+
+func NewMetadataCache(q *queries.Queries, provider MetadataProvider, ttl, maxStale time.Duration) *MetadataCache {
+	return &MetadataCache{
+		q:        q,
+		provider: provider,
+		ttl:      ttl,
+		maxStale: maxStale,
+	}
+}
+
+// Run purges entries older than maxStale once a day until the context is cancelled
+func (c *MetadataCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	c.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.purge(ctx)
+		}
+	}
+}
+
+// SearchMovies searches for movies, returning the results and the cache status
+func (c *MetadataCache) SearchMovies(ctx context.Context, query string, page int, language string) (*tmdb.SearchMovies, string, error) {
+	key := c.key(fmt.Sprintf("search/movie?query=%s&page=%d", url.QueryEscape(strings.ToLower(strings.TrimSpace(query))), page), language)
+
+	return cachedFetch(ctx, c, key, func() (*tmdb.SearchMovies, error) {
+		results, err := c.provider.SearchMovies(query, page, language)
+		return results, mapMetadataError(err, "failed to fetch movies")
+	})
+}
+
+// GetMovieDetails fetches the details of a movie, returning the details and the cache status
+func (c *MetadataCache) GetMovieDetails(ctx context.Context, tmdbId int64, language string) (*tmdb.MovieDetails, string, error) {
+	key := c.key(fmt.Sprintf("movie/%d", tmdbId), language)
+
+	return cachedFetch(ctx, c, key, func() (*tmdb.MovieDetails, error) {
+		details, err := c.provider.GetMovieDetails(tmdbId, language)
+		return details, mapMetadataError(err, "failed to fetch movie")
+	})
+}
+
+// GetMovieCredits fetches the cast and crew of a movie, returning the credits and the cache status
+func (c *MetadataCache) GetMovieCredits(ctx context.Context, tmdbId int64, language string) (*tmdb.MovieCredits, string, error) {
+	key := c.key(fmt.Sprintf("movie/%d/credits", tmdbId), language)
+
+	return cachedFetch(ctx, c, key, func() (*tmdb.MovieCredits, error) {
+		credits, err := c.provider.GetMovieCredits(tmdbId, language)
+		return credits, mapMetadataError(err, "failed to fetch movie credits")
+	})
+}
+
+// GetMovieImages fetches the images of a movie, returning the images and the cache status
+func (c *MetadataCache) GetMovieImages(ctx context.Context, tmdbId int64, language string) (*tmdb.MovieImages, string, error) {
+	key := c.key(fmt.Sprintf("movie/%d/images", tmdbId), language)
+
+	return cachedFetch(ctx, c, key, func() (*tmdb.MovieImages, error) {
+		images, err := c.provider.GetMovieImages(tmdbId, language)
+		return images, mapMetadataError(err, "failed to fetch movie images")
+	})
+}
+
+// FindByImdbID looks up TMDB results by IMDb ID, returning the results and the cache status
+func (c *MetadataCache) FindByImdbID(ctx context.Context, imdbId string) (*tmdb.FindByID, string, error) {
+	key := c.key(fmt.Sprintf("find/%s?external_source=imdb_id", url.PathEscape(imdbId)), "")
+
+	return cachedFetch(ctx, c, key, func() (*tmdb.FindByID, error) {
+		results, err := c.provider.FindByImdbID(imdbId)
+		return results, mapMetadataError(err, "failed to find movie")
+	})
+}
+
+// key builds a cache key that is unique to the provider, path and language of a request
+func (c *MetadataCache) key(path, language string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s:%s%slanguage=%s", c.provider.Name(), path, separator, url.QueryEscape(language))
+}
+
+// purge deletes entries that are too old to be served even when TMDB is unreachable
+func (c *MetadataCache) purge(ctx context.Context) {
+	fetchedBefore := pgtype.Timestamptz{Time: time.Now().Add(-c.maxStale), Valid: true}
+
+	deleted, err := c.q.DeleteTmdbCacheEntriesBefore(ctx, fetchedBefore)
+	if err != nil {
+		log.Printf("Couldn't purge the metadata cache: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("Purged %d metadata cache entries", deleted)
+	}
+}
+
+// cachedFetch returns the entry stored under key while it is younger than the cache TTL. Otherwise it
+// calls fetch and stores the result. If fetch fails for any reason other than the movie not
+// existing, a stale entry is served instead of the error
+func cachedFetch[T any](ctx context.Context, c *MetadataCache, key string, fetch func() (*T, error)) (*T, string, error) {
+	entry, err := c.q.GetTmdbCacheEntry(ctx, key)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		// A broken cache shouldn't break search, so treat it as a miss
+		log.Printf("Couldn't read metadata cache entry %s: %v", key, err)
+	}
+
+	var cached *T
+	if err == nil {
+		cached = new(T)
+		if err := json.Unmarshal(entry.Response, cached); err != nil {
+			log.Printf("Couldn't decode metadata cache entry %s: %v", key, err)
+			cached = nil
+		}
+	}
+
+	if cached != nil && time.Since(entry.FetchedDate.Time) < c.ttl {
+		return cached, CacheHit, nil
+	}
+
+	result, err := fetch()
+	if err != nil {
+		var apiErr *types.APIError
+		if cached != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+			log.Printf("Serving stale metadata cache entry %s: %v", key, err)
+			return cached, CacheStale, nil
+		}
+		return nil, "", err
+	}
+
+	response, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("Couldn't encode metadata cache entry %s: %v", key, err)
+		return result, CacheMiss, nil
+	}
+
+	err = c.q.UpsertTmdbCacheEntry(ctx, queries.UpsertTmdbCacheEntryParams{
+		CacheKey: key,
+		Response: response,
+	})
+	if err != nil {
+		log.Printf("Couldn't store metadata cache entry %s: %v", key, err)
+	}
+
+	return result, CacheMiss, nil
+}
+
+// mapMetadataError turns a MetadataProvider error into an API error
+func mapMetadataError(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrMetadataNotFound) {
+		return types.NewAPIError(http.StatusNotFound, "movie not found")
+	}
+	log.Printf("Metadata provider error: %v", err)
+	return types.NewAPIError(http.StatusBadGateway, message)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	tmdb "github.com/cyruzin/golang-tmdb"
+)
+
+// ErrMetadataNotFound is returned by a MetadataProvider when a movie doesn't exist
+var ErrMetadataNotFound = errors.New("movie not found")
+
+// MetadataProvider fetches movie metadata. Responses use the TMDB response types so that
+// every provider returns the same shape regardless of where the data comes from
+type MetadataProvider interface {
+	// Name identifies the provider in cache keys and logs
+	Name() string
+	SearchMovies(query string, page int, language string) (*tmdb.SearchMovies, error)
+	GetMovieDetails(tmdbId int64, language string) (*tmdb.MovieDetails, error)
+	GetMovieCredits(tmdbId int64, language string) (*tmdb.MovieCredits, error)
+	GetMovieImages(tmdbId int64, language string) (*tmdb.MovieImages, error)
+	FindByImdbID(imdbId string) (*tmdb.FindByID, error)
+}
+
+// TmdbProvider fetches movie metadata from the TMDB API
+type TmdbProvider struct {
+	client *tmdb.Client
+}
+
+func NewTmdbProvider(client *tmdb.Client) *TmdbProvider {
+	return &TmdbProvider{client: client}
+}
+
+// Name identifies the provider in cache keys and logs
+func (p *TmdbProvider) Name() string {
+	return "tmdb"
+}
+
+// SearchMovies searches TMDB for movies
+func (p *TmdbProvider) SearchMovies(query string, page int, language string) (*tmdb.SearchMovies, error) {
+	options := tmdbOptions(language)
+	options["page"] = fmt.Sprint(page)
+
+	results, err := p.client.GetSearchMovies(query, options)
+	return results, wrapTmdbError(err)
+}
+
+// GetMovieDetails fetches the primary details of a movie from TMDB
+func (p *TmdbProvider) GetMovieDetails(tmdbId int64, language string) (*tmdb.MovieDetails, error) {
+	details, err := p.client.GetMovieDetails(int(tmdbId), tmdbOptions(language))
+	return details, wrapTmdbError(err)
+}
+
+// GetMovieCredits fetches the cast and crew of a movie from TMDB
+func (p *TmdbProvider) GetMovieCredits(tmdbId int64, language string) (*tmdb.MovieCredits, error) {
+	credits, err := p.client.GetMovieCredits(int(tmdbId), tmdbOptions(language))
+	return credits, wrapTmdbError(err)
+}
+
+// GetMovieImages fetches the posters, backdrops and logos of a movie from TMDB
+func (p *TmdbProvider) GetMovieImages(tmdbId int64, language string) (*tmdb.MovieImages, error) {
+	images, err := p.client.GetMovieImages(int(tmdbId), tmdbOptions(language))
+	return images, wrapTmdbError(err)
+}
+
+// FindByImdbID looks up the TMDB movies, shows and people with an IMDb ID
+func (p *TmdbProvider) FindByImdbID(imdbId string) (*tmdb.FindByID, error) {
+	results, err := p.client.GetFindByID(imdbId, map[string]string{"external_source": "imdb_id"})
+	return results, wrapTmdbError(err)
+}
+
+// tmdbNotFound is the TMDB status code returned for unknown resources
+const tmdbNotFound = 34
+
+// tmdbOptions builds the TMDB URL options for a request, adding the language when one is given
+func tmdbOptions(language string) map[string]string {
+	options := map[string]string{}
+	if language != "" {
+		options["language"] = language
+	}
+	return options
+}
+
+// wrapTmdbError converts TMDB's not found response into ErrMetadataNotFound
+func wrapTmdbError(err error) error {
+	var tmdbErr tmdb.Error
+	if errors.As(err, &tmdbErr) && tmdbErr.StatusCode == tmdbNotFound {
+		return fmt.Errorf("%w: %v", ErrMetadataNotFound, err)
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MetadataRefresher periodically re-fetches the details of TMDB items whose metadata is older than
+// maxAge. Requests to the provider are spaced out by requestInterval to stay within TMDB's rate limits.
+// The cache is bypassed so that every refresh sees the provider's latest details
+type MetadataRefresher struct {
+	q               *queries.Queries
+	provider        MetadataProvider
+	maxAge          time.Duration
+	requestInterval time.Duration
+	interval        time.Duration
+	batchSize       int32
+}
+
+func NewMetadataRefresher(q *queries.Queries, provider MetadataProvider, maxAge, requestInterval time.Duration) *MetadataRefresher {
+	return &MetadataRefresher{
+		q:               q,
+		provider:        provider,
+		maxAge:          maxAge,
+		requestInterval: requestInterval,
+		interval:        time.Hour,
+		batchSize:       500,
+	}
+}
+
+// Run refreshes a batch of stale items every interval until the context is cancelled
+func (r *MetadataRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.sweep(ctx)
+		}
+	}
+}
+
+// RefreshItem re-fetches the TMDB details of a single item and stores any changes
+func (r *MetadataRefresher) RefreshItem(ctx context.Context, itemUuid pgtype.UUID) (*types.ItemDetailsResponse, error) {
+	item, err := r.q.GetItemByUuid(ctx, itemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	}
+
+	if !item.TmdbID.Valid {
+		return nil, types.NewAPIError(http.StatusBadRequest, "item was not imported from tmdb")
+	}
+
+	details, err := r.provider.GetMovieDetails(item.TmdbID.Int64, "")
+	if err != nil {
+		err = mapMetadataError(err, "failed to fetch movie")
+		// Movies removed from TMDB keep their last known details, but shouldn't be retried on every sweep
+		var apiErr *types.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			log.Printf("Item %s (tmdb %d) no longer exists on %s", item.Uuid.String(), item.TmdbID.Int64, r.provider.Name())
+			if err := r.q.TouchItemMetadataRefreshed(ctx, item.Uuid); err != nil {
+				return nil, types.NewAPIError(http.StatusInternalServerError, "error updating item")
+			}
+		}
+		return nil, err
+	}
+
+	metadata := movieMetadataFromDetails(details)
+
+	err = r.q.UpdateItemMetadata(ctx, queries.UpdateItemMetadataParams{
+		ImdbID:           metadata.ImdbID,
+		ReleaseDate:      metadata.ReleaseDate,
+		Runtime:          metadata.Runtime,
+		Overview:         metadata.Overview,
+		OriginalLanguage: metadata.OriginalLanguage,
+		PosterPath:       metadata.PosterPath,
+		BackdropPath:     metadata.BackdropPath,
+		Genres:           metadata.Genres,
+		ItemUuid:         item.Uuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating item")
+	}
+
+	if changes := metadataChanges(item, metadata); len(changes) > 0 {
+		log.Printf("Refreshed item %s (tmdb %d): %s", item.Uuid.String(), item.TmdbID.Int64, strings.Join(changes, "; "))
+	}
+
+	return getItemDetails(ctx, r.q, item.Uuid)
+}
+
+// sweep refreshes the items whose metadata is older than maxAge, oldest first
+func (r *MetadataRefresher) sweep(ctx context.Context) {
+	staleBefore := pgtype.Timestamptz{Time: time.Now().Add(-r.maxAge), Valid: true}
+
+	items, err := r.q.GetItemsDueForRefresh(ctx, queries.GetItemsDueForRefreshParams{
+		StaleBefore: staleBefore,
+		BatchSize:   r.batchSize,
+	})
+	if err != nil {
+		log.Printf("Couldn't fetch items due for a metadata refresh: %v", err)
+		return
+	}
+
+	limiter := time.NewTicker(r.requestInterval)
+	defer limiter.Stop()
+
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			return
+		case <-limiter.C:
+		}
+
+		if _, err := r.RefreshItem(ctx, item.Uuid); err != nil {
+			log.Printf("Couldn't refresh item %s (tmdb %d): %v", item.Uuid.String(), item.TmdbID.Int64, err)
+		}
+	}
+}
+
+// metadataChanges describes the fields that differ between an item and its refreshed metadata
+func metadataChanges(item queries.GetItemByUuidRow, metadata *movieMetadata) []string {
+	var changes []string
+
+	compare := func(field string, before, after any) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s %v -> %v", field, before, after))
+		}
+	}
+
+	compare("imdb_id", item.ImdbID.String, metadata.ImdbID.String)
+	compare("release_date", formatPgDate(item.ReleaseDate), formatPgDate(metadata.ReleaseDate))
+	compare("runtime", item.Runtime.Int32, metadata.Runtime.Int32)
+	compare("original_language", item.OriginalLanguage.String, metadata.OriginalLanguage.String)
+	compare("poster_path", item.PosterPath.String, metadata.PosterPath.String)
+	compare("backdrop_path", item.BackdropPath.String, metadata.BackdropPath.String)
+
+	if !slices.Equal(item.Genres, metadata.Genres) {
+		compare("genres", strings.Join(item.Genres, ","), strings.Join(metadata.Genres, ","))
+	}
+
+	// Overviews are too long to log in full
+	if item.Overview.String != metadata.Overview.String {
+		changes = append(changes, "overview updated")
+	}
+
+	return changes
+}
+
+// formatPgDate formats a postgres date for logging
+func formatPgDate(d pgtype.Date) string {
+	if date := helpers.PgDatePointer(d); date != nil {
+		return *date
+	}
+	return "none"
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/skip2/go-qrcode"
+	"net/http"
+	"time"
+)
+
+const (
+	// totpIssuer names the app in authenticator apps
+	totpIssuer = "eigakanban"
+	// totpQrCodeSize is the width and height of the QR code image in pixels
+	totpQrCodeSize = 256
+	// recoveryCodeCount is how many recovery codes are issued at a time
+	recoveryCodeCount = 10
+)
+
+type MfaService struct {
+	q  *queries.Queries
+	db *pgxpool.Pool
+}
+
+func NewMfaService(q *queries.Queries, db *pgxpool.Pool) *MfaService {
+	return &MfaService{q: q, db: db}
+}
+
+// StartTotpEnrollment creates a new TOTP secret for the authenticated user. Two-factor authentication isn't
+// turned on until a code from the secret is confirmed, and starting again replaces a secret that wasn't
+func (s *MfaService) StartTotpEnrollment(ctx context.Context, userUuid string) (*types.TotpEnrollmentResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getUserTotp(ctx, s.q, *pgUserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TotpEnabledAt.Valid {
+		return nil, types.NewAPIError(http.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	secret, err := helpers.GenerateTotpSecret()
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating totp secret")
+	}
+
+	updated, err := s.q.SetPendingTotpSecret(ctx, queries.SetPendingTotpSecretParams{
+		TotpSecret: helpers.MakePgString(secret),
+		UserUuid:   *pgUserUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error storing totp secret")
+	}
+
+	if updated == 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	uri := helpers.TotpURI(totpIssuer, user.Username, secret)
+
+	qrCode, err := qrcode.Encode(uri, qrcode.Medium, totpQrCodeSize)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating qr code")
+	}
+
+	response := types.TotpEnrollmentResponse{
+		Secret:     secret,
+		OtpauthURI: uri,
+		QrCode:     qrCode,
+	}
+
+	return &response, nil
+}
+
+// ConfirmTotpEnrollment turns on two-factor authentication once the user has entered a code from their new
+// secret, and returns their recovery codes
+func (s *MfaService) ConfirmTotpEnrollment(ctx context.Context, userUuid, code string) (*types.RecoveryCodesResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getUserTotp(ctx, s.q, *pgUserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TotpEnabledAt.Valid {
+		return nil, types.NewAPIError(http.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	if !user.TotpSecret.Valid {
+		return nil, types.NewAPIError(http.StatusConflict, "two-factor authentication enrollment hasn't been started")
+	}
+
+	step, ok := helpers.ValidateTotp(user.TotpSecret.String, code, time.Now())
+	if !ok {
+		return nil, types.NewAPIError(http.StatusBadRequest, "invalid code")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	// The code used to confirm can't be used to log in as well
+	enabled, err := qtx.EnableTotp(ctx, queries.EnableTotpParams{
+		Step:   pgtype.Int8{Int64: step, Valid: true},
+		UserID: user.UserID,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error enabling two-factor authentication")
+	}
+
+	if enabled == 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	recoveryCodes, err := replaceRecoveryCodes(ctx, qtx, user.UserID.Int64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return &types.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTotp turns off two-factor authentication for the authenticated user. Both their password and a
+// second factor are needed, so that a session left open can't be used to weaken the account
+func (s *MfaService) DisableTotp(ctx context.Context, userUuid string, req types.DisableTotpRequest) error {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return err
+	}
+
+	user, err := getUserTotp(ctx, s.q, *pgUserUuid)
+	if err != nil {
+		return err
+	}
+
+	if !user.TotpEnabledAt.Valid {
+		return types.NewAPIError(http.StatusConflict, "two-factor authentication isn't enabled")
+	}
+
+	if !helpers.CheckPasswordHash(req.Password, user.HashedPassword) {
+		return types.NewAPIError(http.StatusBadRequest, "incorrect password")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	ok, err := checkSecondFactor(ctx, qtx, user, req.Code)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return types.NewAPIError(http.StatusBadRequest, "invalid code")
+	}
+
+	if err := qtx.DisableTotp(ctx, user.UserID); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error disabling two-factor authentication")
+	}
+
+	if err := qtx.DeleteRecoveryCodes(ctx, user.UserID.Int64); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting recovery codes")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the authenticated user's recovery codes, for when they have been lost or
+// mostly used up
+func (s *MfaService) RegenerateRecoveryCodes(ctx context.Context, userUuid, code string) (*types.RecoveryCodesResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := getUserTotp(ctx, s.q, *pgUserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TotpEnabledAt.Valid {
+		return nil, types.NewAPIError(http.StatusConflict, "two-factor authentication isn't enabled")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	ok, err := checkSecondFactor(ctx, qtx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, types.NewAPIError(http.StatusBadRequest, "invalid code")
+	}
+
+	recoveryCodes, err := replaceRecoveryCodes(ctx, qtx, user.UserID.Int64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return &types.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// getUserTotp fetches a user's password and TOTP settings
+func getUserTotp(ctx context.Context, q *queries.Queries, userUuid pgtype.UUID) (queries.GetUserTotpRow, error) {
+	user, err := q.GetUserTotp(ctx, userUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return queries.GetUserTotpRow{}, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return queries.GetUserTotpRow{}, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	return user, nil
+}
+
+// checkSecondFactor checks a code from the user's authenticator app or one of their recovery codes. A code
+// that is accepted is used up, so it can't be used again
+func checkSecondFactor(ctx context.Context, q *queries.Queries, user queries.GetUserTotpRow, code string) (bool, error) {
+	if step, ok := helpers.ValidateTotp(user.TotpSecret.String, code, time.Now()); ok {
+		used, err := q.UseTotpStep(ctx, queries.UseTotpStepParams{
+			Step:   pgtype.Int8{Int64: step, Valid: true},
+			UserID: user.UserID,
+		})
+		if err != nil {
+			return false, types.NewAPIError(http.StatusInternalServerError, "error checking code")
+		}
+
+		return used > 0, nil
+	}
+
+	used, err := q.UseRecoveryCode(ctx, queries.UseRecoveryCodeParams{
+		UserID:   user.UserID.Int64,
+		CodeHash: helpers.HashRecoveryCode(code),
+	})
+	if err != nil {
+		return false, types.NewAPIError(http.StatusInternalServerError, "error checking code")
+	}
+
+	return used > 0, nil
+}
+
+// replaceRecoveryCodes issues a new set of recovery codes, and stops the old ones from working
+func replaceRecoveryCodes(ctx context.Context, q *queries.Queries, userId int64) ([]string, error) {
+	if err := q.DeleteRecoveryCodes(ctx, userId); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting recovery codes")
+	}
+
+	recoveryCodes := make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		code, err := helpers.GenerateRecoveryCode()
+		if err != nil {
+			return nil, types.NewAPIError(http.StatusInternalServerError, "error generating recovery codes")
+		}
+
+		err = q.AddRecoveryCode(ctx, queries.AddRecoveryCodeParams{
+			UserID:   userId,
+			CodeHash: helpers.HashRecoveryCode(code),
+		})
+		if err != nil {
+			return nil, types.NewAPIError(http.StatusInternalServerError, "error storing recovery codes")
+		}
+
+		recoveryCodes[i] = code
+	}
+
+	return recoveryCodes, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+	"time"
+)
+
+// mfaDB fakes the queries that spend a second factor. It keeps the last TOTP step used and which recovery
+// codes are unused, as the users and recovery_codes tables do
+func mfaDB(recoveryCodeHashes ...string) *fakeDB {
+	var lastStep int64
+	unused := make(map[string]bool)
+	for _, hash := range recoveryCodeHashes {
+		unused[hash] = true
+	}
+
+	return &fakeDB{
+		exec: func(name string, args []interface{}) (int64, error) {
+			switch name {
+			case "UseTotpStep":
+				step := args[0].(pgtype.Int8).Int64
+				if step <= lastStep {
+					return 0, nil
+				}
+				lastStep = step
+				return 1, nil
+			case "UseRecoveryCode":
+				hash := args[1].(string)
+				if !unused[hash] {
+					return 0, nil
+				}
+				unused[hash] = false
+				return 1, nil
+			}
+
+			return 0, fmt.Errorf("unexpected query %s", name)
+		},
+	}
+}
+
+func TestCheckSecondFactor(t *testing.T) {
+	secret, err := helpers.GenerateTotpSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recoveryCode, err := helpers.GenerateRecoveryCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := queries.New(mfaDB(helpers.HashRecoveryCode(recoveryCode)))
+
+	user := queries.GetUserTotpRow{
+		UserID:     pgtype.Int8{Int64: 1, Valid: true},
+		TotpSecret: pgtype.Text{String: secret, Valid: true},
+	}
+
+	totpCode := currentTotpCode(t, secret)
+
+	tests := []struct {
+		name string
+		code string
+		ok   bool
+	}{
+		{"totp code", totpCode, true},
+		{"totp code again", totpCode, false},
+		{"recovery code", recoveryCode, true},
+		{"recovery code again", recoveryCode, false},
+		{"unknown recovery code", "aaaaa-aaaaa", false},
+	}
+
+	// The cases run in order, so that the codes used earlier are spent
+	for _, tt := range tests {
+		ok, err := checkSecondFactor(context.Background(), q, user, tt.code)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if ok != tt.ok {
+			t.Errorf("%s: got %v, want %v", tt.name, ok, tt.ok)
+		}
+	}
+}
+
+// currentTotpCode works out the code an authenticator app would show for a secret right now, following
+// RFC 4226 independently of the helpers
+func currentTotpCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(time.Now().Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000)
+}
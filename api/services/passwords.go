@@ -0,0 +1,235 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"net/http"
+	"time"
+)
+
+type PasswordsService struct {
+	q         *queries.Queries
+	db        *pgxpool.Pool
+	tokenKeys *helpers.TokenKeys
+	mailer    Mailer
+	appUrl    string
+	resetTTL  time.Duration
+}
+
+func NewPasswordsService(q *queries.Queries, db *pgxpool.Pool, tokenKeys *helpers.TokenKeys, mailer Mailer, appUrl string, resetTTL time.Duration) *PasswordsService {
+	return &PasswordsService{q: q, db: db, tokenKeys: tokenKeys, mailer: mailer, appUrl: appUrl, resetTTL: resetTTL}
+}
+
+// ChangePassword sets a new password for the authenticated user once their current password has been checked.
+// Every other session is logged out. The session the request was made from is kept and gets new tokens
+func (s *PasswordsService) ChangePassword(ctx context.Context, userUuid, sessionId string, req types.ChangePasswordRequest, client types.SessionClient) (*types.TokenResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgSessionId, err := helpers.ValidateAndConvertUUID(sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.q.GetUserByUuid(ctx, *pgUserUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	password, err := s.q.GetUserPassword(ctx, *pgUserUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting password")
+	}
+
+	if !helpers.CheckPasswordHash(req.CurrentPassword, password.HashedPassword) {
+		return nil, types.NewAPIError(http.StatusBadRequest, "incorrect password")
+	}
+
+	if req.NewPassword == req.CurrentPassword {
+		return nil, types.NewAPIError(http.StatusBadRequest, "new password must be different")
+	}
+
+	hashedPassword, err := helpers.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusBadRequest, "invalid password")
+	}
+
+	deviceName, err := s.q.GetSessionDeviceName(ctx, *pgSessionId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting session")
+	}
+	client.DeviceName = deviceName.String
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	err = qtx.UpdateUserPassword(ctx, queries.UpdateUserPasswordParams{
+		HashedPassword: hashedPassword,
+		UserID:         password.UserID,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating password")
+	}
+
+	if err := qtx.RevokeRefreshTokensForUser(ctx, password.UserID.Int64); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error revoking sessions")
+	}
+
+	// Reset links sent before the change shouldn't undo it
+	if err := qtx.DeletePasswordResetTokensForUser(ctx, password.UserID.Int64); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error deleting password reset tokens")
+	}
+
+	// Start the current session again under the same ID, so its access token keeps working
+	refreshToken, err := generateAndStoreRefreshToken(ctx, qtx, password.UserID.Int64, *pgSessionId, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	accessToken, expiryDate, err := s.tokenKeys.GenerateAccessToken(user, sessionId)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error generating access token")
+	}
+
+	tokenResponse := types.TokenResponse{
+		AccessToken:  accessToken,
+		ExpiryDate:   expiryDate,
+		RefreshToken: *refreshToken,
+	}
+
+	return &tokenResponse, nil
+}
+
+// RequestPasswordReset emails a password reset link to a user. Nothing is sent if the email doesn't belong
+// to a user, and the caller gets the same response either way. Storing the token still makes known emails a
+// little slower to answer, so the timing can hint at who has an account. Asking for a new link invalidates
+// any earlier one
+func (s *PasswordsService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.q.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	token, err := helpers.GenerateToken(32)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error generating reset token")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	if err := qtx.DeletePasswordResetTokensForUser(ctx, user.UserID.Int64); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting password reset tokens")
+	}
+
+	err = qtx.AddPasswordResetToken(ctx, queries.AddPasswordResetTokenParams{
+		UserID:    user.UserID.Int64,
+		TokenHash: helpers.HashToken(*token),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.resetTTL), Valid: true},
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error storing reset token")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	// Send the email in the background so that the response doesn't wait for the mail server
+	go sendInBackground(s.mailer, Email{
+		To:      user.Email,
+		Subject: "Reset your eigakanban password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password for your eigakanban account. "+
+			"To choose a new password, open this link within %s:\n\n%s\n\n"+
+			"If you didn't ask to reset your password, you can ignore this email.\n",
+			user.Username, s.resetTTL, appLink(s.appUrl, "reset-password", *token)),
+	})
+
+	return nil
+}
+
+// ResetPassword sets a new password using the token from a reset link. The token can't be used again, and
+// every session the user has is logged out
+func (s *PasswordsService) ResetPassword(ctx context.Context, req types.ResetPasswordRequest) error {
+	resetToken, err := s.q.GetPasswordResetTokenByHash(ctx, helpers.HashToken(req.Token))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting reset token")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) || resetToken.UsedAt.Valid || time.Now().After(resetToken.ExpiresAt.Time) {
+		return types.NewAPIError(http.StatusBadRequest, "invalid or expired reset token")
+	}
+
+	hashedPassword, err := helpers.HashPassword(req.NewPassword)
+	if err != nil {
+		return types.NewAPIError(http.StatusBadRequest, "invalid password")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	// Another request may have used the token since it was read
+	used, err := qtx.MarkPasswordResetTokenUsed(ctx, resetToken.TokenID)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error using reset token")
+	}
+
+	if used == 0 {
+		return types.NewAPIError(http.StatusBadRequest, "invalid or expired reset token")
+	}
+
+	err = qtx.UpdateUserPassword(ctx, queries.UpdateUserPasswordParams{
+		HashedPassword: hashedPassword,
+		UserID:         pgtype.Int8{Int64: resetToken.UserID, Valid: true},
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error updating password")
+	}
+
+	if err := qtx.RevokeRefreshTokensForUser(ctx, resetToken.UserID); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error revoking sessions")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return nil
+}
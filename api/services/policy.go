@@ -0,0 +1,140 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"net/http"
+	"slices"
+)
+
+// resourceKind names a kind of resource that belongs to a user
+type resourceKind string
+
+const (
+	resourceUser      resourceKind = "user"
+	resourceList      resourceKind = "list"
+	resourceStatus    resourceKind = "status"
+	resourceListItem  resourceKind = "list item"
+	resourceReview    resourceKind = "review"
+	resourceWatch     resourceKind = "watch"
+	resourceImportJob resourceKind = "import job"
+	resourceSession   resourceKind = "session"
+)
+
+// accessRule decides who other than a resource's owner may act on it
+type accessRule struct {
+	// public allows anyone, including users who aren't logged in
+	public bool
+	// permission allows users whose roles grant it, such as moderators
+	permission string
+}
+
+var (
+	// ownerOnly is for private data, such as exports and sessions, that not even admins may see
+	ownerOnly = accessRule{}
+	// publicRead is for reading resources that anyone may see, such as boards
+	publicRead = accessRule{public: true}
+)
+
+// ownerOrPermission also allows users whose roles grant a permission
+func ownerOrPermission(permission string) accessRule {
+	return accessRule{permission: permission}
+}
+
+// ownership describes who a resource belongs to
+type ownership struct {
+	owner pgtype.UUID
+}
+
+// userActor is an actor for services that are only given the requesting user's UUID. Their permissions
+// aren't known, so it can't satisfy ownerOrPermission on someone else's resource
+func userActor(userUuid string) types.Actor {
+	return types.Actor{UserUuid: userUuid}
+}
+
+// anonymousActor is an actor for requests that aren't logged in. It can only satisfy publicRead
+var anonymousActor = types.Actor{}
+
+// authorize checks that an actor may act on a resource under a rule. Every service denies access with the
+// same 403 error
+func authorize(actor types.Actor, rule accessRule, kind resourceKind, resource ownership) error {
+	if resource.owner.Valid && resource.owner.String() == actor.UserUuid {
+		return nil
+	}
+
+	if rule.public {
+		return nil
+	}
+
+	if rule.permission != "" && slices.Contains(actor.Permissions, rule.permission) {
+		return nil
+	}
+
+	return types.NewAPIError(http.StatusForbidden, fmt.Sprintf("you do not have permission to access this %s", kind))
+}
+
+// authorizeResource looks up who owns a resource and checks that an actor may act on it. Missing resources
+// return a 404 error
+func authorizeResource(ctx context.Context, q *queries.Queries, actor types.Actor, rule accessRule, kind resourceKind, uuid pgtype.UUID) error {
+	resource, err := resolveOwnership(ctx, q, kind, uuid)
+	if err != nil {
+		return err
+	}
+
+	return authorize(actor, rule, kind, resource)
+}
+
+// resolveOwnership finds the user a resource belongs to. List items and their columns belong to the owner
+// of their list
+func resolveOwnership(ctx context.Context, q *queries.Queries, kind resourceKind, uuid pgtype.UUID) (ownership, error) {
+	var owner pgtype.UUID
+	var err error
+
+	switch kind {
+	case resourceUser:
+		var user queries.GetUserByUuidRow
+		user, err = q.GetUserByUuid(ctx, uuid)
+		owner = user.Uuid
+	case resourceList:
+		var list queries.GetListByUuidRow
+		list, err = q.GetListByUuid(ctx, uuid)
+		owner = list.UserUuid
+	case resourceStatus:
+		var status queries.GetStatusRow
+		status, err = q.GetStatus(ctx, uuid)
+		owner = status.UserUuid
+	case resourceListItem:
+		owner, err = q.GetListItemOwner(ctx, uuid)
+	case resourceReview:
+		var review queries.GetReviewRow
+		review, err = q.GetReview(ctx, uuid)
+		owner = review.UserUuid
+	case resourceWatch:
+		var watch queries.GetWatchRow
+		watch, err = q.GetWatch(ctx, uuid)
+		owner = watch.UserUuid
+	case resourceImportJob:
+		var job queries.GetImportJobRow
+		job, err = q.GetImportJob(ctx, uuid)
+		owner = job.UserUuid
+	case resourceSession:
+		owner, err = q.GetSessionOwner(ctx, uuid)
+	default:
+		return ownership{}, types.NewAPIError(http.StatusInternalServerError, fmt.Sprintf("unknown resource %q", kind))
+	}
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return ownership{}, types.NewAPIError(http.StatusInternalServerError, fmt.Sprintf("error getting %s", kind))
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return ownership{}, types.NewAPIError(http.StatusNotFound, fmt.Sprintf("%s not found", kind))
+	}
+
+	return ownership{owner: owner}, nil
+}
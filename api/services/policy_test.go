@@ -0,0 +1,138 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"net/http"
+	"testing"
+)
+
+var (
+	ownerUuid    = pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	strangerUuid = pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	resourceUuid = pgtype.UUID{Bytes: [16]byte{3}, Valid: true}
+)
+
+// ownerDB fakes the queries that find who owns a resource. Every UUID column of the row is the owner's,
+// which is all resolveOwnership reads
+func ownerDB(owner pgtype.UUID, missing bool) *fakeDB {
+	return &fakeDB{
+		queryRow: func(string, []interface{}) pgx.Row {
+			if missing {
+				return noRows
+			}
+
+			return fakeRow{set: func(dest any) {
+				if uuid, ok := dest.(*pgtype.UUID); ok {
+					*uuid = owner
+				}
+			}}
+		},
+	}
+}
+
+// statusCode returns the HTTP status of an error from the policy, or 200 if access was allowed
+func statusCode(t *testing.T, err error) int {
+	t.Helper()
+
+	if err == nil {
+		return http.StatusOK
+	}
+
+	var apiErr *types.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an API error, got %v", err)
+	}
+
+	return apiErr.StatusCode
+}
+
+func TestAuthorize(t *testing.T) {
+	resource := ownership{owner: ownerUuid}
+
+	tests := []struct {
+		name  string
+		actor types.Actor
+		rule  accessRule
+		want  int
+	}{
+		{"owner with owner only", types.Actor{UserUuid: ownerUuid.String()}, ownerOnly, http.StatusOK},
+		{"owner with public read", types.Actor{UserUuid: ownerUuid.String()}, publicRead, http.StatusOK},
+		{"stranger with public read", types.Actor{UserUuid: strangerUuid.String()}, publicRead, http.StatusOK},
+		{"anonymous with public read", anonymousActor, publicRead, http.StatusOK},
+		{"anonymous with owner only", anonymousActor, ownerOnly, http.StatusForbidden},
+		{"stranger with permission rule", types.Actor{UserUuid: strangerUuid.String()}, ownerOrPermission(types.PermissionReviewsModerate), http.StatusForbidden},
+		{"permission override", types.Actor{UserUuid: strangerUuid.String(), Permissions: []string{types.PermissionReviewsModerate}}, ownerOrPermission(types.PermissionReviewsModerate), http.StatusOK},
+		{"other permission", types.Actor{UserUuid: strangerUuid.String(), Permissions: []string{types.PermissionItemsEdit}}, ownerOrPermission(types.PermissionReviewsModerate), http.StatusForbidden},
+		{"permission with owner only", types.Actor{UserUuid: strangerUuid.String(), Permissions: []string{types.PermissionReviewsModerate}}, ownerOnly, http.StatusForbidden},
+		{"stranger", types.Actor{UserUuid: strangerUuid.String()}, ownerOnly, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorize(tt.actor, tt.rule, resourceList, resource)
+			if got := statusCode(t, err); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeResource(t *testing.T) {
+	kinds := []resourceKind{
+		resourceUser,
+		resourceList,
+		resourceStatus,
+		resourceListItem,
+		resourceReview,
+		resourceWatch,
+		resourceImportJob,
+		resourceSession,
+	}
+
+	moderator := types.Actor{UserUuid: strangerUuid.String(), Permissions: []string{types.PermissionUsersEdit}}
+
+	tests := []struct {
+		name    string
+		actor   types.Actor
+		rule    accessRule
+		missing bool
+		want    int
+	}{
+		{"owner", types.Actor{UserUuid: ownerUuid.String()}, ownerOnly, false, http.StatusOK},
+		{"public read", anonymousActor, publicRead, false, http.StatusOK},
+		{"permission override", moderator, ownerOrPermission(types.PermissionUsersEdit), false, http.StatusOK},
+		{"permission with owner only", moderator, ownerOnly, false, http.StatusForbidden},
+		{"stranger", types.Actor{UserUuid: strangerUuid.String()}, ownerOnly, false, http.StatusForbidden},
+		{"missing resource", types.Actor{UserUuid: ownerUuid.String()}, ownerOnly, true, http.StatusNotFound},
+		{"missing public resource", anonymousActor, publicRead, true, http.StatusNotFound},
+	}
+
+	for _, kind := range kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					q := queries.New(ownerDB(ownerUuid, tt.missing))
+
+					err := authorizeResource(context.Background(), q, tt.actor, tt.rule, kind, resourceUuid)
+					if got := statusCode(t, err); got != tt.want {
+						t.Errorf("got %d, want %d", got, tt.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestResolveOwnershipUnknownKind(t *testing.T) {
+	q := queries.New(ownerDB(ownerUuid, false))
+
+	_, err := resolveOwnership(context.Background(), q, resourceKind("unknown"), resourceUuid)
+	if got := statusCode(t, err); got != http.StatusInternalServerError {
+		t.Errorf("got %d, want %d", got, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"time"
+)
+
+// rankColumn identifies a single status column on a list
+type rankColumn struct {
+	ListUuid   pgtype.UUID
+	StatusUuid pgtype.UUID
+}
+
+// RankRebalancer respaces the rank keys of board columns whose keys have grown too long.
+// Columns are queued by ListItemsService after a write and swept periodically in case a
+// queued column was dropped.
+type RankRebalancer struct {
+	q        *queries.Queries
+	db       *pgxpool.Pool
+	queue    chan rankColumn
+	interval time.Duration
+}
+
+func NewRankRebalancer(q *queries.Queries, db *pgxpool.Pool) *RankRebalancer {
+	return &RankRebalancer{
+		q:        q,
+		db:       db,
+		queue:    make(chan rankColumn, 64),
+		interval: time.Hour,
+	}
+}
+
+// Schedule queues a column for rebalancing without blocking the caller
+func (r *RankRebalancer) Schedule(listUuid, statusUuid pgtype.UUID) {
+	select {
+	case r.queue <- rankColumn{ListUuid: listUuid, StatusUuid: statusUuid}:
+	default:
+		// The queue is full. The periodic sweep will pick the column up
+	}
+}
+
+// Run processes queued columns and sweeps for long keys until the context is cancelled
+func (r *RankRebalancer) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case column := <-r.queue:
+			if err := r.Rebalance(ctx, column.ListUuid, column.StatusUuid); err != nil {
+				log.Printf("Couldn't rebalance list %s status %s: %v", column.ListUuid.String(), column.StatusUuid.String(), err)
+			}
+		case <-ticker.C:
+			r.sweep(ctx)
+		}
+	}
+}
+
+// Rebalance rewrites every rank in a column with evenly spaced keys, keeping the current order
+func (r *RankRebalancer) Rebalance(ctx context.Context, listUuid, statusUuid pgtype.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+
+	// Lock the list so that no cards move while the keys are rewritten
+	_, err = qtx.LockListByUuid(ctx, listUuid)
+	if err != nil {
+		return fmt.Errorf("couldn't lock list: %w", err)
+	}
+
+	listItemUuids, err := qtx.GetListItemUuidsForStatus(ctx, queries.GetListItemUuidsForStatusParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't fetch list items: %w", err)
+	}
+
+	err = qtx.UpdateListItemRanks(ctx, queries.UpdateListItemRanksParams{
+		ListItemUuids: listItemUuids,
+		Ranks:         helpers.SpreadRanks(len(listItemUuids)),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't update ranks: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// sweep rebalances every column that contains a key longer than helpers.MaxRankLength
+func (r *RankRebalancer) sweep(ctx context.Context) {
+	columns, err := r.q.GetColumnsWithLongRanks(ctx, helpers.MaxRankLength)
+	if err != nil {
+		log.Printf("Couldn't check for long ranks: %v", err)
+		return
+	}
+
+	for _, column := range columns {
+		if err := r.Rebalance(ctx, column.ListUuid, column.StatusUuid); err != nil {
+			log.Printf("Couldn't rebalance list %s status %s: %v", column.ListUuid.String(), column.StatusUuid.String(), err)
+		}
+	}
+}
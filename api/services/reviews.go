@@ -0,0 +1,300 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+)
+
+type ReviewsService struct {
+	q *queries.Queries
+}
+
+func NewReviewsService(q *queries.Queries) *ReviewsService {
+	return &ReviewsService{q: q}
+}
+
+// AddReview reviews an item as the authenticated user. Users can review each item once
+func (s *ReviewsService) AddReview(ctx context.Context, itemUuid, userUuid string, request types.AddReviewRequest) (*types.ReviewResponse, error) {
+	pgItemUuid, err := helpers.ValidateAndConvertUUID(itemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := validateReview(request.Content, request.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.q.GetItemByUuid(ctx, *pgItemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	}
+
+	exists, err := s.q.CheckReviewExists(ctx, queries.CheckReviewExistsParams{
+		ItemUuid: *pgItemUuid,
+		UserUuid: *pgUserUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking reviews")
+	}
+
+	if exists != 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "you have already reviewed this item")
+	}
+
+	reviewUuid, err := s.q.AddReview(ctx, queries.AddReviewParams{
+		ItemUuid: *pgItemUuid,
+		UserUuid: *pgUserUuid,
+		Content:  content,
+		Rating:   helpers.MakePgNumeric(request.Rating),
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error adding review")
+	}
+
+	return s.GetReview(ctx, reviewUuid.String())
+}
+
+// GetReview returns a single review by UUID
+func (s *ReviewsService) GetReview(ctx context.Context, uuid string) (*types.ReviewResponse, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.q.GetReview(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting review")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "review not found")
+	}
+
+	response := reviewResponse(queries.GetReviewsForItemRow(review))
+	return &response, nil
+}
+
+// GetReviewsForItem returns the reviews of an item as a paginated list, newest first
+func (s *ReviewsService) GetReviewsForItem(ctx context.Context, itemUuid string, pagination *types.Pagination) (*types.PaginatedReviewsResponse, error) {
+	pgItemUuid, err := helpers.ValidateAndConvertUUID(itemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.q.GetItemByUuid(ctx, *pgItemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	}
+
+	total, err := s.q.GetReviewsCountForItem(ctx, *pgItemUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching review count")
+	}
+
+	pagination.Total = total
+
+	if total == 0 {
+		response := &types.PaginatedReviewsResponse{
+			Pagination: *pagination,
+			Reviews:    []types.ReviewResponse{},
+		}
+		return response, nil
+	}
+
+	rows, err := s.q.GetReviewsForItem(ctx, queries.GetReviewsForItemParams{
+		ItemUuid: *pgItemUuid,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching reviews")
+	}
+
+	reviews := make([]types.ReviewResponse, len(rows))
+	for i, row := range rows {
+		reviews[i] = reviewResponse(row)
+	}
+
+	response := types.PaginatedReviewsResponse{
+		Pagination: *pagination,
+		Reviews:    reviews,
+	}
+
+	return &response, nil
+}
+
+// GetReviewsForUser returns the reviews written by a user as a paginated list, newest first
+func (s *ReviewsService) GetReviewsForUser(ctx context.Context, userUuid string, pagination *types.Pagination) (*types.PaginatedReviewsResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.q.GetUserByUuid(ctx, *pgUserUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	total, err := s.q.GetReviewsCountForUser(ctx, *pgUserUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching review count")
+	}
+
+	pagination.Total = total
+
+	if total == 0 {
+		response := &types.PaginatedReviewsResponse{
+			Pagination: *pagination,
+			Reviews:    []types.ReviewResponse{},
+		}
+		return response, nil
+	}
+
+	rows, err := s.q.GetReviewsForUser(ctx, queries.GetReviewsForUserParams{
+		UserUuid: *pgUserUuid,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching reviews")
+	}
+
+	reviews := make([]types.ReviewResponse, len(rows))
+	for i, row := range rows {
+		reviews[i] = reviewResponse(queries.GetReviewsForItemRow(row))
+	}
+
+	response := types.PaginatedReviewsResponse{
+		Pagination: *pagination,
+		Reviews:    reviews,
+	}
+
+	return &response, nil
+}
+
+// UpdateReview replaces the content and rating of a review written by the authenticated user
+func (s *ReviewsService) UpdateReview(ctx context.Context, uuid, userUuid string, request types.UpdateReviewRequest) (*types.ReviewResponse, error) {
+	review, err := s.getReviewFor(ctx, uuid, userActor(userUuid), ownerOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := validateReview(request.Content, request.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.q.UpdateReview(ctx, queries.UpdateReviewParams{
+		Content:    content,
+		Rating:     helpers.MakePgNumeric(request.Rating),
+		ReviewUuid: review.Uuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating review")
+	}
+
+	return s.GetReview(ctx, review.Uuid.String())
+}
+
+// DeleteReview deletes a review written by the authenticated user. Users with the reviews:moderate
+// permission can delete anyone's review
+func (s *ReviewsService) DeleteReview(ctx context.Context, uuid string, actor types.Actor) error {
+	review, err := s.getReviewFor(ctx, uuid, actor, ownerOrPermission(types.PermissionReviewsModerate))
+	if err != nil {
+		return err
+	}
+
+	err = s.q.DeleteReview(ctx, review.Uuid)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting review")
+	}
+
+	return nil
+}
+
+// getReviewFor fetches a review and checks that the actor may act on it under the rule
+func (s *ReviewsService) getReviewFor(ctx context.Context, uuid string, actor types.Actor, rule accessRule) (*queries.GetReviewRow, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.q.GetReview(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting review")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "review not found")
+	}
+
+	if err := authorize(actor, rule, resourceReview, ownership{owner: review.UserUuid}); err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// validateReview checks that a review has content or a valid rating. It returns the trimmed content
+func validateReview(content string, rating *float64) (string, error) {
+	content = strings.TrimSpace(content)
+
+	if content == "" && rating == nil {
+		return "", types.NewAPIError(http.StatusBadRequest, "a review needs content or a rating")
+	}
+
+	if err := validateRating(rating); err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
+
+// validateRating checks that an optional rating is a whole or half star between 0.5 and 5
+func validateRating(rating *float64) error {
+	if rating != nil && (*rating < 0.5 || *rating > 5 || *rating*2 != math.Trunc(*rating*2)) {
+		return types.NewAPIError(http.StatusBadRequest, "rating must be between 0.5 and 5 in steps of 0.5")
+	}
+
+	return nil
+}
+
+// reviewResponse converts a review row to its API representation
+func reviewResponse(row queries.GetReviewsForItemRow) types.ReviewResponse {
+	return types.ReviewResponse{
+		UUID:        row.Uuid.String(),
+		ItemUUID:    row.ItemUuid.String(),
+		ItemTitle:   row.ItemTitle,
+		UserUUID:    row.UserUuid.String(),
+		Username:    row.Username,
+		Content:     row.Content,
+		Rating:      helpers.PgNumericPointer(row.Rating),
+		CreatedDate: row.CreatedDate.Time,
+		UpdatedDate: helpers.PgTimestamptzPointer(row.UpdatedDate),
+	}
+}
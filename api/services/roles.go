@@ -0,0 +1,193 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"net/http"
+)
+
+type RolesService struct {
+	q  *queries.Queries
+	db *pgxpool.Pool
+}
+
+func NewRolesService(q *queries.Queries, db *pgxpool.Pool) *RolesService {
+	return &RolesService{q: q, db: db}
+}
+
+// GetRoles returns every role with the permissions it grants
+func (s *RolesService) GetRoles(ctx context.Context) (*types.RolesResponse, error) {
+	rows, err := s.q.GetRoles(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching roles")
+	}
+
+	roles := make([]types.RoleResponse, len(rows))
+	for i, row := range rows {
+		roles[i] = types.RoleResponse{
+			Name:        row.Name,
+			Description: row.Description,
+			Permissions: row.Permissions,
+		}
+	}
+
+	return &types.RolesResponse{Roles: roles}, nil
+}
+
+// GetRolesForUser returns the roles assigned to a user and the permissions they grant
+func (s *RolesService) GetRolesForUser(ctx context.Context, userUuid string) (*types.UserRolesResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := resolveOwnership(ctx, s.q, resourceUser, *pgUserUuid); err != nil {
+		return nil, err
+	}
+
+	return getUserRoles(ctx, s.q, *pgUserUuid)
+}
+
+// AssignRole gives a user a role. Assigning a role the user already has changes nothing
+func (s *RolesService) AssignRole(ctx context.Context, userUuid, role string) (*types.UserRolesResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	if _, err := resolveOwnership(ctx, qtx, resourceUser, *pgUserUuid); err != nil {
+		return nil, err
+	}
+
+	roleId, err := lockRole(ctx, qtx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.AddUserRole(ctx, queries.AddUserRoleParams{
+		RoleID:   roleId,
+		UserUuid: *pgUserUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error assigning role")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return getUserRoles(ctx, s.q, *pgUserUuid)
+}
+
+// RemoveRole takes a role away from a user. The last admin can't lose the admin role, so that there is
+// always someone who can assign roles
+func (s *RolesService) RemoveRole(ctx context.Context, userUuid, role string) (*types.UserRolesResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	if _, err := resolveOwnership(ctx, qtx, resourceUser, *pgUserUuid); err != nil {
+		return nil, err
+	}
+
+	// Locking the role stops two admins from removing each other at the same time
+	roleId, err := lockRole(ctx, qtx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := qtx.RemoveUserRole(ctx, queries.RemoveUserRoleParams{
+		UserUuid: *pgUserUuid,
+		RoleID:   roleId,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error removing role")
+	}
+
+	if removed == 0 {
+		return nil, types.NewAPIError(http.StatusNotFound, "user does not have this role")
+	}
+
+	if role == types.RoleAdmin {
+		admins, err := qtx.GetUserCountForRole(ctx, roleId)
+		if err != nil {
+			return nil, types.NewAPIError(http.StatusInternalServerError, "error counting admins")
+		}
+
+		if admins == 0 {
+			return nil, types.NewAPIError(http.StatusConflict, "the last admin can't lose the admin role")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	return getUserRoles(ctx, s.q, *pgUserUuid)
+}
+
+// lockRole finds a role by name and locks it for the rest of the transaction
+func lockRole(ctx context.Context, qtx *queries.Queries, role string) (int64, error) {
+	roleId, err := qtx.LockRoleByName(ctx, role)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, types.NewAPIError(http.StatusInternalServerError, "error getting role")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, types.NewAPIError(http.StatusNotFound, "role not found")
+	}
+
+	return roleId.Int64, nil
+}
+
+// getUserRoles fetches the roles assigned to a user and the permissions they grant
+func getUserRoles(ctx context.Context, q *queries.Queries, userUuid pgtype.UUID) (*types.UserRolesResponse, error) {
+	roles, err := q.GetRolesForUser(ctx, userUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching roles")
+	}
+
+	permissions, err := q.GetPermissionsForUser(ctx, userUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching permissions")
+	}
+
+	response := types.UserRolesResponse{
+		UserUUID:    userUuid.String(),
+		Roles:       roles,
+		Permissions: permissions,
+	}
+
+	// Users without roles have empty lists rather than null
+	if response.Roles == nil {
+		response.Roles = []string{}
+	}
+	if response.Permissions == nil {
+		response.Permissions = []string{}
+	}
+
+	return &response, nil
+}
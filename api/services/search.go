@@ -3,32 +3,38 @@ package services
 import (
 	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
 	"codeberg.org/sporiff/eigakanban/types"
+	"context"
 	tmdb "github.com/cyruzin/golang-tmdb"
-	"net/http"
-	"strconv"
 )
 
 type SearchService struct {
-	q          *queries.Queries
-	TMDBClient *tmdb.Client
+	q             *queries.Queries
+	metadataCache *MetadataCache
 }
 
-func NewSearchService(q *queries.Queries, tmdbClient *tmdb.Client) *SearchService {
+func NewSearchService(q *queries.Queries, metadataCache *MetadataCache) *SearchService {
 	return &SearchService{
-		q:          q,
-		TMDBClient: tmdbClient,
+		q:             q,
+		metadataCache: metadataCache,
 	}
 }
 
-// SearchMovie uses the TMDB API to search for movies
-func (s *SearchService) SearchMovie(pagination *types.Pagination, q string) (*tmdb.SearchMovies, error) {
-	var urlOptions = map[string]string{}
-	parsedPage := strconv.Itoa(int(pagination.Page) + 1)
-	urlOptions["page"] = parsedPage
-	results, err := s.TMDBClient.GetSearchMovies(q, urlOptions)
-	if err != nil {
-		return nil, types.NewAPIError(http.StatusInternalServerError, "failed to fetch movies")
-	}
+// SearchMovie searches the metadata provider for movies. The cache status of the results is returned alongside them
+func (s *SearchService) SearchMovie(ctx context.Context, pagination *types.Pagination, q, language string) (*tmdb.SearchMovies, string, error) {
+	return s.metadataCache.SearchMovies(ctx, q, int(pagination.Page)+1, language)
+}
+
+// GetMovieDetails fetches the details of a movie from the metadata provider. The cache status of the details is returned alongside them
+func (s *SearchService) GetMovieDetails(ctx context.Context, tmdbId int64, language string) (*tmdb.MovieDetails, string, error) {
+	return s.metadataCache.GetMovieDetails(ctx, tmdbId, language)
+}
+
+// GetMovieCredits fetches the cast and crew of a movie from the metadata provider. The cache status of the credits is returned alongside them
+func (s *SearchService) GetMovieCredits(ctx context.Context, tmdbId int64, language string) (*tmdb.MovieCredits, string, error) {
+	return s.metadataCache.GetMovieCredits(ctx, tmdbId, language)
+}
 
-	return results, nil
+// GetMovieImages fetches the images of a movie from the metadata provider. The cache status of the images is returned alongside them
+func (s *SearchService) GetMovieImages(ctx context.Context, tmdbId int64, language string) (*tmdb.MovieImages, string, error) {
+	return s.metadataCache.GetMovieImages(ctx, tmdbId, language)
 }
@@ -0,0 +1,96 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"net/http"
+)
+
+type SessionsService struct {
+	q *queries.Queries
+}
+
+func NewSessionsService(q *queries.Queries) *SessionsService {
+	return &SessionsService{q: q}
+}
+
+// GetSessionsForUser returns the sessions a user is logged in with, marking the one the request was made from
+func (s *SessionsService) GetSessionsForUser(ctx context.Context, userUuid, requesterUuid, currentSessionId string) (*types.SessionsResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(userActor(requesterUuid), ownerOnly, resourceUser, ownership{owner: *pgUserUuid}); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.q.GetSessionsForUser(ctx, *pgUserUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching sessions")
+	}
+
+	sessions := make([]types.SessionResponse, len(rows))
+	for i, row := range rows {
+		sessions[i] = types.SessionResponse{
+			ID:           row.FamilyID.String(),
+			DeviceName:   helpers.PgTextPointer(row.DeviceName),
+			UserAgent:    helpers.PgTextPointer(row.UserAgent),
+			IPAddress:    helpers.PgTextPointer(row.IpAddress),
+			LastUsedDate: row.LastUsedAt.Time,
+			ExpiryDate:   row.ExpiresAt.Time,
+			Current:      row.FamilyID.String() == currentSessionId,
+		}
+	}
+
+	return &types.SessionsResponse{Sessions: sessions}, nil
+}
+
+// RevokeSession logs out one of the requesting user's sessions
+func (s *SessionsService) RevokeSession(ctx context.Context, sessionId, requesterUuid string) error {
+	pgSessionId, err := helpers.ValidateAndConvertUUID(sessionId)
+	if err != nil {
+		return err
+	}
+
+	err = authorizeResource(ctx, s.q, userActor(requesterUuid), ownerOnly, resourceSession, *pgSessionId)
+	if err != nil {
+		return err
+	}
+
+	err = s.q.DeleteRefreshTokenFamily(ctx, *pgSessionId)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error revoking session")
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions logs a user out of every session except the one the request was made from
+func (s *SessionsService) RevokeOtherSessions(ctx context.Context, userUuid, requesterUuid, currentSessionId string) (*types.RevokedSessionsResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(userActor(requesterUuid), ownerOnly, resourceUser, ownership{owner: *pgUserUuid}); err != nil {
+		return nil, err
+	}
+
+	pgSessionId, err := helpers.ValidateAndConvertUUID(currentSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.q.DeleteOtherSessionsForUser(ctx, queries.DeleteOtherSessionsForUserParams{
+		UserUuid:        *pgUserUuid,
+		CurrentFamilyID: *pgSessionId,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error revoking sessions")
+	}
+
+	return &types.RevokedSessionsResponse{Revoked: revoked}, nil
+}
@@ -5,15 +5,20 @@ import (
 	"codeberg.org/sporiff/eigakanban/helpers"
 	"codeberg.org/sporiff/eigakanban/types"
 	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"net/http"
 )
 
 type StatusesService struct {
-	q *queries.Queries
+	q  *queries.Queries
+	db *pgxpool.Pool
 }
 
-func NewStatusesService(q *queries.Queries) *StatusesService {
-	return &StatusesService{q: q}
+func NewStatusesService(q *queries.Queries, db *pgxpool.Pool) *StatusesService {
+	return &StatusesService{q: q, db: db}
 }
 
 // AddStatus adds a new status to the database
@@ -81,3 +86,208 @@ func (s *StatusesService) GetStatusesForUser(ctx context.Context, uuid string, p
 
 	return &response, nil
 }
+
+// UpdateStatus renames a status owned by the authenticated user
+func (s *StatusesService) UpdateStatus(ctx context.Context, uuid, userUuid string, request types.UpdateStatusRequest) (*queries.UpdateStatusLabelRow, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := getOwnedStatus(ctx, s.q, *pgUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.StatusLabel == "" {
+		return nil, types.NewAPIError(http.StatusBadRequest, "label is required")
+	}
+
+	existing, err := s.q.CheckStatusLabelForUser(ctx, queries.CheckStatusLabelForUserParams{
+		UserUuid:    status.UserUuid,
+		StatusLabel: helpers.MakePgString(request.StatusLabel),
+		ExcludeUuid: status.Uuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking status labels")
+	}
+
+	if existing != 0 {
+		return nil, types.NewAPIError(http.StatusConflict, "a status with this label already exists")
+	}
+
+	result, err := s.q.UpdateStatusLabel(ctx, queries.UpdateStatusLabelParams{
+		StatusLabel: helpers.MakePgString(request.StatusLabel),
+		StatusUuid:  status.Uuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating status")
+	}
+
+	return &result, nil
+}
+
+// DeleteStatus deletes a status owned by the authenticated user. Every list item using
+// the status is moved to the end of the target status column on its list, and the target
+// status takes the place of the deleted column on any list that doesn't already show it
+func (s *StatusesService) DeleteStatus(ctx context.Context, uuid, targetUuid, userUuid string) error {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return err
+	}
+
+	if targetUuid == "" {
+		return types.NewAPIError(http.StatusBadRequest, "target status is required")
+	}
+
+	pgTargetUuid, err := helpers.ValidateAndConvertUUID(targetUuid)
+	if err != nil {
+		return err
+	}
+
+	if *pgUuid == *pgTargetUuid {
+		return types.NewAPIError(http.StatusBadRequest, "target status must be different from the deleted status")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	if _, err := getOwnedStatus(ctx, qtx, *pgUuid, userUuid); err != nil {
+		return err
+	}
+
+	if _, err := getOwnedStatus(ctx, qtx, *pgTargetUuid, userUuid); err != nil {
+		return err
+	}
+
+	listUuids, err := qtx.GetListUuidsForStatus(ctx, *pgUuid)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error fetching lists for status")
+	}
+
+	for _, listUuid := range listUuids {
+		err = s.reassignStatusOnList(ctx, qtx, listUuid, *pgUuid, *pgTargetUuid, userUuid)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = qtx.DeleteStatus(ctx, *pgUuid)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting status")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting status")
+	}
+
+	return nil
+}
+
+// reassignStatusOnList moves the column and list items of a status on a single list to the target status
+func (s *StatusesService) reassignStatusOnList(ctx context.Context, qtx *queries.Queries, listUuid, statusUuid, targetUuid pgtype.UUID, userUuid string) error {
+	err := lockOwnedList(ctx, qtx, listUuid, userUuid)
+	if err != nil {
+		return err
+	}
+
+	targetAttached, err := qtx.CheckStatusOnList(ctx, queries.CheckStatusOnListParams{
+		ListUuid:   listUuid,
+		StatusUuid: targetUuid,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error checking list statuses")
+	}
+
+	if targetAttached == 0 {
+		// Put the target column where the deleted one was, or at the end if the deleted
+		// status only had list items on this list
+		rank, err := qtx.GetListStatusRank(ctx, queries.GetListStatusRankParams{
+			ListUuid:   listUuid,
+			StatusUuid: statusUuid,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return types.NewAPIError(http.StatusInternalServerError, "error fetching list statuses")
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			rank, err = nextListStatusRank(ctx, qtx, listUuid)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = qtx.AddListStatus(ctx, queries.AddListStatusParams{
+			ListUuid:   listUuid,
+			StatusUuid: targetUuid,
+			Rank:       rank,
+		})
+		if err != nil {
+			return types.NewAPIError(http.StatusInternalServerError, "error adding status to list")
+		}
+	}
+
+	listItemUuids, err := qtx.GetListItemUuidsForStatus(ctx, queries.GetListItemUuidsForStatusParams{
+		ListUuid:   listUuid,
+		StatusUuid: statusUuid,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error fetching list items")
+	}
+
+	if len(listItemUuids) == 0 {
+		return nil
+	}
+
+	last, err := qtx.GetLastRankForStatus(ctx, queries.GetLastRankForStatusParams{
+		ListUuid:   listUuid,
+		StatusUuid: targetUuid,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return types.NewAPIError(http.StatusInternalServerError, "error fetching list items")
+	}
+
+	// Append the moved items after the target column, keeping their current order
+	ranks := make([]string, len(listItemUuids))
+	for i := range ranks {
+		ranks[i], err = helpers.RankBetween(last, "")
+		if err != nil {
+			return types.NewAPIError(http.StatusInternalServerError, "error calculating rank")
+		}
+		last = ranks[i]
+	}
+
+	err = qtx.ReassignListItems(ctx, queries.ReassignListItemsParams{
+		StatusUuid:    targetUuid,
+		ListItemUuids: listItemUuids,
+		Ranks:         ranks,
+	})
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error moving list items")
+	}
+
+	return nil
+}
+
+// getOwnedStatus fetches a status and checks that it belongs to the given user
+func getOwnedStatus(ctx context.Context, q *queries.Queries, statusUuid pgtype.UUID, userUuid string) (*queries.GetStatusRow, error) {
+	status, err := q.GetStatus(ctx, statusUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting status by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "status not found")
+	}
+
+	if err := authorize(userActor(userUuid), ownerOnly, resourceStatus, ownership{owner: status.UserUuid}); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
@@ -7,15 +7,20 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"net/http"
+	"slices"
 )
 
 type UsersService struct {
-	q *queries.Queries
+	q            *queries.Queries
+	db           *pgxpool.Pool
+	verification *EmailVerificationService
 }
 
-func NewUsersService(q *queries.Queries) *UsersService {
-	return &UsersService{q: q}
+func NewUsersService(q *queries.Queries, db *pgxpool.Pool, verification *EmailVerificationService) *UsersService {
+	return &UsersService{q: q, db: db, verification: verification}
 }
 
 // GetAllUsers returns the total number of users and a list of all users
@@ -84,13 +89,18 @@ func (s *UsersService) GetUserByUuid(ctx context.Context, uuid string) (*queries
 	return &user, nil
 }
 
-// UpdateUser updates user details
-func (s *UsersService) UpdateUser(ctx context.Context, uuid string, user types.UpdateUserRequest) (*queries.UpdateUserDetailsRow, error) {
+// UpdateUser updates user details. Users can update their own details, and users with the users:edit
+// permission can update anyone's
+func (s *UsersService) UpdateUser(ctx context.Context, uuid string, actor types.Actor, user types.UpdateUserRequest) (*queries.UpdateUserDetailsRow, error) {
 	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := authorizeResource(ctx, s.q, actor, ownerOrPermission(types.PermissionUsersEdit), resourceUser, *pgUuid); err != nil {
+		return nil, err
+	}
+
 	existingUser, err := s.q.GetUserByUuid(ctx, *pgUuid)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
@@ -100,6 +110,22 @@ func (s *UsersService) UpdateUser(ctx context.Context, uuid string, user types.U
 		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
 	}
 
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	var verificationToken *string
+	if user.NewEmail != nil {
+		verificationToken, err = s.updateEmail(ctx, qtx, *pgUuid, *user.NewEmail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	params := queries.UpdateUserDetailsParams{
 		UserUuid: *pgUuid,
 	}
@@ -116,26 +142,107 @@ func (s *UsersService) UpdateUser(ctx context.Context, uuid string, user types.U
 	helpers.AssignPgtypeText(&params.NewBio, user.NewBio)
 
 	// Update the user details
-	userRow, err := s.q.UpdateUserDetails(ctx, params)
+	userRow, err := qtx.UpdateUserDetails(ctx, params)
 	if err != nil {
 		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating user")
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
+	// The link is only sent once the new address has been saved
+	if verificationToken != nil {
+		s.verification.mailVerification(userRow.Username, *user.NewEmail, *verificationToken)
+	}
+
 	return &userRow, nil
 }
 
-// DeleteUser deletes a user from the database
-func (s *UsersService) DeleteUser(ctx context.Context, uuid string) error {
+// updateEmail changes a user's email. The new address is unverified until the user follows the link sent to
+// it, and the token for that link is returned. No token is returned if the email hasn't changed
+func (s *UsersService) updateEmail(ctx context.Context, qtx *queries.Queries, userUuid pgtype.UUID, email string) (*string, error) {
+	existingUser, err := qtx.GetUserEmail(ctx, userUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if existingUser.Email == email {
+		return nil, nil
+	}
+
+	emailUser, err := qtx.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error checking email")
+	}
+
+	if err == nil && emailUser.Uuid != userUuid {
+		return nil, types.NewAPIError(http.StatusBadRequest, "email already in use")
+	}
+
+	err = qtx.UpdateUserEmail(ctx, queries.UpdateUserEmailParams{
+		Email:    email,
+		UserUuid: userUuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating email")
+	}
+
+	return s.verification.addVerificationToken(ctx, qtx, userUuid, email)
+}
+
+// DeleteUser deletes a user from the database. Users can delete their own account, and users with the
+// users:delete permission can delete anyone's
+func (s *UsersService) DeleteUser(ctx context.Context, uuid string, actor types.Actor) error {
 	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
 	if err != nil {
 		return err
 	}
 
-	err = s.q.DeleteUser(ctx, *pgUuid)
+	if err := authorizeResource(ctx, s.q, actor, ownerOrPermission(types.PermissionUsersDelete), resourceUser, *pgUuid); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	// Locking the admin role stops the last two admins from deleting each other at the same time
+	adminRoleId, err := lockRole(ctx, qtx, types.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	roles, err := qtx.GetRolesForUser(ctx, *pgUuid)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error getting roles")
+	}
+
+	err = qtx.DeleteUser(ctx, *pgUuid)
 	if err != nil {
 		return types.NewAPIError(http.StatusInternalServerError, "error deleting user")
 	}
 
+	// As with removing the admin role, the last admin can't be deleted
+	if slices.Contains(roles, types.RoleAdmin) {
+		admins, err := qtx.GetUserCountForRole(ctx, adminRoleId)
+		if err != nil {
+			return types.NewAPIError(http.StatusInternalServerError, "error counting admins")
+		}
+
+		if admins == 0 {
+			return types.NewAPIError(http.StatusConflict, "the last admin can't be deleted")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error committing transaction")
+	}
+
 	return nil
 }
 
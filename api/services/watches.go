@@ -0,0 +1,416 @@
+package services
+
+import (
+	queries "codeberg.org/sporiff/eigakanban/db/sqlc"
+	"codeberg.org/sporiff/eigakanban/helpers"
+	"codeberg.org/sporiff/eigakanban/types"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"net/http"
+	"strings"
+)
+
+type WatchesService struct {
+	q          *queries.Queries
+	db         *pgxpool.Pool
+	rebalancer *RankRebalancer
+}
+
+func NewWatchesService(q *queries.Queries, db *pgxpool.Pool, rebalancer *RankRebalancer) *WatchesService {
+	return &WatchesService{q: q, db: db, rebalancer: rebalancer}
+}
+
+// LogWatch adds a diary entry for the authenticated user. If a list and status are given, the item's
+// card on that list is moved to the end of the status column in the same transaction
+func (s *WatchesService) LogWatch(ctx context.Context, userUuid string, request types.LogWatchRequest) (*types.WatchLoggedResponse, error) {
+	pgItemUuid, err := helpers.ValidateAndConvertUUID(request.ItemUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateRating(request.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error starting transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	_, err = qtx.GetItemByUuid(ctx, *pgItemUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting item by uuid")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "item not found")
+	}
+
+	reviewUuid, err := checkLinkedReview(ctx, qtx, request.ReviewUUID, *pgItemUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	watchUuid, err := qtx.AddWatch(ctx, queries.AddWatchParams{
+		UserUuid:    *pgUserUuid,
+		ItemUuid:    *pgItemUuid,
+		ReviewUuid:  reviewUuid,
+		WatchedDate: helpers.MakePgDate(request.WatchedDate),
+		Rewatch:     request.Rewatch,
+		Rating:      helpers.MakePgNumeric(request.Rating),
+		Location:    helpers.MakePgString(strings.TrimSpace(request.Location)),
+		Format:      helpers.MakePgString(strings.TrimSpace(request.Format)),
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error logging watch")
+	}
+
+	response := &types.WatchLoggedResponse{}
+
+	var pgListUuid, pgStatusUuid *pgtype.UUID
+	var rank string
+	if request.ListUUID != "" {
+		pgListUuid, err = helpers.ValidateAndConvertUUID(request.ListUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		pgStatusUuid, err = helpers.ValidateAndConvertUUID(request.StatusUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		response.ListItem, rank, err = moveCardToStatus(ctx, qtx, *pgListUuid, *pgItemUuid, *pgStatusUuid, userUuid, request.IgnoreWipLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	watch, err := getWatch(ctx, qtx, watchUuid)
+	if err != nil {
+		return nil, err
+	}
+	response.Watch = *watch
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error logging watch")
+	}
+
+	if len(rank) > helpers.MaxRankLength {
+		s.rebalancer.Schedule(*pgListUuid, *pgStatusUuid)
+	}
+
+	return response, nil
+}
+
+// GetWatch returns a single diary entry by UUID
+func (s *WatchesService) GetWatch(ctx context.Context, uuid string) (*types.WatchResponse, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return getWatch(ctx, s.q, *pgUuid)
+}
+
+// GetWatchesForUser returns a user's diary as a paginated list, most recently watched first
+func (s *WatchesService) GetWatchesForUser(ctx context.Context, userUuid string, pagination *types.Pagination) (*types.PaginatedWatchesResponse, error) {
+	pgUserUuid, err := helpers.ValidateAndConvertUUID(userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.q.GetUserByUuid(ctx, *pgUserUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting user")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "user not found")
+	}
+
+	total, err := s.q.GetWatchesCountForUser(ctx, *pgUserUuid)
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching watch count")
+	}
+
+	pagination.Total = total
+
+	if total == 0 {
+		response := &types.PaginatedWatchesResponse{
+			Pagination: *pagination,
+			Watches:    []types.WatchResponse{},
+		}
+		return response, nil
+	}
+
+	rows, err := s.q.GetWatchesForUser(ctx, queries.GetWatchesForUserParams{
+		UserUuid: *pgUserUuid,
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error fetching watches")
+	}
+
+	watches := make([]types.WatchResponse, len(rows))
+	for i, row := range rows {
+		watches[i] = watchResponse(queries.GetWatchRow(row))
+	}
+
+	response := types.PaginatedWatchesResponse{
+		Pagination: *pagination,
+		Watches:    watches,
+	}
+
+	return &response, nil
+}
+
+// UpdateWatch replaces the details of a diary entry belonging to the authenticated user
+func (s *WatchesService) UpdateWatch(ctx context.Context, uuid, userUuid string, request types.UpdateWatchRequest) (*types.WatchResponse, error) {
+	watch, err := s.getOwnWatch(ctx, uuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateRating(request.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewUuid, err := checkLinkedReview(ctx, s.q, request.ReviewUUID, watch.ItemUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.q.UpdateWatch(ctx, queries.UpdateWatchParams{
+		ReviewUuid:  reviewUuid,
+		WatchedDate: helpers.MakePgDate(request.WatchedDate),
+		Rewatch:     request.Rewatch,
+		Rating:      helpers.MakePgNumeric(request.Rating),
+		Location:    helpers.MakePgString(strings.TrimSpace(request.Location)),
+		Format:      helpers.MakePgString(strings.TrimSpace(request.Format)),
+		WatchUuid:   watch.Uuid,
+	})
+	if err != nil {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error updating watch")
+	}
+
+	return getWatch(ctx, s.q, watch.Uuid)
+}
+
+// DeleteWatch deletes a diary entry belonging to the authenticated user
+func (s *WatchesService) DeleteWatch(ctx context.Context, uuid, userUuid string) error {
+	watch, err := s.getOwnWatch(ctx, uuid, userUuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.q.DeleteWatch(ctx, watch.Uuid)
+	if err != nil {
+		return types.NewAPIError(http.StatusInternalServerError, "error deleting watch")
+	}
+
+	return nil
+}
+
+// getOwnWatch fetches a diary entry and checks that it belongs to the given user
+func (s *WatchesService) getOwnWatch(ctx context.Context, uuid, userUuid string) (*queries.GetWatchRow, error) {
+	pgUuid, err := helpers.ValidateAndConvertUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	watch, err := s.q.GetWatch(ctx, *pgUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting watch")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "watch not found")
+	}
+
+	if err := authorize(userActor(userUuid), ownerOnly, resourceWatch, ownership{owner: watch.UserUuid}); err != nil {
+		return nil, err
+	}
+
+	return &watch, nil
+}
+
+// checkLinkedReview checks that a review linked to a diary entry is the user's own review of the
+// same item. An empty review UUID unlinks the review
+func checkLinkedReview(ctx context.Context, q *queries.Queries, reviewUuid string, itemUuid pgtype.UUID, userUuid string) (pgtype.UUID, error) {
+	if reviewUuid == "" {
+		return pgtype.UUID{}, nil
+	}
+
+	pgReviewUuid, err := helpers.ValidateAndConvertUUID(reviewUuid)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	review, err := q.GetReview(ctx, *pgReviewUuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, types.NewAPIError(http.StatusInternalServerError, "error getting review")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgtype.UUID{}, types.NewAPIError(http.StatusNotFound, "review not found")
+	}
+
+	// Reviews are public, but only the user's own can be linked to their diary
+	err = authorize(userActor(userUuid), ownerOnly, resourceReview, ownership{owner: review.UserUuid})
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	if review.ItemUuid != itemUuid {
+		return pgtype.UUID{}, types.NewAPIError(http.StatusBadRequest, "review_uuid must be a review of this item")
+	}
+
+	return review.Uuid, nil
+}
+
+// moveCardToStatus moves an item's card on a list owned by the user to the end of a status column,
+// adding the item to the list if it isn't on it yet. A card already in the status is left where it is.
+// The rank is returned so that the caller can schedule a rebalance once the transaction commits
+func moveCardToStatus(ctx context.Context, qtx *queries.Queries, listUuid, itemUuid, statusUuid pgtype.UUID, userUuid string, ignoreWipLimit bool) (*types.ListItemsResponse, string, error) {
+	err := lockOwnedList(ctx, qtx, listUuid, userUuid)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = checkStatusOnList(ctx, qtx, listUuid, statusUuid)
+	if err != nil {
+		return nil, "", err
+	}
+
+	card, err := qtx.GetListItemForItem(ctx, queries.GetListItemForItemParams{
+		ListUuid: listUuid,
+		ItemUuid: itemUuid,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, "", types.NewAPIError(http.StatusInternalServerError, "error checking list items")
+	}
+
+	onList := err == nil
+	listItemUuid := card.ListItemUuid
+
+	var rank string
+	if !onList || card.StatusUuid != statusUuid {
+		if !ignoreWipLimit {
+			err = checkWipLimit(ctx, qtx, listUuid, statusUuid)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		last, err := qtx.GetLastRankForStatus(ctx, queries.GetLastRankForStatusParams{
+			ListUuid:   listUuid,
+			StatusUuid: statusUuid,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, "", types.NewAPIError(http.StatusInternalServerError, "error fetching list item ranks")
+		}
+
+		rank, err = helpers.RankBetween(last, "")
+		if err != nil {
+			return nil, "", types.NewAPIError(http.StatusInternalServerError, "error calculating list item rank")
+		}
+
+		if onList {
+			_, err = qtx.MoveItemInList(ctx, queries.MoveItemInListParams{
+				StatusUuid:   statusUuid,
+				Rank:         rank,
+				ListItemUuid: listItemUuid,
+			})
+			if err != nil {
+				return nil, "", types.NewAPIError(http.StatusInternalServerError, "error moving list item")
+			}
+		} else {
+			added, err := qtx.AddItemToList(ctx, queries.AddItemToListParams{
+				ListUuid:   listUuid,
+				ItemUuid:   itemUuid,
+				StatusUuid: statusUuid,
+				Rank:       rank,
+			})
+			if err != nil {
+				return nil, "", types.NewAPIError(http.StatusInternalServerError, "error adding item to list")
+			}
+			listItemUuid = added.Uuid
+		}
+	}
+
+	item, err := qtx.GetListItemByUuid(ctx, listItemUuid)
+	if err != nil {
+		return nil, "", types.NewAPIError(http.StatusInternalServerError, "error getting list item by uuid")
+	}
+
+	response := types.ListItemsResponse{
+		UUID:     item.ListItemUuid.String(),
+		ListUUID: item.ListUuid.String(),
+		ItemUUID: item.ItemUuid.String(),
+		Status:   item.Label.String,
+		Rank:     item.Rank,
+		Position: item.Position,
+	}
+
+	return &response, rank, nil
+}
+
+// getWatch fetches a single diary entry by UUID
+func getWatch(ctx context.Context, q *queries.Queries, uuid pgtype.UUID) (*types.WatchResponse, error) {
+	watch, err := q.GetWatch(ctx, uuid)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusInternalServerError, "error getting watch")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NewAPIError(http.StatusNotFound, "watch not found")
+	}
+
+	response := watchResponse(watch)
+	return &response, nil
+}
+
+// watchResponse converts a diary entry row to its API representation
+func watchResponse(row queries.GetWatchRow) types.WatchResponse {
+	var reviewUuid *string
+	if row.ReviewUuid.Valid {
+		uuid := row.ReviewUuid.String()
+		reviewUuid = &uuid
+	}
+
+	var watchedDate string
+	if date := helpers.PgDatePointer(row.WatchedDate); date != nil {
+		watchedDate = *date
+	}
+
+	return types.WatchResponse{
+		UUID:        row.Uuid.String(),
+		ItemUUID:    row.ItemUuid.String(),
+		ItemTitle:   row.ItemTitle,
+		UserUUID:    row.UserUuid.String(),
+		Username:    row.Username,
+		WatchedDate: watchedDate,
+		Rewatch:     row.Rewatch,
+		Rating:      helpers.PgNumericPointer(row.Rating),
+		ReviewUUID:  reviewUuid,
+		Location:    helpers.PgTextPointer(row.Location),
+		Format:      helpers.PgTextPointer(row.Format),
+		CreatedDate: row.CreatedDate.Time,
+		UpdatedDate: helpers.PgTimestamptzPointer(row.UpdatedDate),
+	}
+}
@@ -0,0 +1,156 @@
+package types
+
+import "time"
+
+// AccountExportVersion is the version of the account export schema written by this server. Imports
+// reject files with a newer version, and the version is bumped whenever a field changes meaning
+const AccountExportVersion = 1
+
+// AccountExport represents a full backup of everything a user owns
+//
+//	@Description	A versioned backup of a user's profile, items, statuses, boards, reviews and watches.
+//	@Description	Every record keeps its UUID so that references between records survive an import.
+//	@Description	Dates are RFC 3339 timestamps, except release_date and watched_date which are YYYY-MM-DD
+type AccountExport struct {
+	Version    int                   `json:"version" example:"1" binding:"required"`
+	ExportedAt time.Time             `json:"exported_at" example:"2024-01-01T00:00:00Z"`
+	Profile    AccountExportProfile  `json:"profile"`
+	Items      []AccountExportItem   `json:"items"`
+	Statuses   []AccountExportStatus `json:"statuses"`
+	Lists      []AccountExportList   `json:"lists"`
+	Reviews    []AccountExportReview `json:"reviews"`
+	Watches    []AccountExportWatch  `json:"watches"`
+}
+
+// AccountExportProfile represents the profile of an exported user
+//
+//	@Description	the exported user's profile. Only full_name and bio are restored on import
+type AccountExportProfile struct {
+	UUID        string    `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Username    string    `json:"username" example:"eiga_fan"`
+	Email       string    `json:"email" example:"eiga@example.com"`
+	FullName    *string   `json:"full_name" example:"Eiga Fan"`
+	Bio         *string   `json:"bio" example:"I watch a lot of films"`
+	CreatedDate time.Time `json:"created_date" example:"2024-01-01T00:00:00Z"`
+}
+
+// AccountExportItem represents an item referenced by a user's lists, reviews or watches
+//
+//	@Description	an item referenced by the export. On import, items with a tmdb_id are imported from TMDB and the
+//	@Description	other fields are ignored. Items without one are only matched to an existing item with the same uuid
+type AccountExportItem struct {
+	UUID             string    `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Title            string    `json:"title" example:"Fight Club"`
+	TmdbID           *int64    `json:"tmdb_id" example:"550"`
+	ImdbID           *string   `json:"imdb_id" example:"tt0137523"`
+	ReleaseDate      *string   `json:"release_date" example:"1999-10-15"`
+	Runtime          *int32    `json:"runtime" example:"139"`
+	Overview         *string   `json:"overview" example:"A ticking-time-bomb insomniac..."`
+	OriginalLanguage *string   `json:"original_language" example:"en"`
+	PosterPath       *string   `json:"poster_path" example:"/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"`
+	BackdropPath     *string   `json:"backdrop_path" example:"/hZkgoQYus5vegHoetLkCJzb17zJ.jpg"`
+	Genres           []string  `json:"genres" example:"Drama"`
+	CreatedDate      time.Time `json:"created_date" example:"2024-01-01T00:00:00Z"`
+}
+
+// AccountExportStatus represents one of a user's statuses
+//
+//	@Description	a status owned by the user. Statuses are matched to existing statuses by label on import
+type AccountExportStatus struct {
+	UUID        string    `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Label       string    `json:"label" example:"backlog"`
+	CreatedDate time.Time `json:"created_date" example:"2024-01-01T00:00:00Z"`
+}
+
+// AccountExportList represents one of a user's boards with its columns and cards
+//
+//	@Description	a list with its status columns and cards in rank order
+type AccountExportList struct {
+	UUID        string                    `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Name        string                    `json:"name" example:"Watchlist"`
+	CreatedDate time.Time                 `json:"created_date" example:"2024-01-01T00:00:00Z"`
+	Statuses    []AccountExportListStatus `json:"statuses"`
+	Cards       []AccountExportCard       `json:"cards"`
+}
+
+// AccountExportListStatus represents a status column on an exported list
+//
+//	@Description	a status column on a list. wip_limit is null when the column has no WIP limit
+type AccountExportListStatus struct {
+	StatusUUID string `json:"status_uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Rank       string `json:"rank" example:"i"`
+	WipLimit   *int32 `json:"wip_limit" example:"5"`
+}
+
+// AccountExportCard represents a list item on an exported list
+//
+//	@Description	a card on a list. status_uuid is null when the card has no status
+type AccountExportCard struct {
+	UUID        string    `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	ItemUUID    string    `json:"item_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	StatusUUID  *string   `json:"status_uuid" example:"00000000-0000-0000-0000-000000000002"`
+	Rank        string    `json:"rank" example:"i"`
+	CreatedDate time.Time `json:"created_date" example:"2024-01-01T00:00:00Z"`
+}
+
+// AccountExportReview represents one of a user's reviews
+//
+//	@Description	a review written by the user
+type AccountExportReview struct {
+	UUID        string     `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	ItemUUID    string     `json:"item_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	Content     string     `json:"content" example:"A masterpiece"`
+	Rating      *float64   `json:"rating" example:"4.5"`
+	CreatedDate time.Time  `json:"created_date" example:"2024-01-01T00:00:00Z"`
+	UpdatedDate *time.Time `json:"updated_date" example:"2024-01-02T00:00:00Z"`
+}
+
+// AccountExportWatch represents one of a user's diary entries
+//
+//	@Description	a diary entry logged by the user
+type AccountExportWatch struct {
+	UUID        string     `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	ItemUUID    string     `json:"item_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	ReviewUUID  *string    `json:"review_uuid" example:"00000000-0000-0000-0000-000000000002"`
+	WatchedDate string     `json:"watched_date" example:"2024-01-01"`
+	Rewatch     bool       `json:"rewatch" example:"false"`
+	Rating      *float64   `json:"rating" example:"4.5"`
+	Location    *string    `json:"location" example:"Prince Charles Cinema"`
+	Format      *string    `json:"format" example:"35mm"`
+	CreatedDate time.Time  `json:"created_date" example:"2024-01-01T00:00:00Z"`
+	UpdatedDate *time.Time `json:"updated_date" example:"2024-01-02T00:00:00Z"`
+}
+
+// AccountImportReport summarises an import of an account export
+//
+//	@Description	a summary of an account import. Records whose UUID was already taken by someone else are
+//	@Description	created with a new UUID and listed in remapped. Items that couldn't be matched are listed in unmatched_items
+type AccountImportReport struct {
+	ItemsCreated    int             `json:"items_created" example:"10"`
+	StatusesCreated int             `json:"statuses_created" example:"3"`
+	ListsCreated    int             `json:"lists_created" example:"1"`
+	CardsCreated    int             `json:"cards_created" example:"10"`
+	ReviewsCreated  int             `json:"reviews_created" example:"4"`
+	WatchesCreated  int             `json:"watches_created" example:"12"`
+	Skipped         int             `json:"skipped" example:"0"`
+	Remapped        []RemappedUUID  `json:"remapped"`
+	UnmatchedItems  []UnmatchedItem `json:"unmatched_items"`
+}
+
+// RemappedUUID represents a record that was given a new UUID on import
+//
+//	@Description	a record that was imported under a new UUID
+type RemappedUUID struct {
+	Type    string `json:"type" example:"list"`
+	OldUUID string `json:"old_uuid" example:"00000000-0000-0000-0000-000000000000"`
+	NewUUID string `json:"new_uuid" example:"00000000-0000-0000-0000-000000000001"`
+}
+
+// UnmatchedItem represents an exported item that couldn't be imported
+//
+//	@Description	an exported item that couldn't be matched to an item. Cards, reviews and watches of the item are skipped
+type UnmatchedItem struct {
+	UUID   string `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Title  string `json:"title" example:"Fight Club"`
+	Reason string `json:"reason" example:"item has no TMDB ID"`
+}
@@ -8,16 +8,14 @@ type AuthenticatedUserResponse struct {
 	AccessToken  string `json:"access_token" example:"00000000-0000-0000-0000-000000000000"`
 	RefreshToken string `json:"refresh_token" example:"00000000-0000-0000-0000-000000000000"`
 	ExpiryDate   string `json:"expiry_date" example:"2025-02-15 11:59:01.837871 +0100 CET m=+3603.614509085"`
-	SuperUser    bool
 }
 
-func NewAuthenticateUserResponse(uuid, accessToken, refreshToken, expiryDate string, superUser bool) *AuthenticatedUserResponse {
+func NewAuthenticateUserResponse(uuid, accessToken, refreshToken, expiryDate string) *AuthenticatedUserResponse {
 	return &AuthenticatedUserResponse{
 		Uuid:         uuid,
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiryDate:   expiryDate,
-		SuperUser:    superUser,
 	}
 }
 
@@ -33,14 +31,20 @@ func NewAccessTokenResponse(accessToken, expiryDate string) *AccessTokenResponse
 	}
 }
 
-// TokenClaims represents the claims stored in the JWT token
+// TokenClaims represents the claims stored in the JWT token. The user's UUID is the subject. Permissions
+// aren't stored in the token, so that revoking them takes effect before the token expires
 type TokenClaims struct {
-	UserUuid  string // The user's UUID
-	SuperUser bool   // Whether the user is a superuser
-	ExpiresAt int64  // The expiry date of the token as a UNIX timestamp
+	SessionID string `json:"sid"` // The session the token was issued for
 	jwt.RegisteredClaims
 }
 
+// Actor is the user a request is made by, as identified by their access token, and the permissions their
+// roles grant
+type Actor struct {
+	UserUuid    string
+	Permissions []string
+}
+
 // RegisterUserRequest represents the request body for registering a user
 //
 //	@Description	A request body for registering a new user
@@ -53,11 +57,13 @@ type RegisterUserRequest struct {
 // LoginUserRequest represents the request body for logging in a user
 //
 //	@Description	request body for a login request.
-//	@Description	either email or username must be provided
+//	@Description	either email or username must be provided.
+//	@Description	device_name optionally names the session so it can be recognised in the list of sessions
 type LoginUserRequest struct {
-	Email    string `json:"email" example:"test@test.com"`
-	Username string `json:"username" example:"test"`
-	Password string `json:"password" example:"password" binding:"required"`
+	Email      string `json:"email" example:"test@test.com"`
+	Username   string `json:"username" example:"test"`
+	Password   string `json:"password" example:"password" binding:"required"`
+	DeviceName string `json:"device_name" example:"Living room laptop"`
 }
 
 // TokenResponse represents the request body for receiving access and refresh tokens
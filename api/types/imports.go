@@ -0,0 +1,58 @@
+package types
+
+import "time"
+
+// ImportReport summarises an import of a user's history from another service
+//
+//	@Description	a summary of an import. In a dry run nothing is written and the created counts are the
+//	@Description	number of matched rows that would be imported, before duplicates are skipped
+type ImportReport struct {
+	DryRun         bool           `json:"dry_run" example:"false"`
+	Rows           int            `json:"rows" example:"120"`
+	Matched        int            `json:"matched" example:"117"`
+	WatchesCreated int            `json:"watches_created" example:"80"`
+	ReviewsCreated int            `json:"reviews_created" example:"12"`
+	RatingsCreated int            `json:"ratings_created" example:"20"`
+	WatchlistAdded int            `json:"watchlist_added" example:"5"`
+	Skipped        int            `json:"skipped" example:"0"`
+	Unmatched      []UnmatchedRow `json:"unmatched"`
+}
+
+// UnmatchedRow represents a row of an import that couldn't be imported
+//
+//	@Description	a row of an import file that couldn't be matched to an item or imported
+type UnmatchedRow struct {
+	File   string `json:"file" example:"diary.csv"`
+	Line   int    `json:"line" example:"14"`
+	Name   string `json:"name" example:"Fight Club"`
+	Year   string `json:"year" example:"1999"`
+	Reason string `json:"reason" example:"no match found"`
+}
+
+// ImportJobResponse represents an import that runs in the background
+//
+//	@Description	an import that runs in the background. state is pending, running, completed or failed.
+//	@Description	A failed job keeps the rows it has already imported and can be resumed. The report counts the
+//	@Description	rows processed so far, and unmatched rows are only included when a single job is fetched
+type ImportJobResponse struct {
+	UUID         string       `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Source       string       `json:"source" example:"imdb"`
+	State        string       `json:"state" example:"running"`
+	ListUUID     *string      `json:"list_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	StatusUUID   *string      `json:"status_uuid" example:"00000000-0000-0000-0000-000000000002"`
+	Total        int64        `json:"total" example:"250"`
+	Processed    int64        `json:"processed" example:"120"`
+	Error        *string      `json:"error" example:"failed to find movie"`
+	CreatedDate  time.Time    `json:"created_date" example:"2024-01-01T00:00:00Z"`
+	StartedDate  *time.Time   `json:"started_date" example:"2024-01-01T00:00:01Z"`
+	FinishedDate *time.Time   `json:"finished_date" example:"2024-01-01T00:05:00Z"`
+	Report       ImportReport `json:"report"`
+}
+
+// PaginatedImportJobsResponse represents a paginated list of import jobs
+//
+//	@Description	a paginated list of import jobs, most recent first
+type PaginatedImportJobsResponse struct {
+	Pagination Pagination          `json:"pagination"`
+	Jobs       []ImportJobResponse `json:"jobs"`
+}
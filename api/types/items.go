@@ -1,8 +1,13 @@
 package types
 
+import "time"
+
 type ItemsResponse struct {
-	UUID  string `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
-	Title string `json:"title" example:"Item title"`
+	UUID        string  `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Title       string  `json:"title" example:"Item title"`
+	TmdbID      *int64  `json:"tmdb_id" example:"550"`
+	ReleaseDate *string `json:"release_date" example:"1999-10-15"`
+	PosterPath  *string `json:"poster_path" example:"/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"`
 }
 
 // PaginatedItemsResponse represents a response containing a list of items
@@ -32,3 +37,26 @@ type UpdateItemRequest struct {
 type ItemDeletedResponse struct {
 	Message string `json:"success" example:"Item deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"`
 }
+
+// ItemDetailsResponse represents an item with its full metadata
+//
+//	@Description	an item with the metadata imported from TMDB. Metadata fields are null for items added by title.
+//	@Description	average_rating is null until the item has been rated
+type ItemDetailsResponse struct {
+	UUID                string     `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Title               string     `json:"title" example:"Fight Club"`
+	TmdbID              *int64     `json:"tmdb_id" example:"550"`
+	ImdbID              *string    `json:"imdb_id" example:"tt0137523"`
+	ReleaseDate         *string    `json:"release_date" example:"1999-10-15"`
+	Runtime             *int32     `json:"runtime" example:"139"`
+	Overview            *string    `json:"overview" example:"A ticking-time-bomb insomniac and a slippery soap salesman channel primal male aggression into a shocking new form of therapy."`
+	OriginalLanguage    *string    `json:"original_language" example:"en"`
+	PosterPath          *string    `json:"poster_path" example:"/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"`
+	BackdropPath        *string    `json:"backdrop_path" example:"/hZkgoQYus5vegHoetLkCJzb17zJ.jpg"`
+	Genres              []string   `json:"genres" example:"Drama,Thriller"`
+	AverageRating       *float64   `json:"average_rating" example:"4.5"`
+	RatingCount         int64      `json:"rating_count" example:"12"`
+	ReviewCount         int64      `json:"review_count" example:"15"`
+	MetadataRefreshedAt *time.Time `json:"metadata_refreshed_at" example:"2025-02-15T11:59:01Z"`
+	CreatedDate         time.Time  `json:"created_date" example:"2025-02-15T11:59:01Z"`
+}
@@ -5,6 +5,7 @@ type ListItemsResponse struct {
 	ListUUID string `json:"list_uuid" example:"00000000-0000-0000-0000-000000000001"`
 	ItemUUID string `json:"item_uuid" example:"00000000-0000-0000-0000-000000000002"`
 	Status   string `json:"status" example:"Backlog"`
+	Rank     string `json:"rank" example:"0001"`
 	Position int32  `json:"position" example:"0"`
 }
 
@@ -12,3 +13,36 @@ type PaginatedListItemsResponse struct {
 	Pagination Pagination          `json:"pagination"`
 	ListItems  []ListItemsResponse `json:"list_items"`
 }
+
+// AddListItemRequest represents the request body for adding an item to a list
+//
+//	@Description	a request body for adding an item to a list.
+//	@Description	position is the zero-based index in the status column.
+//	@Description	the item is added to the end of the status column if no position is provided.
+//	@Description	set ignore_wip_limit to add the item even if the status column is at its WIP limit
+type AddListItemRequest struct {
+	ItemUUID       string `json:"item_uuid" example:"00000000-0000-0000-0000-000000000002" binding:"required"`
+	StatusUUID     string `json:"status_uuid" example:"00000000-0000-0000-0000-000000000003" binding:"required"`
+	Position       *int32 `json:"position" example:"0" binding:"omitempty,min=0"`
+	IgnoreWipLimit bool   `json:"ignore_wip_limit" example:"false"`
+}
+
+// MoveListItemRequest represents the request body for moving a list item
+//
+//	@Description	a request body for moving a list item to a new status and position.
+//	@Description	position is the zero-based index in the status column.
+//	@Description	set ignore_wip_limit to move the item even if the new status column is at its WIP limit
+type MoveListItemRequest struct {
+	StatusUUID     string `json:"status_uuid" example:"00000000-0000-0000-0000-000000000003" binding:"required"`
+	Position       *int32 `json:"position" example:"0" binding:"required,min=0"`
+	IgnoreWipLimit bool   `json:"ignore_wip_limit" example:"false"`
+}
+
+// ListItemPlacementResponse represents a list item and the items either side of it after a change
+//
+//	@Description	the affected list item and its neighbours in the status column
+type ListItemPlacementResponse struct {
+	ListItem *ListItemsResponse `json:"list_item,omitempty"`
+	Previous *ListItemsResponse `json:"previous"`
+	Next     *ListItemsResponse `json:"next"`
+}
@@ -0,0 +1,92 @@
+package types
+
+import "time"
+
+// ListsResponse represents a list
+// @Description list details
+type ListsResponse struct {
+	UUID string `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Name string `json:"name" example:"Watchlist"`
+}
+
+// PaginatedListsResponse represents a paginated list of lists
+// @Description a paginated list of lists
+type PaginatedListsResponse struct {
+	Pagination Pagination      `json:"pagination"`
+	Lists      []ListsResponse `json:"lists"`
+}
+
+// AddListRequest represents the request body for creating a list
+// @Description A request body for adding a new list
+type AddListRequest struct {
+	Name string `json:"name" example:"Watchlist" binding:"required"`
+}
+
+// UpdateListRequest represents the request body for updating a list
+// @Description a request body for updating a list
+type UpdateListRequest struct {
+	Name string `json:"name" example:"Watchlist" binding:"required"`
+}
+
+// ListDeletedResponse represents a success message for a list deletion
+//
+//	@Description	A success message confirming the list was deleted
+type ListDeletedResponse struct {
+	Message string `json:"success" example:"list deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"`
+}
+
+// BoardResponse represents a list rendered as a kanban board
+// @Description a list with its statuses as columns and its items as cards
+type BoardResponse struct {
+	UUID    string                `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Name    string                `json:"name" example:"Watchlist"`
+	Columns []BoardColumnResponse `json:"columns"`
+}
+
+// BoardColumnResponse represents a single status column on a board
+// @Description a status column containing cards ordered by position.
+// @Description wip_limit is null when the column has no WIP limit
+type BoardColumnResponse struct {
+	UUID       string              `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	StatusUUID string              `json:"status_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	Label      string              `json:"label" example:"backlog"`
+	WipLimit   *int32              `json:"wip_limit" example:"5"`
+	CardCount  int32               `json:"card_count" example:"3"`
+	AtLimit    bool                `json:"at_limit" example:"false"`
+	OverLimit  bool                `json:"over_limit" example:"false"`
+	Cards      []BoardCardResponse `json:"cards"`
+}
+
+// BoardCardResponse represents a list item shown as a card on a board
+// @Description a list item enriched with item details
+type BoardCardResponse struct {
+	UUID            string    `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	ItemUUID        string    `json:"item_uuid" example:"00000000-0000-0000-0000-000000000002"`
+	Title           string    `json:"title" example:"Item title"`
+	TmdbID          *int64    `json:"tmdb_id" example:"550"`
+	ReleaseDate     *string   `json:"release_date" example:"1999-10-15"`
+	Runtime         *int32    `json:"runtime" example:"139"`
+	PosterPath      *string   `json:"poster_path" example:"/pB8BM7pdSp6B6Ih7QZ4DrQ3PmJK.jpg"`
+	Rank            string    `json:"rank" example:"0001"`
+	Position        int32     `json:"position" example:"0"`
+	AddedDate       time.Time `json:"added_date" example:"2025-02-15T11:59:01Z"`
+	ItemCreatedDate time.Time `json:"item_created_date" example:"2025-02-15T11:59:01Z"`
+}
+
+// BoardIssue kinds reported by the board integrity checker
+const (
+	BoardIssueDuplicateRank = "duplicate_rank"
+	BoardIssueInvalidRank   = "invalid_rank"
+	BoardIssueLongRank      = "long_rank"
+	BoardIssueMissingStatus = "missing_status"
+	BoardIssueDetached      = "status_not_on_list"
+)
+
+// BoardIssue represents a single ordering problem found on a board
+type BoardIssue struct {
+	ListUUID     string `json:"list_uuid"`
+	StatusUUID   string `json:"status_uuid"`
+	ListItemUUID string `json:"list_item_uuid"`
+	Kind         string `json:"kind"`
+	Detail       string `json:"detail"`
+}
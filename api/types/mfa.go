@@ -0,0 +1,60 @@
+package types
+
+// MfaChallengeResponse represents the response to logging in with a password when the user has two-factor
+// authentication enabled
+//
+//	@Description	a short-lived token to exchange for access and refresh tokens along with a code from the
+//	@Description	user's authenticator app or one of their recovery codes
+type MfaChallengeResponse struct {
+	MfaRequired bool   `json:"mfa_required" example:"true"`
+	MfaToken    string `json:"mfa_token" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ExpiryDate  string `json:"expiry_date" example:"2025-02-15 11:04:01.837871 +0100 CET m=+303.614509085"`
+}
+
+// MfaLoginRequest represents the request body for finishing a login with a second factor
+//
+//	@Description	request body for finishing a login. code is a code from the authenticator app or a recovery code
+type MfaLoginRequest struct {
+	MfaToken string `json:"mfa_token" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" binding:"required"`
+	Code     string `json:"code" example:"123456" binding:"required"`
+}
+
+// TotpEnrollmentResponse represents a new TOTP secret waiting to be confirmed
+//
+//	@Description	a TOTP secret to add to an authenticator app, as text, as an otpauth URI, and as a QR code.
+//	@Description	qr_code is a base64 encoded PNG
+type TotpEnrollmentResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OtpauthURI string `json:"otpauth_uri" example:"otpauth://totp/eigakanban:test?algorithm=SHA1&digits=6&issuer=eigakanban&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	QrCode     []byte `json:"qr_code" swaggertype:"string" format:"byte"`
+}
+
+// TotpCodeRequest represents a request body containing a code from an authenticator app
+//
+//	@Description	request body containing a code from an authenticator app, or a recovery code where accepted
+type TotpCodeRequest struct {
+	Code string `json:"code" example:"123456" binding:"required"`
+}
+
+// DisableTotpRequest represents the request body for turning off two-factor authentication
+//
+//	@Description	request body for turning off two-factor authentication.
+//	@Description	code is a code from the authenticator app or a recovery code
+type DisableTotpRequest struct {
+	Password string `json:"password" example:"password" binding:"required"`
+	Code     string `json:"code" example:"123456" binding:"required"`
+}
+
+// RecoveryCodesResponse represents a new set of recovery codes. They are only ever shown once
+//
+//	@Description	one-time codes for logging in without the authenticator app. Earlier codes stop working
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"mbfhj-b724h,q7rte-9xk2m"`
+}
+
+// TotpDisabledResponse represents a success message for turning off two-factor authentication
+//
+//	@Description	A success message confirming two-factor authentication was turned off
+type TotpDisabledResponse struct {
+	Message string `json:"message" example:"two-factor authentication disabled"`
+}
@@ -0,0 +1,37 @@
+package types
+
+// ChangePasswordRequest represents the request body for changing the authenticated user's password
+//
+//	@Description	request body for changing a password. The current password must be given
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"password" binding:"required"`
+	NewPassword     string `json:"new_password" example:"correct horse battery staple" binding:"required"`
+}
+
+// ForgotPasswordRequest represents the request body for asking for a password reset link
+//
+//	@Description	request body for asking for a password reset link to be emailed
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"test@test.com" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents the request body for resetting a forgotten password
+//
+//	@Description	request body for setting a new password with the token from a reset link
+type ResetPasswordRequest struct {
+	Token       string `json:"token" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" binding:"required"`
+	NewPassword string `json:"new_password" example:"correct horse battery staple" binding:"required"`
+}
+
+// PasswordResetRequestedResponse the response for asking for a password reset link. It is the same whether
+// or not the email belongs to a user
+// @Description password reset link sent if the email belongs to a user
+type PasswordResetRequestedResponse struct {
+	Message string `json:"message" example:"if the email belongs to an account, a reset link has been sent to it"`
+}
+
+// PasswordResetResponse the response for resetting a password successfully
+// @Description password reset successfully
+type PasswordResetResponse struct {
+	Message string `json:"message" example:"password reset, please log in again"`
+}
@@ -0,0 +1,50 @@
+package types
+
+import "time"
+
+// ReviewResponse represents a review of an item
+//
+//	@Description	a review of an item. rating is null for reviews without a star rating
+type ReviewResponse struct {
+	UUID        string     `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	ItemUUID    string     `json:"item_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	ItemTitle   string     `json:"item_title" example:"Fight Club"`
+	UserUUID    string     `json:"user_uuid" example:"00000000-0000-0000-0000-000000000002"`
+	Username    string     `json:"username" example:"tyler"`
+	Content     string     `json:"content" example:"The first rule is you do not talk about it."`
+	Rating      *float64   `json:"rating" example:"4.5"`
+	CreatedDate time.Time  `json:"created_date" example:"2025-02-15T11:59:01Z"`
+	UpdatedDate *time.Time `json:"updated_date" example:"2025-02-16T11:59:01Z"`
+}
+
+// PaginatedReviewsResponse represents a paginated list of reviews
+//
+//	@Description	a paginated list of reviews, newest first
+type PaginatedReviewsResponse struct {
+	Pagination Pagination       `json:"pagination"`
+	Reviews    []ReviewResponse `json:"reviews"`
+}
+
+// AddReviewRequest represents the request body for reviewing an item
+//
+//	@Description	a request body for reviewing an item. The rating is out of five stars in half-star steps.
+//	@Description	A review needs content, a rating, or both
+type AddReviewRequest struct {
+	Content string   `json:"content" example:"The first rule is you do not talk about it."`
+	Rating  *float64 `json:"rating" example:"4.5" binding:"omitempty,min=0.5,max=5"`
+}
+
+// UpdateReviewRequest represents the request body for updating a review
+//
+//	@Description	a request body for updating a review. The content and rating replace the existing values
+type UpdateReviewRequest struct {
+	Content string   `json:"content" example:"Better on a second watch."`
+	Rating  *float64 `json:"rating" example:"5" binding:"omitempty,min=0.5,max=5"`
+}
+
+// ReviewDeletedResponse represents a success message for a review deletion
+//
+//	@Description	A success message confirming the review was deleted
+type ReviewDeletedResponse struct {
+	Message string `json:"success" example:"review deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"`
+}
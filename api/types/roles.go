@@ -0,0 +1,48 @@
+package types
+
+// Permissions that roles can grant. Permissions are read from the database on every request, so changes to
+// a user's roles take effect straight away
+const (
+	PermissionUsersList       = "users:list"
+	PermissionUsersEdit       = "users:edit"
+	PermissionUsersDelete     = "users:delete"
+	PermissionRolesAssign     = "roles:assign"
+	PermissionItemsEdit       = "items:edit"
+	PermissionItemsRefresh    = "items:refresh"
+	PermissionReviewsModerate = "reviews:moderate"
+)
+
+// Roles every installation has
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleMember    = "member"
+)
+
+// RoleResponse represents a role and the permissions it grants
+// @Description a named role and the permissions it grants
+type RoleResponse struct {
+	Name        string   `json:"name" example:"moderator"`
+	Description string   `json:"description" example:"Keeps the item catalogue and reviews tidy"`
+	Permissions []string `json:"permissions" example:"items:edit,items:refresh,reviews:moderate"`
+}
+
+// RolesResponse represents every role
+// @Description the roles that can be assigned to users
+type RolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// UserRolesResponse represents the roles assigned to a user
+// @Description the roles assigned to a user and the permissions they add up to
+type UserRolesResponse struct {
+	UserUUID    string   `json:"user_uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Roles       []string `json:"roles" example:"member,moderator"`
+	Permissions []string `json:"permissions" example:"items:edit,items:refresh,reviews:moderate"`
+}
+
+// AssignRoleRequest represents the request body for assigning a role to a user
+// @Description a request body for assigning a role to a user
+type AssignRoleRequest struct {
+	Role string `json:"role" example:"moderator" binding:"required"`
+}
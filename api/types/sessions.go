@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+// SessionClient describes the client a session was started or last refreshed from
+type SessionClient struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+// SessionResponse represents a session, which lasts from logging in until logging out or the refresh token
+// expiring
+// @Description a device the user is logged in on
+type SessionResponse struct {
+	ID           string    `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	DeviceName   *string   `json:"device_name" example:"Living room laptop"`
+	UserAgent    *string   `json:"user_agent" example:"Mozilla/5.0 (X11; Linux x86_64; rv:135.0) Gecko/20100101 Firefox/135.0"`
+	IPAddress    *string   `json:"ip_address" example:"192.0.2.1"`
+	LastUsedDate time.Time `json:"last_used_date" example:"2025-02-15T11:59:01Z"`
+	ExpiryDate   time.Time `json:"expiry_date" example:"2025-02-22T11:59:01Z"`
+	Current      bool      `json:"current" example:"true"`
+}
+
+// SessionsResponse represents a user's active sessions, most recently used first
+// @Description the devices a user is logged in on
+type SessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// RevokedSessionsResponse represents the result of logging out of other sessions
+// @Description the number of sessions that were logged out
+type RevokedSessionsResponse struct {
+	Revoked int64 `json:"revoked" example:"2"`
+}
+
+// SessionRevokedResponse represents a success message for logging out a session
+//
+//	@Description	A success message confirming the session was logged out
+type SessionRevokedResponse struct {
+	Message string `json:"success" example:"session revoked: 77b62cff-0020-43d9-a90c-5d35bff89f7a"`
+}
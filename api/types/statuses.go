@@ -19,3 +19,51 @@ type PaginatedStatusesResponse struct {
 	Statuses   []StatusesResponse `json:"statuses"`
 	Pagination Pagination         `json:"pagination"`
 }
+
+// UpdateStatusRequest represents the request body for renaming a status
+// @Description a request body for renaming a status
+type UpdateStatusRequest struct {
+	StatusLabel string `json:"label" example:"watching" binding:"required"`
+}
+
+// StatusDeletedResponse represents a success message for a status deletion
+//
+//	@Description	A success message confirming the status was deleted
+type StatusDeletedResponse struct {
+	Message string `json:"success" example:"status deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"`
+}
+
+// ListStatusResponse represents a status attached to a list as a column
+// @Description a status column on a list
+type ListStatusResponse struct {
+	UUID       string `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	StatusUUID string `json:"status_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	Label      string `json:"label" example:"backlog"`
+	Position   int32  `json:"position" example:"0"`
+	WipLimit   *int32 `json:"wip_limit" example:"5"`
+}
+
+// ListStatusesResponse represents the ordered status columns of a list
+// @Description the status columns of a list in board order
+type ListStatusesResponse struct {
+	ListUUID string               `json:"list_uuid" example:"00000000-0000-0000-0000-000000000000"`
+	Statuses []ListStatusResponse `json:"statuses"`
+}
+
+// AddListStatusRequest represents the request body for attaching a status to a list
+// @Description a request body for attaching a status to a list. The status is added as the last column
+type AddListStatusRequest struct {
+	StatusUUID string `json:"status_uuid" example:"00000000-0000-0000-0000-000000000001" binding:"required"`
+}
+
+// ReorderListStatusesRequest represents the request body for ordering the columns of a list
+// @Description a request body listing every status attached to a list in the desired column order
+type ReorderListStatusesRequest struct {
+	StatusUUIDs []string `json:"status_uuids" binding:"required,min=1"`
+}
+
+// UpdateListStatusRequest represents the request body for updating a status column on a list
+// @Description a request body for updating a status column. A null wip_limit removes the limit
+type UpdateListStatusRequest struct {
+	WipLimit *int32 `json:"wip_limit" example:"5" binding:"omitempty,min=1"`
+}
@@ -20,9 +20,11 @@ type PaginatedUsersResponse struct {
 
 // UpdateUserRequest represents the request body for updating a user
 //
-//	@Description	a request body for updating a user
+//	@Description	a request body for updating a user.
+//	@Description	changing the email marks it as unverified and sends a verification link to the new address
 type UpdateUserRequest struct {
 	NewUsername *string `json:"username" example:"new_username"`
+	NewEmail    *string `json:"email" example:"new@test.com" binding:"omitempty,email"`
 	NewName     *string `json:"full_name" example:"Tim Test"`
 	NewBio      *string `json:"bio" example:"This is a bio"`
 }
@@ -0,0 +1,41 @@
+package types
+
+// UnverifiedAccess is how much users who haven't verified their email address can do
+type UnverifiedAccess string
+
+const (
+	// UnverifiedAccessFull lets unverified users do everything verified users can
+	UnverifiedAccessFull UnverifiedAccess = "full"
+	// UnverifiedAccessReadOnly lets unverified users log in, but only make requests that don't change anything
+	// apart from managing their account
+	UnverifiedAccessReadOnly UnverifiedAccess = "read_only"
+	// UnverifiedAccessNone stops unverified users from logging in
+	UnverifiedAccessNone UnverifiedAccess = "none"
+)
+
+// VerifyEmailRequest represents the request body for verifying an email address
+//
+//	@Description	request body for verifying an email address with the token from a verification link
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" binding:"required"`
+}
+
+// ResendVerificationRequest represents the request body for asking for a new verification link
+//
+//	@Description	request body for asking for a new verification link to be emailed
+type ResendVerificationRequest struct {
+	Email string `json:"email" example:"test@test.com" binding:"required,email"`
+}
+
+// EmailVerifiedResponse the response for verifying an email address successfully
+// @Description email address verified
+type EmailVerifiedResponse struct {
+	Message string `json:"message" example:"email verified"`
+}
+
+// VerificationSentResponse the response for asking for a new verification link. It is the same whether or
+// not the email belongs to a user who needs one
+// @Description verification link sent if the email belongs to an unverified user
+type VerificationSentResponse struct {
+	Message string `json:"message" example:"if the email belongs to an unverified account, a verification link has been sent to it"`
+}
@@ -0,0 +1,77 @@
+package types
+
+import "time"
+
+// WatchResponse represents a diary entry for a single viewing of an item
+//
+//	@Description	a diary entry for a single viewing of an item.
+//	@Description	rating, review_uuid, location and format are null when they weren't recorded
+type WatchResponse struct {
+	UUID        string     `json:"uuid" example:"00000000-0000-0000-0000-000000000000"`
+	ItemUUID    string     `json:"item_uuid" example:"00000000-0000-0000-0000-000000000001"`
+	ItemTitle   string     `json:"item_title" example:"Fight Club"`
+	UserUUID    string     `json:"user_uuid" example:"00000000-0000-0000-0000-000000000002"`
+	Username    string     `json:"username" example:"tyler"`
+	WatchedDate string     `json:"watched_date" example:"2025-02-14"`
+	Rewatch     bool       `json:"rewatch" example:"false"`
+	Rating      *float64   `json:"rating" example:"4.5"`
+	ReviewUUID  *string    `json:"review_uuid" example:"00000000-0000-0000-0000-000000000003"`
+	Location    *string    `json:"location" example:"Prince Charles Cinema"`
+	Format      *string    `json:"format" example:"35mm"`
+	CreatedDate time.Time  `json:"created_date" example:"2025-02-15T11:59:01Z"`
+	UpdatedDate *time.Time `json:"updated_date" example:"2025-02-16T11:59:01Z"`
+}
+
+// PaginatedWatchesResponse represents a page of a user's diary
+//
+//	@Description	a paginated list of diary entries, most recently watched first
+type PaginatedWatchesResponse struct {
+	Pagination Pagination      `json:"pagination"`
+	Watches    []WatchResponse `json:"watches"`
+}
+
+// LogWatchRequest represents the request body for logging a viewing
+//
+//	@Description	a request body for logging a viewing of an item. The rating is out of five stars in half-star steps.
+//	@Description	review_uuid must be the authenticated user's review of the same item.
+//	@Description	set list_uuid and status_uuid to move the item's card on that list to the status, adding it to the list if needed.
+//	@Description	set ignore_wip_limit to move the card even if the status column is at its WIP limit
+type LogWatchRequest struct {
+	ItemUUID       string   `json:"item_uuid" example:"00000000-0000-0000-0000-000000000001" binding:"required"`
+	WatchedDate    string   `json:"watched_date" example:"2025-02-14" binding:"required,datetime=2006-01-02"`
+	Rewatch        bool     `json:"rewatch" example:"false"`
+	Rating         *float64 `json:"rating" example:"4.5" binding:"omitempty,min=0.5,max=5"`
+	ReviewUUID     string   `json:"review_uuid" example:"00000000-0000-0000-0000-000000000003"`
+	Location       string   `json:"location" example:"Prince Charles Cinema"`
+	Format         string   `json:"format" example:"35mm"`
+	ListUUID       string   `json:"list_uuid" example:"00000000-0000-0000-0000-000000000004" binding:"required_with=StatusUUID"`
+	StatusUUID     string   `json:"status_uuid" example:"00000000-0000-0000-0000-000000000005" binding:"required_with=ListUUID"`
+	IgnoreWipLimit bool     `json:"ignore_wip_limit" example:"false"`
+}
+
+// UpdateWatchRequest represents the request body for updating a diary entry
+//
+//	@Description	a request body for updating a diary entry. The fields replace the existing values
+type UpdateWatchRequest struct {
+	WatchedDate string   `json:"watched_date" example:"2025-02-14" binding:"required,datetime=2006-01-02"`
+	Rewatch     bool     `json:"rewatch" example:"true"`
+	Rating      *float64 `json:"rating" example:"5" binding:"omitempty,min=0.5,max=5"`
+	ReviewUUID  string   `json:"review_uuid" example:"00000000-0000-0000-0000-000000000003"`
+	Location    string   `json:"location" example:"Home"`
+	Format      string   `json:"format" example:"Blu-ray"`
+}
+
+// WatchLoggedResponse represents a newly logged viewing
+//
+//	@Description	the new diary entry, and the item's card if it was moved on a list
+type WatchLoggedResponse struct {
+	Watch    WatchResponse      `json:"watch"`
+	ListItem *ListItemsResponse `json:"list_item,omitempty"`
+}
+
+// WatchDeletedResponse represents a success message for a diary entry deletion
+//
+//	@Description	A success message confirming the diary entry was deleted
+type WatchDeletedResponse struct {
+	Message string `json:"success" example:"watch deleted: 77b62cff-0020-43d9-a90c-5d35bff89f7a"`
+}
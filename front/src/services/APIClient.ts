@@ -16,6 +16,10 @@ export class APIClient {
   private accessToken: string = "";
   private refreshToken: string = "";
   private expiryDate: string = "";
+  private onTokensRefreshed?: (accessToken: string, refreshToken: string, expiryDate: string) => void;
+  // The refresh in progress, if any. Requests that fail together share it, because sending the same
+  // refresh token twice looks like a stolen token to the server and logs the user out
+  private pendingRefresh: Promise<string | null> | null = null;
 
   constructor() {
     // Access tokens should be managed in Pinia
@@ -27,6 +31,10 @@ export class APIClient {
     this.expiryDate = expiryDate;
   }
 
+  public setOnTokensRefreshed(callback: (accessToken: string, refreshToken: string, expiryDate: string) => void): void {
+    this.onTokensRefreshed = callback;
+  }
+
   public clearTokens(): void {
     this.accessToken = "";
     this.refreshToken = "";
@@ -45,6 +53,7 @@ export class APIClient {
     { method = "GET", body = null, queryParams = {}, headers = new Headers() }: ClientOptions = {}
   ): Promise<any> {
     headers = this.buildHeaders(headers);
+    const sentAccessToken = this.accessToken;
 
     const queryString = Object.keys(queryParams)
       .map((key) => `${encodeURIComponent(key)}=${encodeURIComponent(queryParams[key])}`)
@@ -63,9 +72,10 @@ export class APIClient {
     });
 
     if (response.status === 401) {
-      const newAccessToken = await this.getNewAccessToken();
+      // Another request may have refreshed the tokens while this one was in flight
+      const newAccessToken =
+        this.accessToken && this.accessToken !== sentAccessToken ? this.accessToken : await this.getNewAccessToken();
       if (newAccessToken) {
-        this.setTokens(newAccessToken, this.refreshToken, this.expiryDate);
         headers.set("Authorization", `Bearer ${newAccessToken}`);
 
         // Retry the request with the new access token
@@ -88,13 +98,32 @@ export class APIClient {
     return response.json();
   }
 
-  private async getNewAccessToken(): Promise<string | null> {
-    const response = await this.request("/auth/refresh", {
+  private getNewAccessToken(): Promise<string | null> {
+    if (!this.pendingRefresh) {
+      this.pendingRefresh = this.refreshTokens().finally(() => {
+        this.pendingRefresh = null;
+      });
+    }
+    return this.pendingRefresh;
+  }
+
+  private async refreshTokens(): Promise<string | null> {
+    // Call fetch directly so that a rejected refresh token doesn't trigger another refresh
+    const response = await fetch(`${this.baseUrl}/auth/refresh`, {
       method: "POST",
-      headers: new Headers({ "Refresh_Token": this.refreshToken }),
+      headers: new Headers({ "Refresh-Token": this.refreshToken }),
     });
 
-    return response?.access_token || null;
+    if (!response.ok) {
+      return null;
+    }
+
+    // Refresh tokens can only be used once, so the new one has to be kept
+    const tokens = await response.json();
+    this.setTokens(tokens.access_token, tokens.refresh_token, tokens.expiry_date);
+    this.onTokensRefreshed?.(tokens.access_token, tokens.refresh_token, tokens.expiry_date);
+
+    return tokens.access_token;
   }
 
   public async login(credential: string, password: string): Promise<any> {
@@ -109,6 +138,13 @@ export class APIClient {
     });
   }
 
+  public async loginMfa(mfaToken: string, code: string): Promise<any> {
+    return await this.request("/auth/login/mfa", {
+      method: "POST",
+      body: { mfa_token: mfaToken, code },
+    });
+  }
+
   public async logout(): Promise<void> {
     await this.request("/auth/logout", {
       method: "POST",
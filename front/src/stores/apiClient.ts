@@ -7,6 +7,7 @@ export const useApiClientStore = defineStore("auth", {
     accessToken: "",
     refreshToken: "",
     expiryDate: "",
+    mfaToken: "",
     apiClient: new APIClient(),
   }),
 
@@ -17,6 +18,11 @@ export const useApiClientStore = defineStore("auth", {
       this.refreshToken = cookies.get("refresh_token") || "";
       this.expiryDate = sessionStorage.getItem("access_token_expiry") || "";
       this.apiClient.setTokens(this.accessToken, this.refreshToken, this.expiryDate);
+      this.apiClient.setOnTokensRefreshed((accessToken, refreshToken, expiryDate) => {
+        this.setAccessToken(accessToken);
+        this.setExpiryDate(expiryDate);
+        this.setRefreshToken(refreshToken);
+      });
     },
 
     setAccessToken(token: string) {
@@ -43,10 +49,16 @@ export const useApiClientStore = defineStore("auth", {
      * @param credential The username or email of the user
      * @param password The password of the user
      */
-    async login(credential: string, password: string): Promise<{ success: boolean; status: string }> {
+    async login(credential: string, password: string): Promise<{ success: boolean; mfaRequired?: boolean; status: string }> {
       try {
         const response = await this.apiClient.login(credential, password);
 
+        // Users with two-factor authentication finish logging in with a code
+        if (response && response.mfa_required) {
+          this.mfaToken = response.mfa_token;
+          return { success: false, mfaRequired: true, status: "Enter a code from your authenticator app" };
+        }
+
         if (response && response.access_token) {
           this.setAccessToken(response.access_token);
           this.setExpiryDate(response.expiry_date);
@@ -61,6 +73,29 @@ export const useApiClientStore = defineStore("auth", {
       }
     },
 
+    /**
+     * Finishes logging in a user with two-factor authentication
+     * @param code A code from the user's authenticator app, or one of their recovery codes
+     */
+    async loginMfa(code: string): Promise<{ success: boolean; status: string }> {
+      try {
+        const response = await this.apiClient.loginMfa(this.mfaToken, code);
+
+        if (response && response.access_token) {
+          this.mfaToken = "";
+          this.setAccessToken(response.access_token);
+          this.setExpiryDate(response.expiry_date);
+          this.setRefreshToken(response.refresh_token);
+
+          return { success: true, status: "Logged in successfully" };
+        } else {
+          return { success: false, status: "Failed to login: Invalid code" };
+        }
+      } catch (e: any) {
+        return { success: false, status: `Failed to log in: ${e.message || e}` };
+      }
+    },
+
     async logout() {
       await this.apiClient.logout();
       this.$reset();